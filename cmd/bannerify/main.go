@@ -2,8 +2,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -18,13 +20,18 @@ import (
 	"github.com/swaggo/http-swagger"
 	"github.com/swaggo/swag"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"github.com/PoorMercymain/bannerify/internal/bannerify/config"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
 	"github.com/PoorMercymain/bannerify/internal/bannerify/handlers"
 	"github.com/PoorMercymain/bannerify/internal/bannerify/middleware"
 	"github.com/PoorMercymain/bannerify/internal/bannerify/repository"
 	"github.com/PoorMercymain/bannerify/internal/bannerify/service"
+	"github.com/PoorMercymain/bannerify/pkg/api"
+	"github.com/PoorMercymain/bannerify/pkg/breaker"
 	"github.com/PoorMercymain/bannerify/pkg/logger"
+	"github.com/PoorMercymain/bannerify/pkg/lru"
 )
 
 func main() {
@@ -73,19 +80,39 @@ func main() {
 	logger.Logger().Infoln("Postgres connection pool created")
 
 	pg := repository.NewPostgres(pool)
-	redis, err := repository.NewCache(cfg.CachePort)
+	bannerCache, err := newBannerCache(&cfg)
 	if err != nil {
 		logger.Logger().Fatalln(zap.Error(err))
 	}
 
+	logger.Logger().Infoln("Using", cfg.CacheBackend, "cache backend")
+
+	outboxSink, err := newOutboxSink(&cfg)
+	if err != nil {
+		logger.Logger().Fatalln(zap.Error(err))
+	}
+
+	logger.Logger().Infoln("Using", cfg.OutboxSink, "outbox sink")
+
 	var wg sync.WaitGroup
 
 	pingProviderRepository := repository.NewPingProvider(pg)
-	getterRepository := repository.NewGetter(pg, redis)
-	versionerRepository := repository.NewVersioner(pg)
-	creatorRepository := repository.NewCreator(pg)
-	updaterRepository := repository.NewUpdater(pg)
-	deleterRepository := repository.NewDeleter(pg, &wg, cfg.DeleteWorkersAmount)
+	l1 := lru.New[string, domain.UserBanner](cfg.L1CacheMaxEntries, cfg.L1CacheMaxBytes, cfg.L1CacheTTL)
+	dbBreaker := breaker.New(cfg.DBBreakerThreshold, cfg.DBBreakerTimeout)
+	getterRepository := repository.NewGetter(pg, bannerCache, l1, dbBreaker, cfg.StaleCacheTTL, cfg.MissingCacheTTL, newShowCounter(bannerCache))
+	versionerRepository := repository.NewVersioner(pg, bannerCache)
+	creatorRepository := repository.NewCreator(pg, bannerCache)
+	updaterRepository := repository.NewUpdater(pg, bannerCache)
+	deleterRepository := repository.NewDeleter(pg, bannerCache, &wg, cfg.DeleteWorkersAmount)
+	tagPrioritizerRepository := repository.NewTagPrioritizer(pg, bannerCache)
+	experimenterRepository := repository.NewExperimenter(pg, bannerCache)
+	featureRegistryRepository := repository.NewFeatureRegistry(pg, bannerCache)
+	eventTrackerRepository := repository.NewEventTracker(pg, cfg.EventsFlushInterval, cfg.EventsBatchSize, cfg.EventsMaxPending)
+	changeStreamerRepository := repository.NewChangeStreamer(pg, cfg.ChangesRetention)
+	webhookManagerRepository := repository.NewWebhookManager(pg)
+	auditorRepository := repository.NewAuditor(pg)
+	webhookDispatcher := repository.NewWebhookDispatcher(pg, cfg.WebhookTimeout, cfg.WebhookMaxAttempts, cfg.WebhookBackoffBase, cfg.WebhookBackoffMax, cfg.WebhookPollInterval)
+	outboxRelay := repository.NewOutboxRelay(pg, outboxSink, cfg.OutboxPollInterval, cfg.OutboxRetention)
 
 	pingProviderService := service.NewPingProvider(pingProviderRepository)
 	getterService := service.NewGetter(getterRepository)
@@ -93,6 +120,13 @@ func main() {
 	creatorService := service.NewCreator(creatorRepository)
 	updaterService := service.NewUpdater(updaterRepository)
 	deleterService := service.NewDeleter(deleterRepository)
+	tagPrioritizerService := service.NewTagPrioritizer(tagPrioritizerRepository)
+	experimenterService := service.NewExperimenter(experimenterRepository)
+	featureRegistryService := service.NewFeatureRegistry(featureRegistryRepository)
+	eventTrackerService := service.NewEventTracker(eventTrackerRepository)
+	changeStreamerService := service.NewChangeStreamer(changeStreamerRepository)
+	webhookManagerService := service.NewWebhookManager(webhookManagerRepository)
+	auditorService := service.NewAuditor(auditorRepository)
 
 	pingProviderHandler := handlers.NewPingProvider(pingProviderService)
 	getterHandler := handlers.NewGetter(getterService)
@@ -100,6 +134,13 @@ func main() {
 	creatorHandler := handlers.NewCreator(creatorService)
 	updaterHandler := handlers.NewUpdater(updaterService)
 	deleterHandler := handlers.NewDeleter(deleterService)
+	tagPrioritizerHandler := handlers.NewTagPrioritizer(tagPrioritizerService)
+	experimenterHandler := handlers.NewExperimenter(experimenterService)
+	featureRegistryHandler := handlers.NewFeatureRegistry(featureRegistryService)
+	eventTrackerHandler := handlers.NewEventTracker(eventTrackerService)
+	changeStreamerHandler := handlers.NewChangeStreamer(changeStreamerService, getterService)
+	webhookManagerHandler := handlers.NewWebhookManager(webhookManagerService)
+	auditorHandler := handlers.NewAuditor(auditorService)
 
 	authRepository := repository.NewAuthorization(pg)
 	authService := service.NewAuthorization(authRepository)
@@ -107,6 +148,45 @@ func main() {
 
 	deleteCtx, cancelDeleteCtx := context.WithCancel(context.Background())
 
+	invalidationsCtx, cancelInvalidationsCtx := context.WithCancel(context.Background())
+	defer cancelInvalidationsCtx()
+
+	go func() {
+		if err := getterRepository.ListenInvalidations(invalidationsCtx); err != nil {
+			logger.Logger().Errorln("Failed to listen for cache invalidations:", zap.Error(err))
+		}
+	}()
+
+	eventsCtx, cancelEventsCtx := context.WithCancel(context.Background())
+	eventsFlushed := make(chan struct{})
+
+	go func() {
+		eventTrackerRepository.FlushEvents(eventsCtx)
+		close(eventsFlushed)
+	}()
+
+	changesCtx, cancelChangesCtx := context.WithCancel(context.Background())
+	defer cancelChangesCtx()
+
+	go changeStreamerRepository.ListenChanges(changesCtx)
+	go changeStreamerRepository.PruneChanges(changesCtx)
+
+	webhooksCtx, cancelWebhooksCtx := context.WithCancel(context.Background())
+	webhooksStopped := make(chan struct{})
+
+	go func() {
+		webhookDispatcher.DeliverWebhooks(webhooksCtx)
+		close(webhooksStopped)
+	}()
+
+	outboxCtx, cancelOutboxCtx := context.WithCancel(context.Background())
+	outboxStopped := make(chan struct{})
+
+	go func() {
+		outboxRelay.RelayOutbox(outboxCtx)
+		close(outboxStopped)
+	}()
+
 	mux := http.NewServeMux()
 
 	mux.Handle("GET /ping", middleware.Log(middleware.AdminRequired(http.HandlerFunc(pingProviderHandler.Ping), authHandler.JWTKey)))
@@ -115,20 +195,69 @@ func main() {
 	mux.Handle("POST /acquire-token", middleware.Log(http.HandlerFunc(authHandler.LogIn)))
 
 	mux.Handle("GET /user_banner", middleware.Log(middleware.ProvideIsAdmin(getterHandler.GetBanner, authHandler.JWTKey)))
-	mux.Handle("GET /banner", middleware.Log(middleware.AdminRequired(http.HandlerFunc(getterHandler.ListBanners), authHandler.JWTKey)))
-	mux.Handle("GET /banner_versions/{banner_id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(versionerHandler.ListVersions), authHandler.JWTKey)))
+	mux.Handle("GET /user_banners", middleware.Log(middleware.Compress(middleware.ProvideIsAdmin(getterHandler.GetBanners, authHandler.JWTKey))))
+	mux.Handle("GET /cache_stats", middleware.Log(middleware.AdminRequired(http.HandlerFunc(getterHandler.CacheStats), authHandler.JWTKey)))
+	mux.Handle("POST /cache_warmup", middleware.Log(middleware.AdminRequired(http.HandlerFunc(getterHandler.WarmUp), authHandler.JWTKey)))
+	mux.Handle("GET /banner", middleware.Log(middleware.Compress(middleware.AdminRequired(http.HandlerFunc(getterHandler.ListBanners), authHandler.JWTKey))))
+	mux.Handle("GET /banner_versions/{banner_id}", middleware.Log(middleware.Compress(middleware.AdminRequired(http.HandlerFunc(versionerHandler.ListVersions), authHandler.JWTKey))))
 	mux.Handle("PATCH /banner_versions/choose/{banner_id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(versionerHandler.ChooseVersion), authHandler.JWTKey)))
 	mux.Handle("POST /banner", middleware.Log(middleware.AdminRequired(http.HandlerFunc(creatorHandler.CreateBanner), authHandler.JWTKey)))
 	mux.Handle("PATCH /banner/{id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(updaterHandler.UpdateBanner), authHandler.JWTKey)))
 	mux.Handle("DELETE /banner/{id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(deleterHandler.DeleteBannerByID), authHandler.JWTKey)))
 	mux.Handle("DELETE /banner", middleware.Log(middleware.AdminRequired(http.HandlerFunc(deleterHandler.DeleteBannerByTagOrFeature(deleteCtx, &wg)), authHandler.JWTKey)))
+	mux.Handle("PUT /tag_priority/{tag_id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(tagPrioritizerHandler.SetTagPriority), authHandler.JWTKey)))
+	mux.Handle("POST /experiment", middleware.Log(middleware.AdminRequired(http.HandlerFunc(experimenterHandler.CreateExperiment), authHandler.JWTKey)))
+	mux.Handle("GET /experiment", middleware.Log(middleware.AdminRequired(http.HandlerFunc(experimenterHandler.ListExperiments), authHandler.JWTKey)))
+	mux.Handle("PATCH /experiment/{id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(experimenterHandler.UpdateExperiment), authHandler.JWTKey)))
+	mux.Handle("DELETE /experiment/{id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(experimenterHandler.DeleteExperiment), authHandler.JWTKey)))
+	mux.Handle("PUT /feature/{id}/schema", middleware.Log(middleware.AdminRequired(http.HandlerFunc(featureRegistryHandler.SetFeatureSchema), authHandler.JWTKey)))
+	mux.Handle("GET /feature/{id}/schema", middleware.Log(middleware.AdminRequired(http.HandlerFunc(featureRegistryHandler.GetFeatureSchema), authHandler.JWTKey)))
+	mux.Handle("DELETE /feature/{id}/schema", middleware.Log(middleware.AdminRequired(http.HandlerFunc(featureRegistryHandler.DeleteFeatureSchema), authHandler.JWTKey)))
+	mux.Handle("POST /feature/{id}/schema/check", middleware.Log(middleware.Compress(middleware.AdminRequired(http.HandlerFunc(featureRegistryHandler.CheckFeatureSchema), authHandler.JWTKey))))
+	mux.Handle("PUT /feature/{id}/fallback", middleware.Log(middleware.AdminRequired(http.HandlerFunc(featureRegistryHandler.SetFeatureFallback), authHandler.JWTKey)))
+	mux.Handle("GET /feature/{id}/fallback", middleware.Log(middleware.AdminRequired(http.HandlerFunc(featureRegistryHandler.GetFeatureFallback), authHandler.JWTKey)))
+	mux.Handle("DELETE /feature/{id}/fallback", middleware.Log(middleware.AdminRequired(http.HandlerFunc(featureRegistryHandler.DeleteFeatureFallback), authHandler.JWTKey)))
+	mux.Handle("POST /banner_events", middleware.Log(middleware.AuthorizationRequired(http.HandlerFunc(eventTrackerHandler.RecordEvents), authHandler.JWTKey)))
+	mux.Handle("GET /banner_stats", middleware.Log(middleware.Compress(middleware.AdminRequired(http.HandlerFunc(eventTrackerHandler.GetBannerStats), authHandler.JWTKey))))
+	mux.Handle("GET /banner_stream", middleware.Log(middleware.ProvideIsAdmin(changeStreamerHandler.StreamBanners, authHandler.JWTKey)))
+	mux.Handle("POST /webhook", middleware.Log(middleware.AdminRequired(http.HandlerFunc(webhookManagerHandler.CreateWebhook), authHandler.JWTKey)))
+	mux.Handle("GET /webhook", middleware.Log(middleware.AdminRequired(http.HandlerFunc(webhookManagerHandler.ListWebhooks), authHandler.JWTKey)))
+	mux.Handle("PATCH /webhook/{id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(webhookManagerHandler.UpdateWebhook), authHandler.JWTKey)))
+	mux.Handle("DELETE /webhook/{id}", middleware.Log(middleware.AdminRequired(http.HandlerFunc(webhookManagerHandler.DeleteWebhook), authHandler.JWTKey)))
+	mux.Handle("GET /webhook/{id}/deliveries", middleware.Log(middleware.Compress(middleware.AdminRequired(http.HandlerFunc(webhookManagerHandler.ListDeliveries), authHandler.JWTKey))))
+	mux.Handle("GET /audit", middleware.Log(middleware.Compress(middleware.AdminRequired(http.HandlerFunc(auditorHandler.SearchAudit), authHandler.JWTKey))))
 	mux.Handle("/swagger/*", httpSwagger.WrapHandler)
 
+	if cfg.WarmUpOnStart {
+		warmUpCtx, cancelWarmUpCtx := context.WithTimeout(context.Background(), cfg.WarmUpTimeout)
+		warmed, err := getterRepository.WarmUp(warmUpCtx)
+		cancelWarmUpCtx()
+		if err != nil {
+			logger.Logger().Errorln("Cache warm-up failed after", warmed, "banners:", zap.Error(err))
+		} else {
+			logger.Logger().Infoln("Cache warmed up with", warmed, "banners")
+		}
+	}
 
 	server := &http.Server{
 		Addr:     fmt.Sprintf("%s:%d", cfg.ServiceHost, cfg.ServicePort),
 		ErrorLog: log.New(logger.Logger(), "", 0),
-		Handler:  mux,
+		Handler:  middleware.RequestID(mux),
+	}
+
+	// the streams are never idle, so they are ended for the shutdown not to wait for them
+	server.RegisterOnShutdown(cancelChangesCtx)
+
+	grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		middleware.GRPCRequestID,
+		middleware.GRPCLog,
+		middleware.GRPCAuthorization(authHandler.JWTKey, api.BannerService_GetBanner_FullMethodName),
+	))
+	api.RegisterBannerServiceServer(grpcServer, handlers.NewBannerServer(getterService, versionerService, creatorService, updaterService, deleterService, deleteCtx, middleware.IsAdmin))
+
+	grpcListener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", cfg.ServiceHost, cfg.GRPCPort))
+	if err != nil {
+		logger.Logger().Fatalln("Failed to listen for gRPC:", zap.Error(err))
 	}
 
 	go func() {
@@ -138,6 +267,13 @@ func main() {
 		}
 	}()
 
+	go func() {
+		logger.Logger().Infoln("gRPC server started, listening on port", cfg.GRPCPort)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logger.Logger().Fatalln("gRPC Serve failed", zap.Error(err))
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -153,6 +289,25 @@ func main() {
 		logger.Logger().Fatalln("Server was forced to shutdown:", zap.Error(err))
 	}
 
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
+	select {
+	case <-grpcStopped:
+	case <-ctx.Done():
+		grpcServer.Stop()
+	}
+
+	cancelEventsCtx()
+	<-eventsFlushed
+
+	// the deliveries interrupted here are sent again after their lease expires
+	cancelWebhooksCtx()
+	<-webhooksStopped
+
 	waitGroupChan := make(chan struct{})
 	go func() {
 		wg.Wait()
@@ -167,5 +322,57 @@ func main() {
 		logger.Logger().Infoln("Some of delete goroutines have not completed their job due to shutdown timeout")
 	}
 
+	// the events left in the outbox are published by the next instance to start
+	cancelOutboxCtx()
+	<-outboxStopped
+
 	logger.Logger().Infoln("Server was shut down")
 }
+
+// newShowCounter counts the shows of the banners with frequency caps in Redis if the banners are cached there,
+// otherwise the counters are kept in the memory of the instance.
+func newShowCounter(bannerCache domain.BannerCache) domain.ShowCounter {
+	if counter, ok := bannerCache.(domain.ShowCounter); ok {
+		return counter
+	}
+
+	return repository.NewMemoryShowCounter()
+}
+
+func newOutboxSink(cfg *config.Config) (domain.OutboxSink, error) {
+	switch cfg.OutboxSink {
+	case "log":
+		return repository.NewLogSink(), nil
+	case "http":
+		if cfg.OutboxHTTPURL == "" {
+			return nil, errors.New("OUTBOX_HTTP_URL is required for the http outbox sink")
+		}
+
+		return repository.NewHTTPSink(cfg.OutboxHTTPURL, cfg.OutboxHTTPTimeout), nil
+	default:
+		return nil, fmt.Errorf("unknown outbox sink %q", cfg.OutboxSink)
+	}
+}
+
+func newBannerCache(cfg *config.Config) (domain.BannerCache, error) {
+	switch cfg.CacheBackend {
+	case "redis":
+		redisOptions, err := cfg.RedisOptions()
+		if err != nil {
+			return nil, err
+		}
+
+		redisCache, err := repository.NewRedisCache(redisOptions, cfg.RedisCluster)
+		if err != nil {
+			return nil, err
+		}
+
+		return redisCache, nil
+	case "memory":
+		return repository.NewMemoryCache(cfg.MemoryCacheMaxEntries, cfg.MemoryCacheMaxBytes), nil
+	case "disabled":
+		return repository.NewNopCache(), nil
+	default:
+		return nil, fmt.Errorf("unknown cache backend %q", cfg.CacheBackend)
+	}
+}
@@ -238,9 +238,143 @@ const DocTemplate = `{
         }
       }
     },
+    "/cache_stats": {
+      "get": {
+        "description": "Счетчики кэша в памяти процесса, который проверяется перед Redis при получении баннеров пользователем",
+        "tags": [
+          "Banners"
+        ],
+        "summary": "Статистика кэша в памяти",
+        "parameters": [
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Статистика кэша",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "hits": {
+                      "type": "integer",
+                      "description": "Количество попаданий в кэш"
+                    },
+                    "misses": {
+                      "type": "integer",
+                      "description": "Количество промахов"
+                    },
+                    "evictions": {
+                      "type": "integer",
+                      "description": "Количество вытеснений из-за ограничений размера"
+                    },
+                    "entries": {
+                      "type": "integer",
+                      "description": "Текущее количество записей"
+                    },
+                    "bytes": {
+                      "type": "integer",
+                      "description": "Примерный текущий объем записей в байтах"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          }
+        }
+      }
+    },
+    "/cache_warmup": {
+      "post": {
+        "description": "Загрузка в кэш баннеров всех выбранных, активных и находящихся в окне показа пар фича-тег (так же, как при запуске сервиса). Используется, например, после очистки Redis",
+        "tags": [
+          "Banners"
+        ],
+        "summary": "Прогрев кэша",
+        "parameters": [
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Кэш прогрет",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "warmed": {
+                      "type": "integer",
+                      "description": "Количество баннеров, записанных в кэш"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "409": {
+            "description": "Прогрев кэша уже выполняется",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
     "/user_banner": {
       "get": {
-        "description": "Запрос получения активного баннера пользователем (если токен админа - то выдаст даже неактивный) с применением кэширования, если не передан use_last_revision=true в query",
+        "description": "Запрос получения активного баннера пользователем (если токен админа - то выдаст даже неактивный или находящийся вне окна показа active_from/active_until) с применением кэширования, если не передан use_last_revision=true в query. Пользователь может состоять в нескольких тегах (tag_id через запятую или несколькими параметрами), тогда из выбранных баннеров фичи побеждает баннер тега с наибольшим приоритетом (теги без заданного приоритета имеют приоритет 0), а при равенстве приоритетов - тега с наименьшим ID. Баннер с правилом таргетинга побеждает, только если правило подходит атрибутам пользователя, иначе выдается баннер следующего тега. Баннер с ограничением частоты (frequency_cap) выдается пользователю с user_id не больше заданного числа раз в сутки, затем выдается баннер следующего тега, баннер по умолчанию фичи или 404 (на админов ограничение не действует)",
         "tags": [
           "Banners"
         ],
@@ -251,8 +385,9 @@ const DocTemplate = `{
             "name": "tag_id",
             "required": true,
             "schema": {
-              "type": "integer",
-              "description": "Тэг пользователя"
+              "type": "string",
+              "description": "Тэг или теги пользователя через запятую (не более 100)",
+              "example": "1,2"
             }
           },
           {
@@ -274,6 +409,55 @@ const DocTemplate = `{
               "description": "Получать актуальную информацию"
             }
           },
+          {
+            "in": "query",
+            "name": "user_id",
+            "required": false,
+            "schema": {
+              "type": "string",
+              "description": "Идентификатор пользователя (не длиннее 256 символов), по которому выбирается вариант A/B-эксперимента и считаются показы баннеров с ограничением частоты. Без него выдается выбранный баннер пары, а ограничение частоты не применяется",
+              "example": "user-42"
+            }
+          },
+          {
+            "in": "query",
+            "name": "attributes",
+            "required": false,
+            "style": "form",
+            "explode": true,
+            "schema": {
+              "type": "object",
+              "additionalProperties": {
+                "type": "string"
+              },
+              "description": "Атрибуты пользователя для правил таргетинга и переменные шаблонов (не более 32, значения не длиннее 256 символов): любые параметры запроса, кроме параметров эндпойнта, или заголовки X-User-*, например X-User-App-Version задает атрибут app_version. Если переменная шаблона с missing_variables=error не передана, возвращается 400",
+              "example": {
+                "platform": "ios",
+                "app_version": "2.3.0",
+                "country": "DE"
+              }
+            }
+          },
+          {
+            "in": "query",
+            "name": "locale",
+            "required": false,
+            "schema": {
+              "type": "string",
+              "description": "Предпочтительный язык содержимого, важнее заголовка Accept-Language",
+              "example": "de-AT"
+            }
+          },
+          {
+            "in": "header",
+            "name": "Accept-Language",
+            "required": false,
+            "schema": {
+              "type": "string",
+              "description": "Предпочтительные языки содержимого. Если у баннера нет подходящего перевода, выдается содержимое на языке по умолчанию",
+              "example": "de-AT, de;q=0.9, en;q=0.8"
+            }
+          },
           {
             "in": "header",
             "name": "token",
@@ -282,11 +466,95 @@ const DocTemplate = `{
               "type": "string",
               "example": "user_token"
             }
+          },
+          {
+            "in": "header",
+            "name": "Accept-Encoding",
+            "required": false,
+            "description": "Поддерживаемые клиентом способы сжатия ответа (br, gzip)",
+            "schema": {
+              "type": "string",
+              "example": "gzip, br"
+            }
+          },
+          {
+            "in": "header",
+            "name": "If-None-Match",
+            "required": false,
+            "description": "ETag ранее полученного баннера",
+            "schema": {
+              "type": "string",
+              "example": "\"5d41402abc4b2a76b9719d911017c592\""
+            }
           }
         ],
         "responses": {
           "200": {
             "description": "Баннер пользователя",
+            "headers": {
+              "ETag": {
+                "description": "Идентификатор версии содержимого баннера",
+                "schema": {
+                  "type": "string"
+                }
+              },
+              "Cache-Control": {
+                "description": "Время, в течение которого ответ можно переиспользовать (не более 5 минут), или no-cache при use_last_revision=true и для баннеров с ограничением частоты показов",
+                "schema": {
+                  "type": "string",
+                  "example": "public, max-age=300"
+                }
+              },
+              "Warning": {
+                "description": "Выставляется, если БД недоступна и баннер выдан из последней сохраненной копии",
+                "schema": {
+                  "type": "string",
+                  "example": "110 - \"Response is Stale\""
+                }
+              },
+              "X-Experiment-Variant": {
+                "description": "Ключ варианта A/B-эксперимента, из которого выдан баннер",
+                "schema": {
+                  "type": "string",
+                  "example": "b"
+                }
+              },
+              "X-Banner-Fallback": {
+                "description": "Выставляется, если для тегов нет видимого баннера и выдан баннер по умолчанию фичи",
+                "schema": {
+                  "type": "string",
+                  "example": "true"
+                }
+              },
+              "X-Banner-ID": {
+                "description": "Идентификатор выданного баннера, используется в событиях показов и кликов",
+                "schema": {
+                  "type": "integer",
+                  "example": 1
+                }
+              },
+              "X-Banner-Version-ID": {
+                "description": "Идентификатор выданной версии баннера",
+                "schema": {
+                  "type": "integer",
+                  "example": 1
+                }
+              },
+              "X-Banner-Tag-ID": {
+                "description": "Идентификатор тега, по которому выдан баннер, не выставляется для баннера по умолчанию фичи",
+                "schema": {
+                  "type": "integer",
+                  "example": 1
+                }
+              },
+              "Content-Language": {
+                "description": "Язык выданного содержимого, если он известен",
+                "schema": {
+                  "type": "string",
+                  "example": "de"
+                }
+              }
+            },
             "content": {
               "application/json": {
                 "schema": {
@@ -298,6 +566,9 @@ const DocTemplate = `{
               }
             }
           },
+          "304": {
+            "description": "Баннер не изменился с момента получения ETag из If-None-Match"
+          },
           "400": {
             "description": "Некорректные данные",
             "content": {
@@ -340,21 +611,2355 @@ const DocTemplate = `{
         }
       }
     },
-    "/banner": {
+    "/user_banners": {
       "get": {
-        "description": "Запрос для получения всех баннеров c фильтрацией по фиче и/или тегу, если не указано ни то не другое - выдаются все баннеры, лимит по умолчанию 15, оффсет - 0, максимальный лимит - 100",
+        "description": "Запрос получения баннеров пользователем сразу для нескольких фич (feature_id можно передать через запятую или несколькими параметрами, не более 100). Теги выбираются по тем же правилам приоритета, что и в /user_banner. Отсутствующие или выключенные баннеры не приводят к ошибке всего запроса, а возвращаются с полем error для соответствующей фичи",
         "tags": [
           "Banners"
         ],
-        "summary": "Получение всех баннеров c фильтрацией по фиче и/или тегу",
+        "summary": "Получение баннеров пользователя для нескольких фич",
         "parameters": [
+          {
+            "in": "query",
+            "name": "tag_id",
+            "required": true,
+            "schema": {
+              "type": "string",
+              "description": "Тэг или теги пользователя через запятую (не более 100)",
+              "example": "1,2"
+            }
+          },
+          {
+            "in": "query",
+            "name": "feature_id",
+            "required": true,
+            "schema": {
+              "type": "string",
+              "description": "Идентификаторы фич через запятую",
+              "example": "1,2,3"
+            }
+          },
+          {
+            "in": "query",
+            "name": "use_last_revision",
+            "required": false,
+            "schema": {
+              "type": "boolean",
+              "default": false,
+              "description": "Получать актуальную информацию"
+            }
+          },
+          {
+            "in": "query",
+            "name": "user_id",
+            "required": false,
+            "schema": {
+              "type": "string",
+              "description": "Идентификатор пользователя (не длиннее 256 символов), по которому выбирается вариант A/B-эксперимента и считаются показы баннеров с ограничением частоты. Без него выдается выбранный баннер пары, а ограничение частоты не применяется",
+              "example": "user-42"
+            }
+          },
+          {
+            "in": "query",
+            "name": "attributes",
+            "required": false,
+            "style": "form",
+            "explode": true,
+            "schema": {
+              "type": "object",
+              "additionalProperties": {
+                "type": "string"
+              },
+              "description": "Атрибуты пользователя для правил таргетинга и переменные шаблонов (не более 32, значения не длиннее 256 символов): любые параметры запроса, кроме параметров эндпойнта, или заголовки X-User-*, например X-User-App-Version задает атрибут app_version. Если переменная шаблона с missing_variables=error не передана, возвращается 400",
+              "example": {
+                "platform": "ios",
+                "app_version": "2.3.0",
+                "country": "DE"
+              }
+            }
+          },
+          {
+            "in": "query",
+            "name": "locale",
+            "required": false,
+            "schema": {
+              "type": "string",
+              "description": "Предпочтительный язык содержимого, важнее заголовка Accept-Language",
+              "example": "de-AT"
+            }
+          },
+          {
+            "in": "header",
+            "name": "Accept-Language",
+            "required": false,
+            "schema": {
+              "type": "string",
+              "description": "Предпочтительные языки содержимого. Если у баннера нет подходящего перевода, выдается содержимое на языке по умолчанию",
+              "example": "de-AT, de;q=0.9, en;q=0.8"
+            }
+          },
           {
             "in": "header",
             "name": "token",
-            "description": "Токен админа",
+            "description": "Токен пользователя/админа",
             "schema": {
               "type": "string",
-              "example": "admin_token"
+              "example": "user_token"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Баннеры пользователя по фичам",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "description": "Объект, где ключ - ID фичи, а значение - содержимое баннера (content) или ошибка (error). Если баннер выдан из варианта A/B-эксперимента, ключ варианта указан в поле variant, а язык содержимого - в поле locale. Если выдан баннер по умолчанию фичи, выставлено поле fallback. Идентификаторы баннера, версии и тега (кроме баннера по умолчанию) для событий показов и кликов указаны в полях banner_id, version_id и tag_id. Если БД недоступна, баннеры могут быть выданы из последней сохраненной копии, тогда у них выставлено поле stale, а в ответе - заголовок Warning",
+                  "type": "object",
+                  "additionalProperties": true,
+                  "example": "{\"1\": {\"content\": {\"title\": \"some_title\"}, \"variant\": \"b\"}, \"2\": {\"error\": \"requested banner not found\"}}"
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера"
+          }
+        }
+      }
+    },
+    "/banner_stream": {
+      "get": {
+        "description": "Подписка на изменения баннеров через Server-Sent Events. Сразу после подключения для каждой фичи приходит событие banner с ее текущим баннером для тега, затем - каждый раз, когда баннер фичи может измениться: при создании, изменении (в том числе выключении), выборе версии или удалении баннера. Изменения рассылаются всем экземплярам сервиса, поэтому подписчик получает изменения, сделанные через любой из них. При переподключении с заголовком Last-Event-ID приходят только баннеры, изменившиеся после этого события (или баннеры всех фич, если изменения уже не хранятся). Подписчик, не успевающий читать события, отключается",
+        "tags": [
+          "Banners"
+        ],
+        "summary": "Поток изменений баннеров пользователя",
+        "parameters": [
+          {
+            "in": "query",
+            "name": "tag_id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Тэг пользователя"
+            }
+          },
+          {
+            "in": "query",
+            "name": "feature_id",
+            "required": true,
+            "schema": {
+              "type": "string",
+              "description": "Идентификаторы фич через запятую (не более 100)",
+              "example": "1,2,3"
+            }
+          },
+          {
+            "in": "query",
+            "name": "user_id",
+            "required": false,
+            "schema": {
+              "type": "string",
+              "description": "Идентификатор пользователя, как в /user_banners",
+              "example": "user-42"
+            }
+          },
+          {
+            "in": "query",
+            "name": "locale",
+            "required": false,
+            "schema": {
+              "type": "string",
+              "description": "Предпочтительный язык содержимого, важнее заголовка Accept-Language",
+              "example": "de-AT"
+            }
+          },
+          {
+            "in": "header",
+            "name": "Accept-Language",
+            "required": false,
+            "schema": {
+              "type": "string",
+              "description": "Предпочтительные языки содержимого",
+              "example": "de-AT, de;q=0.9, en;q=0.8"
+            }
+          },
+          {
+            "in": "header",
+            "name": "Last-Event-ID",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор последнего полученного события, с которого продолжается поток"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен пользователя/админа",
+            "schema": {
+              "type": "string",
+              "example": "user_token"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Поток событий banner, у каждого есть id для Last-Event-ID",
+            "content": {
+              "text/event-stream": {
+                "schema": {
+                  "type": "string",
+                  "description": "Данные события - JSON с полем feature_id и полями элемента ответа /user_banners: содержимым баннера (content) с его идентификаторами или ошибкой (error)",
+                  "example": "id: 42\nevent: banner\ndata: {\"feature_id\": 1, \"content\": {\"title\": \"some_title\"}, \"banner_id\": 3, \"version_id\": 7, \"tag_id\": 5}\n\n"
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера"
+          }
+        }
+      }
+    },
+    "/banner": {
+      "get": {
+        "description": "Запрос для получения всех баннеров c фильтрацией по фиче и/или тегу, если не указано ни то не другое - выдаются все баннеры, лимит по умолчанию 15, оффсет - 0, максимальный лимит - 100",
+        "tags": [
+          "Banners"
+        ],
+        "summary": "Получение всех баннеров c фильтрацией по фиче и/или тегу",
+        "parameters": [
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          },
+          {
+            "in": "query",
+            "name": "feature_id",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор фичи"
+            }
+          },
+          {
+            "in": "query",
+            "name": "tag_id",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор тега"
+            }
+          },
+          {
+            "in": "query",
+            "name": "limit",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "description": "Лимит"
+            }
+          },
+          {
+            "in": "query",
+            "name": "offset",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "description": "Оффсет"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "banner_id": {
+                        "type": "integer",
+                        "description": "Идентификатор баннера"
+                      },
+                      "tag_ids": {
+                        "type": "array",
+                        "description": "Идентификаторы тэгов",
+                        "items": {
+                          "type": "integer"
+                        }
+                      },
+                      "feature_id": {
+                        "type": "integer",
+                        "description": "Идентификатор фичи"
+                      },
+                      "content": {
+                        "type": "object",
+                        "description": "Содержимое баннера",
+                        "additionalProperties": true,
+                        "example": "{\"title\": \"some_title\", \"text\": \"some_text\", \"url\": \"some_url\"}"
+                      },
+                      "is_active": {
+                        "type": "boolean",
+                        "description": "Флаг активности баннера"
+                      },
+                      "active_from": {
+                        "nullable": true,
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Начало окна показа баннера (если не задано - без ограничения)"
+                      },
+                      "active_until": {
+                        "nullable": true,
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Конец окна показа баннера (если не задано - без ограничения)"
+                      },
+                      "targeting": {
+                        "nullable": true,
+                        "type": "string",
+                        "description": "Правило таргетинга по атрибутам пользователя, например platform in (\"ios\", \"android\") && app_version >= 2.3.0 && country == \"DE\". Поддерживаются ==, !=, <, <=, >, >= (версии сравниваются по частям), in, not in, &&, ||, ! и скобки"
+                      },
+                      "default_locale": {
+                        "nullable": true,
+                        "type": "string",
+                        "description": "Язык content"
+                      },
+                      "locales": {
+                        "nullable": true,
+                        "type": "object",
+                        "description": "Переводы содержимого баннера, где ключ - язык",
+                        "additionalProperties": {
+                          "type": "object"
+                        }
+                      },
+                      "is_template": {
+                        "type": "boolean",
+                        "description": "Строковые значения content и переводов - шаблоны с подстановками {{"{{"}}name}}"
+                      },
+                      "missing_variables": {
+                        "type": "string",
+                        "enum": ["empty", "keep", "error"],
+                        "description": "Что делать с подстановкой, переменная которой не передана"
+                      },
+                      "frequency_cap": {
+                        "nullable": true,
+                        "type": "integer",
+                        "description": "Сколько раз в сутки баннер выдается одному пользователю"
+                      },
+                      "created_at": {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Дата создания баннера"
+                      },
+                      "updated_at": {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Дата обновления баннера"
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Создание нового баннера с получением ID",
+        "tags": [
+          "Banners"
+        ],
+        "summary": "Создание нового баннера",
+        "parameters": [
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "tag_ids": {
+                    "type": "array",
+                    "description": "Идентификаторы тэгов",
+                    "items": {
+                      "type": "integer"
+                    }
+                  },
+                  "feature_id": {
+                    "type": "integer",
+                    "description": "Идентификатор фичи"
+                  },
+                  "content": {
+                    "type": "object",
+                    "description": "Содержимое баннера",
+                    "additionalProperties": true,
+                    "example": "{\"title\": \"some_title\", \"text\": \"some_text\", \"url\": \"some_url\"}"
+                  },
+                  "is_active": {
+                    "type": "boolean",
+                    "description": "Флаг активности баннера"
+                  },
+                  "active_from": {
+                    "type": "string",
+                    "format": "date-time",
+                    "description": "Начало окна показа баннера (если не задано - без ограничения)"
+                  },
+                  "active_until": {
+                    "type": "string",
+                    "format": "date-time",
+                    "description": "Конец окна показа баннера (если не задано - без ограничения)"
+                  },
+                  "targeting": {
+                    "type": "string",
+                    "description": "Правило таргетинга по атрибутам пользователя, например platform in (\"ios\", \"android\") && app_version >= 2.3.0 && country == \"DE\". Поддерживаются ==, !=, <, <=, >, >= (версии сравниваются по частям), in, not in, &&, ||, ! и скобки. Если не задано - баннер показывается всем пользователям тега"
+                  },
+                  "default_locale": {
+                    "type": "string",
+                    "description": "Язык content (тег языка, например en или de-AT)",
+                    "example": "en"
+                  },
+                  "locales": {
+                    "type": "object",
+                    "description": "Переводы содержимого баннера (не более 50), где ключ - тег языка, отличный от default_locale",
+                    "additionalProperties": {
+                      "type": "object"
+                    },
+                    "example": {
+                      "de": {
+                        "title": "Hallo"
+                      }
+                    }
+                  },
+                  "is_template": {
+                    "type": "boolean",
+                    "default": false,
+                    "description": "Строковые значения content и переводов - шаблоны с подстановками вида {{"{{"}}name}}, которые заполняются при каждом запросе /user_banner из атрибутов пользователя. Имя состоит из латинских букв, цифр и _, регистр не важен"
+                  },
+                  "missing_variables": {
+                    "type": "string",
+                    "enum": ["empty", "keep", "error"],
+                    "default": "empty",
+                    "description": "Что делать с подстановкой, переменная которой не передана: empty - заменить пустой строкой, keep - оставить как есть, error - вернуть ошибку"
+                  },
+                  "frequency_cap": {
+                    "nullable": true,
+                    "type": "integer",
+                    "minimum": 0,
+                    "description": "Сколько раз в сутки (UTC) баннер выдается одному пользователю, 0 или null - без ограничения"
+                  }
+                }
+              }
+            }
+          }
+        },
+        "responses": {
+          "201": {
+            "description": "Created",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "banner_id": {
+                      "type": "integer",
+                      "description": "Идентификатор созданного баннера"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Некорректные данные/нарушение уникальности пары тег-фича",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    },
+                    "details": {
+                      "type": "array",
+                      "description": "Места содержимого, не соответствующие схеме фичи, если содержимое ей не соответствует",
+                      "items": {
+                        "type": "object",
+                        "properties": {
+                          "path": {
+                            "type": "string",
+                            "description": "JSON Pointer места в теле запроса, например /content/title или /locales/de/title"
+                          },
+                          "message": {
+                            "type": "string"
+                          }
+                        }
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Запрос удаления баннеров (и всех их версий) по тегу, фиче или паре тег-фича, необходимо указать хотя бы что-то одно. Удаление производится в отдельной горутине (одновременно это может делать ограниченное число горутин (остальные ждут), можно задать в конфигурации)",
+        "tags": [
+          "Banners"
+        ],
+        "summary": "Удаление баннеров по тегу/фиче",
+        "parameters": [
+          {
+            "in": "query",
+            "name": "feature_id",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор фичи"
+            }
+          },
+          {
+            "in": "query",
+            "name": "tag_id",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор тега"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "responses": {
+          "202": {
+            "description": "Запрос на удаление получен, удаление может выполниться не мнгновенно"
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "404": {
+            "description": "Ни одного баннера с указанными параметрами не найдено"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/banner/{id}": {
+      "patch": {
+        "description": "Частичное/полное обновление баннера (создает новую версию баннера и делает ее выбранной)",
+        "tags": [
+          "Banners"
+        ],
+        "summary": "Обновление содержимого баннера",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор баннера"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "tag_ids": {
+                    "nullable": true,
+                    "type": "array",
+                    "description": "Идентификаторы тэгов",
+                    "items": {
+                      "type": "integer"
+                    }
+                  },
+                  "feature_id": {
+                    "nullable": true,
+                    "type": "integer",
+                    "description": "Идентификатор фичи"
+                  },
+                  "content": {
+                    "nullable": true,
+                    "type": "object",
+                    "description": "Содержимое баннера",
+                    "additionalProperties": true,
+                    "example": "{\"title\": \"some_title\", \"text\": \"some_text\", \"url\": \"some_url\"}"
+                  },
+                  "is_active": {
+                    "nullable": true,
+                    "type": "boolean",
+                    "description": "Флаг активности баннера"
+                  },
+                  "active_from": {
+                    "nullable": true,
+                    "type": "string",
+                    "format": "date-time",
+                    "description": "Начало окна показа баннера (если не задано - не меняется, null удаляет его)"
+                  },
+                  "active_until": {
+                    "nullable": true,
+                    "type": "string",
+                    "format": "date-time",
+                    "description": "Конец окна показа баннера (если не задано - не меняется, null удаляет его)"
+                  },
+                  "targeting": {
+                    "nullable": true,
+                    "type": "string",
+                    "description": "Правило таргетинга по атрибутам пользователя, например platform in (\"ios\", \"android\") && app_version >= 2.3.0 && country == \"DE\". Поддерживаются ==, !=, <, <=, >, >= (версии сравниваются по частям), in, not in, &&, ||, ! и скобки. Пустая строка удаляет правило"
+                  },
+                  "default_locale": {
+                    "nullable": true,
+                    "type": "string",
+                    "description": "Язык content, пустая строка удаляет его"
+                  },
+                  "locales": {
+                    "nullable": true,
+                    "type": "object",
+                    "description": "Переводы содержимого баннера, полностью заменяют переводы предыдущей версии. Если не переданы - переводы копируются из предыдущей версии",
+                    "additionalProperties": {
+                      "type": "object"
+                    }
+                  },
+                  "is_template": {
+                    "nullable": true,
+                    "type": "boolean",
+                    "description": "Строковые значения content и переводов - шаблоны с подстановками вида {{"{{"}}name}}"
+                  },
+                  "missing_variables": {
+                    "nullable": true,
+                    "type": "string",
+                    "enum": ["empty", "keep", "error"],
+                    "description": "Что делать с подстановкой, переменная которой не передана: empty - заменить пустой строкой, keep - оставить как есть, error - вернуть ошибку"
+                  },
+                  "frequency_cap": {
+                    "nullable": true,
+                    "type": "integer",
+                    "minimum": 0,
+                    "description": "Сколько раз в сутки (UTC) баннер выдается одному пользователю, 0 или null - без ограничения"
+                  }
+                }
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "Обновлено успешно"
+          },
+          "400": {
+            "description": "Некорректные данные/запрос вызывает нарушение уникальности пары тег-фича",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    },
+                    "details": {
+                      "type": "array",
+                      "description": "Места содержимого, не соответствующие схеме фичи, если содержимое ей не соответствует",
+                      "items": {
+                        "type": "object",
+                        "properties": {
+                          "path": {
+                            "type": "string",
+                            "description": "JSON Pointer места в теле запроса, например /content/title или /locales/de/title"
+                          },
+                          "message": {
+                            "type": "string"
+                          }
+                        }
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "404": {
+            "description": "Баннер/версия не найден(-а)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Запрос удаления баннера (и всех его версий) по идентификатору",
+        "tags": [
+          "Banners"
+        ],
+        "summary": "Удаление баннера по идентификатору",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор баннера"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Баннер успешно удален"
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "404": {
+            "description": "Баннер с таким ID не найден"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/banner_versions/{id}": {
+        "get": {
+        "description": "Запрос для получения всех версий баннера по его id, опционально с лимитом (по умолчанию - 3, максимум - 100) и оффсетом (по умолчанию - 0), отсортированные по невозрастанию времени обновления",
+        "tags": [
+          "Versions"
+        ],
+        "summary": "Получение всех версий баннера c лимитом и оффсетом",
+        "parameters": [
+          {
+              "in": "path",
+              "name": "id",
+              "required": true,
+              "schema": {
+                "type": "integer",
+                "description": "Идентификатор баннера"
+              }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          },
+          {
+            "in": "query",
+            "name": "limit",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "description": "Лимит"
+            }
+          },
+          {
+            "in": "query",
+            "name": "offset",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "description": "Оффсет"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "version_id": {
+                        "type": "integer",
+                        "description": "Идентификатор версии (уникальный по отношению ко всем существующим версиям всех баннеров)"
+                      },
+                      "tag_ids": {
+                        "type": "array",
+                        "description": "Идентификаторы тегов этой версии",
+                        "items": {
+                          "type": "integer"
+                        }
+                      },
+                      "feature_id": {
+                        "type": "integer",
+                        "description": "Идентификатор фичи этой версии"
+                      },
+                      "content": {
+                        "type": "object",
+                        "description": "Содержимое баннера этой версии",
+                        "additionalProperties": true,
+                        "example": "{\"title\": \"some_title\", \"text\": \"some_text\", \"url\": \"some_url\"}"
+                      },
+                      "is_active": {
+                        "type": "boolean",
+                        "description": "Флаг активности баннера этой версии"
+                      },
+                      "active_from": {
+                        "nullable": true,
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Начало окна показа баннера (если не задано - без ограничения)"
+                      },
+                      "active_until": {
+                        "nullable": true,
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Конец окна показа баннера (если не задано - без ограничения)"
+                      },
+                      "targeting": {
+                        "nullable": true,
+                        "type": "string",
+                        "description": "Правило таргетинга по атрибутам пользователя, например platform in (\"ios\", \"android\") && app_version >= 2.3.0 && country == \"DE\". Поддерживаются ==, !=, <, <=, >, >= (версии сравниваются по частям), in, not in, &&, ||, ! и скобки"
+                      },
+                      "default_locale": {
+                        "nullable": true,
+                        "type": "string",
+                        "description": "Язык content"
+                      },
+                      "locales": {
+                        "nullable": true,
+                        "type": "object",
+                        "description": "Переводы содержимого баннера, где ключ - язык",
+                        "additionalProperties": {
+                          "type": "object"
+                        }
+                      },
+                      "is_template": {
+                        "type": "boolean",
+                        "description": "Строковые значения content и переводов - шаблоны с подстановками {{"{{"}}name}}"
+                      },
+                      "missing_variables": {
+                        "type": "string",
+                        "enum": ["empty", "keep", "error"],
+                        "description": "Что делать с подстановкой, переменная которой не передана"
+                      },
+                      "frequency_cap": {
+                        "nullable": true,
+                        "type": "integer",
+                        "description": "Сколько раз в сутки баннер выдается одному пользователю"
+                      },
+                      "created_at": {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Дата создания баннера"
+                      },
+                      "updated_at": {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Дата обновления баннера (создания этой версии)"
+                      },
+                      "is_chosen": {
+                        "type": "boolean",
+                        "description": "Флаг выбранной версии"
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "Баннер с указанным ID не найден"
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/experiment": {
+      "get": {
+        "description": "Запрос для получения списка A/B-экспериментов с фильтрацией по фиче и/или тегу",
+        "tags": [
+          "Experiments"
+        ],
+        "summary": "Получение списка экспериментов",
+        "parameters": [
+          {
+            "in": "query",
+            "name": "feature_id",
+            "required": false,
+            "schema": {
+              "type": "integer"
+            }
+          },
+          {
+            "in": "query",
+            "name": "tag_id",
+            "required": false,
+            "schema": {
+              "type": "integer"
+            }
+          },
+          {
+            "in": "query",
+            "name": "limit",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "default": 15
+            }
+          },
+          {
+            "in": "query",
+            "name": "offset",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "default": 0
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Список экспериментов",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "experiment_id": {
+                        "type": "integer"
+                      },
+                      "feature_id": {
+                        "type": "integer"
+                      },
+                      "tag_id": {
+                        "type": "integer"
+                      },
+                      "is_active": {
+                        "type": "boolean"
+                      },
+                      "variants": {
+                        "type": "array",
+                        "items": {
+                          "type": "object"
+                        },
+                        "example": "[{\"key\": \"a\", \"version_id\": 1, \"weight\": 50}, {\"key\": \"b\", \"version_id\": 2, \"weight\": 50}]"
+                      },
+                      "created_at": {
+                        "type": "string",
+                        "format": "date-time"
+                      },
+                      "updated_at": {
+                        "type": "string",
+                        "format": "date-time"
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "post": {
+        "description": "Запрос для создания A/B-эксперимента для пары фича-тег (не более одного эксперимента на пару). Эксперимент применяется, когда пара побеждает при выборе баннера пользователя и у нее есть выбранный баннер; вариант выбирается по user_id. Изменения экспериментов сразу сбрасывают кэш пары",
+        "tags": [
+          "Experiments"
+        ],
+        "summary": "Создание эксперимента",
+        "parameters": [
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "feature_id": {
+                    "type": "integer"
+                  },
+                  "tag_id": {
+                    "type": "integer"
+                  },
+                  "is_active": {
+                    "type": "boolean",
+                    "default": true
+                  },
+                  "variants": {
+                    "type": "array",
+                    "description": "От 2 до 10 вариантов с уникальными ключами, версии должны относиться к фиче эксперимента",
+                    "items": {
+                      "type": "object",
+                      "properties": {
+                        "key": {
+                          "type": "string",
+                          "description": "Ключ варианта, возвращается в заголовке X-Experiment-Variant",
+                          "example": "b"
+                        },
+                        "version_id": {
+                          "type": "integer",
+                          "description": "Идентификатор версии баннера, показываемой в варианте"
+                        },
+                        "weight": {
+                          "type": "integer",
+                          "description": "Вес варианта (доля пользователей пропорциональна весу)",
+                          "example": 50
+                        }
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          }
+        },
+        "responses": {
+          "201": {
+            "description": "Эксперимент создан",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "experiment_id": {
+                      "type": "integer"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Некорректные данные/версии вариантов не соответствуют схеме содержимого фичи",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    },
+                    "details": {
+                      "type": "array",
+                      "description": "Места содержимого версий вариантов, не соответствующие схеме фичи, если они ей не соответствуют",
+                      "items": {
+                        "type": "object",
+                        "properties": {
+                          "path": {
+                            "type": "string",
+                            "description": "JSON Pointer места в содержимом версии варианта, например /variants/1/content/title или /variants/1/locales/de/title"
+                          },
+                          "message": {
+                            "type": "string"
+                          }
+                        }
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "409": {
+            "description": "Для пары фича-тег уже есть эксперимент или у пары нет активного баннера для активного эксперимента",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/experiment/{id}": {
+      "patch": {
+        "description": "Запрос для изменения эксперимента. Если переданы variants, они полностью заменяют существующие варианты",
+        "tags": [
+          "Experiments"
+        ],
+        "summary": "Изменение эксперимента",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор эксперимента"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "feature_id": {
+                    "type": "integer"
+                  },
+                  "tag_id": {
+                    "type": "integer"
+                  },
+                  "is_active": {
+                    "type": "boolean"
+                  },
+                  "variants": {
+                    "type": "array",
+                    "description": "От 2 до 10 вариантов с уникальными ключами, версии должны относиться к фиче эксперимента",
+                    "items": {
+                      "type": "object",
+                      "properties": {
+                        "key": {
+                          "type": "string",
+                          "description": "Ключ варианта, возвращается в заголовке X-Experiment-Variant",
+                          "example": "b"
+                        },
+                        "version_id": {
+                          "type": "integer",
+                          "description": "Идентификатор версии баннера, показываемой в варианте"
+                        },
+                        "weight": {
+                          "type": "integer",
+                          "description": "Вес варианта (доля пользователей пропорциональна весу)",
+                          "example": 50
+                        }
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "Эксперимент изменен"
+          },
+          "400": {
+            "description": "Некорректные данные/версии вариантов не соответствуют схеме содержимого фичи",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    },
+                    "details": {
+                      "type": "array",
+                      "description": "Места содержимого версий вариантов, не соответствующие схеме фичи, если они ей не соответствуют",
+                      "items": {
+                        "type": "object",
+                        "properties": {
+                          "path": {
+                            "type": "string",
+                            "description": "JSON Pointer места в содержимом версии варианта, например /variants/1/content/title или /variants/1/locales/de/title"
+                          },
+                          "message": {
+                            "type": "string"
+                          }
+                        }
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "Эксперимент не найден"
+          },
+          "409": {
+            "description": "Для пары фича-тег уже есть эксперимент или у пары нет активного баннера для активного эксперимента",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Запрос для удаления эксперимента",
+        "tags": [
+          "Experiments"
+        ],
+        "summary": "Удаление эксперимента",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор эксперимента"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Эксперимент удален"
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "Эксперимент не найден"
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/feature/{id}/schema": {
+      "get": {
+        "description": "Запрос получения JSON Schema содержимого баннеров фичи",
+        "tags": [
+          "Features"
+        ],
+        "summary": "Получение схемы содержимого фичи",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор фичи"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Схема содержимого",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "additionalProperties": true
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "У фичи нет схемы содержимого"
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "Запрос задания JSON Schema содержимого баннеров фичи. После этого создание и изменение баннеров фичи, а также выбор их версий, отклоняются, если content или переводы не соответствуют схеме. Уже сохраненные баннеры не проверяются, поэтому перед заданием схемы их стоит проверить через /feature/{id}/schema/check",
+        "tags": [
+          "Features"
+        ],
+        "summary": "Задание схемы содержимого фичи",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор фичи"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "description": "JSON Schema содержимого баннеров фичи. Поддерживаются type, enum, const, properties, required, additionalProperties, minProperties, maxProperties, items, minItems, maxItems, uniqueItems, minLength, maxLength, pattern, minimum, maximum, exclusiveMinimum, exclusiveMaximum, allOf, anyOf, oneOf, not и $ref на $defs той же схемы, остальные ключевые слова игнорируются",
+                "example": {
+                  "type": "object",
+                  "required": [
+                    "title",
+                    "url"
+                  ],
+                  "properties": {
+                    "title": {
+                      "type": "string",
+                      "minLength": 1
+                    },
+                    "url": {
+                      "type": "string",
+                      "pattern": "^https://"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "Схема задана"
+          },
+          "400": {
+            "description": "Некорректные данные или схема",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Запрос удаления JSON Schema содержимого баннеров фичи",
+        "tags": [
+          "Features"
+        ],
+        "summary": "Удаление схемы содержимого фичи",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор фичи"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Схема удалена"
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "У фичи нет схемы содержимого"
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/feature/{id}/schema/check": {
+      "post": {
+        "description": "Запрос проверки выбранных версий баннеров фичи и их переводов на соответствие схеме без ее сохранения",
+        "tags": [
+          "Features"
+        ],
+        "summary": "Проверка баннеров фичи на соответствие схеме",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор фичи"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "description": "JSON Schema содержимого баннеров фичи. Поддерживаются type, enum, const, properties, required, additionalProperties, minProperties, maxProperties, items, minItems, maxItems, uniqueItems, minLength, maxLength, pattern, minimum, maximum, exclusiveMinimum, exclusiveMaximum, allOf, anyOf, oneOf, not и $ref на $defs той же схемы, остальные ключевые слова игнорируются",
+                "example": {
+                  "type": "object",
+                  "required": [
+                    "title",
+                    "url"
+                  ],
+                  "properties": {
+                    "title": {
+                      "type": "string",
+                      "minLength": 1
+                    },
+                    "url": {
+                      "type": "string",
+                      "pattern": "^https://"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "Баннеры, не соответствующие схеме (пустой массив, если соответствуют все)",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "banner_id": {
+                        "type": "integer"
+                      },
+                      "version_id": {
+                        "type": "integer"
+                      },
+                      "details": {
+                        "type": "array",
+                        "items": {
+                          "type": "object",
+                          "properties": {
+                            "path": {
+                              "type": "string",
+                              "description": "JSON Pointer места в теле баннера, например /content/title или /locales/de/title"
+                            },
+                            "message": {
+                              "type": "string"
+                            }
+                          }
+                        }
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Некорректные данные или схема",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/feature/{id}/fallback": {
+      "get": {
+        "description": "Запрос получения баннера по умолчанию фичи",
+        "tags": [
+          "Features"
+        ],
+        "summary": "Получение баннера по умолчанию фичи",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор фичи"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "description": "Баннер по умолчанию",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "banner_id": {
+                      "type": "integer",
+                      "description": "Идентификатор баннера по умолчанию"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "У фичи нет баннера по умолчанию"
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "put": {
+        "description": "Запрос задания баннера по умолчанию фичи. Он выдается в /user_banner и /user_banners, если для тегов пользователя нет выбранного и видимого баннера фичи или ни одно правило таргетинга не подошло. Выдается выбранная версия баннера по тем же правилам активности, но без ее правила таргетинга. Баннер должен относиться к фиче",
+        "tags": [
+          "Features"
+        ],
+        "summary": "Задание баннера по умолчанию фичи",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор фичи"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "banner_id": {
+                    "type": "integer",
+                    "description": "Идентификатор баннера по умолчанию"
+                  }
+                },
+                "example": {
+                  "banner_id": 1
+                }
+              }
+            }
+          }
+        },
+        "responses": {
+          "200": {
+            "description": "Баннер по умолчанию задан"
+          },
+          "400": {
+            "description": "Некорректные данные/баннер относится к другой фиче/выбранная версия баннера не соответствует схеме содержимого фичи",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    },
+                    "details": {
+                      "type": "array",
+                      "description": "Места содержимого выбранной версии баннера, не соответствующие схеме фичи, если она ей не соответствует",
+                      "items": {
+                        "type": "object",
+                        "properties": {
+                          "path": {
+                            "type": "string",
+                            "description": "JSON Pointer места в содержимом выбранной версии баннера, например /content/title или /locales/de/title"
+                          },
+                          "message": {
+                            "type": "string"
+                          }
+                        }
+                      }
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "Баннер не найден"
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Запрос удаления баннера по умолчанию фичи (сам баннер не удаляется)",
+        "tags": [
+          "Features"
+        ],
+        "summary": "Удаление баннера по умолчанию фичи",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор фичи"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Баннер по умолчанию удален"
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "У фичи нет баннера по умолчанию"
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/banner_events": {
+      "post": {
+        "description": "Запрос для отправки событий показов и кликов баннеров, полученных из /user_banner или /user_banners (не более 100 событий за запрос). События накапливаются в памяти и записываются в БД пачками, поэтому попадают в статистику с задержкой. События, у которых баннер, версия и фича не соответствуют друг другу, не учитываются",
+        "tags": [
+          "Statistics"
+        ],
+        "summary": "Отправка событий показов и кликов",
+        "parameters": [
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен пользователя/админа",
+            "schema": {
+              "type": "string",
+              "example": "user_token"
+            }
+          }
+        ],
+        "requestBody": {
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "array",
+                "items": {
+                  "type": "object",
+                  "properties": {
+                    "type": {
+                      "type": "string",
+                      "enum": [
+                        "impression",
+                        "click"
+                      ]
+                    },
+                    "banner_id": {
+                      "type": "integer"
+                    },
+                    "version_id": {
+                      "type": "integer"
+                    },
+                    "feature_id": {
+                      "type": "integer"
+                    },
+                    "tag_id": {
+                      "type": "integer",
+                      "description": "0 для баннера по умолчанию фичи"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        },
+        "responses": {
+          "202": {
+            "description": "События приняты"
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "503": {
+            "description": "Слишком много событий ожидают записи в БД, запрос стоит повторить позже"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера"
+          }
+        }
+      }
+    },
+    "/banner_stats": {
+      "get": {
+        "description": "Запрос для получения количества показов, кликов и CTR по баннерам и их версиям за часы, начинающиеся в промежутке [from, to), с фильтрацией по баннеру, фиче и/или тегу. По умолчанию to - текущее время, from - на 7 дней раньше to. Пагинация по баннерам, лимит по умолчанию 15, оффсет - 0, максимальный лимит - 100",
+        "tags": [
+          "Statistics"
+        ],
+        "summary": "Получение статистики показов и кликов",
+        "parameters": [
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          },
+          {
+            "in": "query",
+            "name": "banner_id",
+            "required": false,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор баннера"
             }
           },
           {
@@ -372,7 +2977,25 @@ const DocTemplate = `{
             "required": false,
             "schema": {
               "type": "integer",
-              "description": "Идентификатор тега"
+              "description": "Идентификатор тега, 0 - баннер по умолчанию фичи"
+            }
+          },
+          {
+            "in": "query",
+            "name": "from",
+            "required": false,
+            "schema": {
+              "type": "string",
+              "description": "Начало промежутка в формате RFC 3339"
+            }
+          },
+          {
+            "in": "query",
+            "name": "to",
+            "required": false,
+            "schema": {
+              "type": "string",
+              "description": "Конец промежутка в формате RFC 3339"
             }
           },
           {
@@ -396,7 +3019,7 @@ const DocTemplate = `{
         ],
         "responses": {
           "200": {
-            "description": "OK",
+            "description": "Статистика баннеров",
             "content": {
               "application/json": {
                 "schema": {
@@ -405,39 +3028,38 @@ const DocTemplate = `{
                     "type": "object",
                     "properties": {
                       "banner_id": {
-                        "type": "integer",
-                        "description": "Идентификатор баннера"
+                        "type": "integer"
                       },
-                      "tag_ids": {
+                      "versions": {
                         "type": "array",
-                        "description": "Идентификаторы тэгов",
                         "items": {
-                          "type": "integer"
+                          "type": "object",
+                          "properties": {
+                            "version_id": {
+                              "type": "integer"
+                            },
+                            "impressions": {
+                              "type": "integer"
+                            },
+                            "clicks": {
+                              "type": "integer"
+                            },
+                            "ctr": {
+                              "type": "number",
+                              "description": "Доля кликов от показов"
+                            }
+                          }
                         }
                       },
-                      "feature_id": {
-                        "type": "integer",
-                        "description": "Идентификатор фичи"
-                      },
-                      "content": {
-                        "type": "object",
-                        "description": "Содержимое баннера",
-                        "additionalProperties": true,
-                        "example": "{\"title\": \"some_title\", \"text\": \"some_text\", \"url\": \"some_url\"}"
-                      },
-                      "is_active": {
-                        "type": "boolean",
-                        "description": "Флаг активности баннера"
+                      "impressions": {
+                        "type": "integer"
                       },
-                      "created_at": {
-                        "type": "string",
-                        "format": "date-time",
-                        "description": "Дата создания баннера"
+                      "clicks": {
+                        "type": "integer"
                       },
-                      "updated_at": {
-                        "type": "string",
-                        "format": "date-time",
-                        "description": "Дата обновления баннера"
+                      "ctr": {
+                        "type": "number",
+                        "description": "Доля кликов от показов"
                       }
                     }
                   }
@@ -445,14 +3067,8 @@ const DocTemplate = `{
               }
             }
           },
-          "401": {
-            "description": "Пользователь не авторизован"
-          },
-          "403": {
-            "description": "Пользователь не имеет доступа (не админ)"
-          },
-          "500": {
-            "description": "Внутренняя ошибка сервера",
+          "400": {
+            "description": "Некорректные данные",
             "content": {
               "application/json": {
                 "schema": {
@@ -465,15 +3081,26 @@ const DocTemplate = `{
                 }
               }
             }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера"
           }
         }
-      },
+      }
+    },
+    "/webhook": {
       "post": {
-        "description": "Создание нового баннера с получением ID",
+        "description": "Запрос для создания подписки на события баннеров. События отправляются POST-запросом с JSON телом и заголовками X-Bannerify-Event, X-Bannerify-Delivery, X-Bannerify-Timestamp и X-Bannerify-Signature (sha256=HMAC-SHA256 секрета от строки \"timestamp.тело\")",
         "tags": [
-          "Banners"
+          "Webhooks"
         ],
-        "summary": "Создание нового баннера",
+        "summary": "Создание вебхука",
         "parameters": [
           {
             "in": "header",
@@ -492,43 +3119,49 @@ const DocTemplate = `{
               "schema": {
                 "type": "object",
                 "properties": {
-                  "tag_ids": {
+                  "url": {
+                    "type": "string",
+                    "description": "Абсолютный http или https URL, на который отправляются события",
+                    "example": "https://cms.example.com/bannerify"
+                  },
+                  "secret": {
+                    "type": "string",
+                    "description": "Секрет для подписи HMAC-SHA256 (от 16 до 256 символов), при создании генерируется, если не передан"
+                  },
+                  "events": {
                     "type": "array",
-                    "description": "Идентификаторы тэгов",
+                    "description": "События: banner.created, banner.updated, banner.version_chosen, banner.deleted (в том числе при удалении по тегу или фиче)",
                     "items": {
-                      "type": "integer"
+                      "type": "string",
+                      "example": "banner.created"
                     }
                   },
-                  "feature_id": {
-                    "type": "integer",
-                    "description": "Идентификатор фичи"
-                  },
-                  "content": {
-                    "type": "object",
-                    "description": "Содержимое баннера",
-                    "additionalProperties": true,
-                    "example": "{\"title\": \"some_title\", \"text\": \"some_text\", \"url\": \"some_url\"}"
-                  },
                   "is_active": {
                     "type": "boolean",
-                    "description": "Флаг активности баннера"
+                    "description": "Отправляются ли события вебхуку (по умолчанию true)"
                   }
-                }
+                },
+                "required": [
+                  "url",
+                  "events"
+                ]
               }
             }
           }
         },
         "responses": {
           "201": {
-            "description": "Created",
+            "description": "Вебхук создан, секрет возвращается только в этом ответе",
             "content": {
               "application/json": {
                 "schema": {
                   "type": "object",
                   "properties": {
-                    "banner_id": {
-                      "type": "integer",
-                      "description": "Идентификатор созданного баннера"
+                    "webhook_id": {
+                      "type": "integer"
+                    },
+                    "secret": {
+                      "type": "string"
                     }
                   }
                 }
@@ -536,7 +3169,7 @@ const DocTemplate = `{
             }
           },
           "400": {
-            "description": "Некорректные данные/нарушение уникальности пары тег-фича",
+            "description": "Некорректные данные",
             "content": {
               "application/json": {
                 "schema": {
@@ -573,44 +3206,75 @@ const DocTemplate = `{
           }
         }
       },
-      "delete": {
-        "description": "Запрос удаления баннеров (и всех их версий) по тегу, фиче или паре тег-фича, необходимо указать хотя бы что-то одно. Удаление производится в отдельной горутине (одновременно это может делать ограниченное число горутин (остальные ждут), можно задать в конфигурации)",
+      "get": {
+        "description": "Запрос для получения списка вебхуков (без секретов)",
         "tags": [
-          "Banners"
+          "Webhooks"
         ],
-        "summary": "Удаление баннеров по тегу/фиче",
+        "summary": "Получение вебхуков",
         "parameters": [
           {
-            "in": "query",
-            "name": "feature_id",
-            "required": false,
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
             "schema": {
-              "type": "integer",
-              "description": "Идентификатор фичи"
+              "type": "string",
+              "example": "admin_token"
             }
           },
           {
             "in": "query",
-            "name": "tag_id",
-            "required": false,
+            "name": "limit",
             "schema": {
               "type": "integer",
-              "description": "Идентификатор тега"
+              "description": "Лимит (от 1 до 100, по умолчанию 15)"
             }
           },
           {
-            "in": "header",
-            "name": "token",
-            "description": "Токен админа",
+            "in": "query",
+            "name": "offset",
             "schema": {
-              "type": "string",
-              "example": "admin_token"
+              "type": "integer",
+              "description": "Оффсет"
             }
           }
         ],
         "responses": {
-          "202": {
-            "description": "Запрос на удаление получен, удаление может выполниться не мнгновенно"
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "webhook_id": {
+                        "type": "integer"
+                      },
+                      "url": {
+                        "type": "string"
+                      },
+                      "events": {
+                        "type": "array",
+                        "items": {
+                          "type": "string"
+                        }
+                      },
+                      "is_active": {
+                        "type": "boolean"
+                      },
+                      "created_at": {
+                        "type": "string"
+                      },
+                      "updated_at": {
+                        "type": "string"
+                      }
+                    }
+                  }
+                }
+              }
+            }
           },
           "400": {
             "description": "Некорректные данные",
@@ -633,9 +3297,6 @@ const DocTemplate = `{
           "403": {
             "description": "Пользователь не имеет доступа (не админ)"
           },
-          "404": {
-            "description": "Ни одного баннера с указанными параметрами не найдено"
-          },
           "500": {
             "description": "Внутренняя ошибка сервера",
             "content": {
@@ -654,13 +3315,13 @@ const DocTemplate = `{
         }
       }
     },
-    "/banner/{id}": {
+    "/webhook/{id}": {
       "patch": {
-        "description": "Частичное/полное обновление баннера (создает новую версию баннера и делает ее выбранной)",
+        "description": "Запрос для изменения вебхука. Еще не доставленные события отправляются на новый URL с новым секретом",
         "tags": [
-          "Banners"
+          "Webhooks"
         ],
-        "summary": "Обновление содержимого баннера",
+        "summary": "Изменение вебхука",
         "parameters": [
           {
             "in": "path",
@@ -668,7 +3329,7 @@ const DocTemplate = `{
             "required": true,
             "schema": {
               "type": "integer",
-              "description": "Идентификатор баннера"
+              "description": "Идентификатор вебхука"
             }
           },
           {
@@ -688,30 +3349,26 @@ const DocTemplate = `{
               "schema": {
                 "type": "object",
                 "properties": {
-                  "tag_ids": {
-                    "nullable": true,
+                  "url": {
+                    "type": "string",
+                    "description": "Абсолютный http или https URL, на который отправляются события",
+                    "example": "https://cms.example.com/bannerify"
+                  },
+                  "secret": {
+                    "type": "string",
+                    "description": "Секрет для подписи HMAC-SHA256 (от 16 до 256 символов), при создании генерируется, если не передан"
+                  },
+                  "events": {
                     "type": "array",
-                    "description": "Идентификаторы тэгов",
+                    "description": "События: banner.created, banner.updated, banner.version_chosen, banner.deleted (в том числе при удалении по тегу или фиче)",
                     "items": {
-                      "type": "integer"
+                      "type": "string",
+                      "example": "banner.created"
                     }
                   },
-                  "feature_id": {
-                    "nullable": true,
-                    "type": "integer",
-                    "description": "Идентификатор фичи"
-                  },
-                  "content": {
-                    "nullable": true,
-                    "type": "object",
-                    "description": "Содержимое баннера",
-                    "additionalProperties": true,
-                    "example": "{\"title\": \"some_title\", \"text\": \"some_text\", \"url\": \"some_url\"}"
-                  },
                   "is_active": {
-                    "nullable": true,
                     "type": "boolean",
-                    "description": "Флаг активности баннера"
+                    "description": "Отправляются ли события вебхуку (по умолчанию true)"
                   }
                 }
               }
@@ -720,10 +3377,93 @@ const DocTemplate = `{
         },
         "responses": {
           "200": {
-            "description": "Обновлено успешно"
+            "description": "Вебхук изменен"
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "404": {
+            "description": "Вебхук не найден",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      },
+      "delete": {
+        "description": "Запрос для удаления вебхука вместе с журналом его доставок, в том числе еще не доставленных",
+        "tags": [
+          "Webhooks"
+        ],
+        "summary": "Удаление вебхука",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор вебхука"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "responses": {
+          "204": {
+            "description": "Вебхук удален"
           },
           "400": {
-            "description": "Некорректные данные/запрос вызывает нарушение уникальности пары тег-фича",
+            "description": "Некорректные данные",
             "content": {
               "application/json": {
                 "schema": {
@@ -737,15 +3477,15 @@ const DocTemplate = `{
               }
             }
           },
+          "404": {
+            "description": "Вебхук не найден"
+          },
           "401": {
             "description": "Пользователь не авторизован"
           },
           "403": {
             "description": "Пользователь не имеет доступа (не админ)"
           },
-          "404": {
-            "description": "Баннер/версия не найден(-а)"
-          },
           "500": {
             "description": "Внутренняя ошибка сервера",
             "content": {
@@ -762,13 +3502,15 @@ const DocTemplate = `{
             }
           }
         }
-      },
-      "delete": {
-        "description": "Запрос удаления баннера (и всех его версий) по идентификатору",
+      }
+    },
+    "/webhook/{id}/deliveries": {
+      "get": {
+        "description": "Запрос для получения журнала доставок вебхука, начиная с последних",
         "tags": [
-          "Banners"
+          "Webhooks"
         ],
-        "summary": "Удаление баннера по идентификатору",
+        "summary": "Журнал доставок вебхука",
         "parameters": [
           {
             "in": "path",
@@ -776,7 +3518,7 @@ const DocTemplate = `{
             "required": true,
             "schema": {
               "type": "integer",
-              "description": "Идентификатор баннера"
+              "description": "Идентификатор вебхука"
             }
           },
           {
@@ -787,11 +3529,87 @@ const DocTemplate = `{
               "type": "string",
               "example": "admin_token"
             }
+          },
+          {
+            "in": "query",
+            "name": "status",
+            "schema": {
+              "type": "string",
+              "description": "Только доставки со статусом pending, delivered или failed"
+            }
+          },
+          {
+            "in": "query",
+            "name": "limit",
+            "schema": {
+              "type": "integer",
+              "description": "Лимит (от 1 до 100, по умолчанию 15)"
+            }
+          },
+          {
+            "in": "query",
+            "name": "offset",
+            "schema": {
+              "type": "integer",
+              "description": "Оффсет"
+            }
           }
         ],
         "responses": {
-          "204": {
-            "description": "Баннер успешно удален"
+          "200": {
+            "description": "OK",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "array",
+                  "items": {
+                    "type": "object",
+                    "properties": {
+                      "delivery_id": {
+                        "type": "integer"
+                      },
+                      "webhook_id": {
+                        "type": "integer"
+                      },
+                      "event": {
+                        "type": "string",
+                        "example": "banner.deleted"
+                      },
+                      "payload": {
+                        "type": "object",
+                        "description": "Тело запроса: event, banner_id, version_id (кроме banner.deleted) и occurred_at"
+                      },
+                      "status": {
+                        "type": "string",
+                        "description": "pending, delivered или failed"
+                      },
+                      "attempts": {
+                        "type": "integer",
+                        "description": "Количество сделанных попыток"
+                      },
+                      "next_attempt_at": {
+                        "type": "string",
+                        "description": "Время следующей попытки (только для pending)"
+                      },
+                      "last_status_code": {
+                        "type": "integer",
+                        "description": "HTTP статус ответа на последнюю попытку"
+                      },
+                      "last_error": {
+                        "type": "string",
+                        "description": "Причина неудачи последней попытки"
+                      },
+                      "created_at": {
+                        "type": "string"
+                      },
+                      "delivered_at": {
+                        "type": "string"
+                      }
+                    }
+                  }
+                }
+              }
+            }
           },
           "400": {
             "description": "Некорректные данные",
@@ -808,15 +3626,27 @@ const DocTemplate = `{
               }
             }
           },
+          "404": {
+            "description": "Вебхук не найден",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          },
           "401": {
             "description": "Пользователь не авторизован"
           },
           "403": {
             "description": "Пользователь не имеет доступа (не админ)"
           },
-          "404": {
-            "description": "Баннер с таким ID не найден"
-          },
           "500": {
             "description": "Внутренняя ошибка сервера",
             "content": {
@@ -835,23 +3665,14 @@ const DocTemplate = `{
         }
       }
     },
-    "/banner_versions/{id}": {
-        "get": {
-        "description": "Запрос для получения всех версий баннера по его id, опционально с лимитом (по умолчанию - 3, максимум - 100) и оффсетом (по умолчанию - 0), отсортированные по невозрастанию времени обновления",
+    "/audit": {
+      "get": {
+        "description": "Запрос для поиска в журнале изменений, сделанных админами, начиная с последних. Время создания записей фильтруется по промежутку [from, to)",
         "tags": [
-          "Versions"
+          "Audit"
         ],
-        "summary": "Получение всех версий баннера c лимитом и оффсетом",
+        "summary": "Поиск в журнале аудита",
         "parameters": [
-          {
-              "in": "path",
-              "name": "id",
-              "required": true,
-              "schema": {
-                "type": "integer",
-                "description": "Идентификатор баннера"
-              }
-          },
           {
             "in": "header",
             "name": "token",
@@ -861,19 +3682,49 @@ const DocTemplate = `{
               "example": "admin_token"
             }
           },
+          {
+            "in": "query",
+            "name": "actor",
+            "schema": {
+              "type": "string",
+              "description": "Логин админа"
+            }
+          },
+          {
+            "in": "query",
+            "name": "banner_id",
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор баннера"
+            }
+          },
+          {
+            "in": "query",
+            "name": "from",
+            "schema": {
+              "type": "string",
+              "description": "Начало промежутка в формате RFC 3339"
+            }
+          },
+          {
+            "in": "query",
+            "name": "to",
+            "schema": {
+              "type": "string",
+              "description": "Конец промежутка в формате RFC 3339"
+            }
+          },
           {
             "in": "query",
             "name": "limit",
-            "required": false,
             "schema": {
               "type": "integer",
-              "description": "Лимит"
+              "description": "Лимит (от 1 до 100, по умолчанию 15)"
             }
           },
           {
             "in": "query",
             "name": "offset",
-            "required": false,
             "schema": {
               "type": "integer",
               "description": "Оффсет"
@@ -890,44 +3741,45 @@ const DocTemplate = `{
                   "items": {
                     "type": "object",
                     "properties": {
+                      "entry_id": {
+                        "type": "integer"
+                      },
+                      "actor": {
+                        "type": "string",
+                        "description": "Логин админа, сделавшего изменение"
+                      },
+                      "action": {
+                        "type": "string",
+                        "example": "banner.update",
+                        "description": "banner.create, banner.update, banner.choose_version, banner.delete, tag_priority.set, experiment.create, experiment.update, experiment.delete, feature_schema.set, feature_schema.delete, feature_fallback.set, feature_fallback.delete, webhook.create, webhook.update или webhook.delete"
+                      },
+                      "banner_id": {
+                        "type": "integer"
+                      },
                       "version_id": {
                         "type": "integer",
-                        "description": "Идентификатор версии (уникальный по отношению ко всем существующим версиям всех баннеров)"
-                      },
-                      "tag_ids": {
-                        "type": "array",
-                        "description": "Идентификаторы тегов этой версии",
-                        "items": {
-                          "type": "integer"
-                        }
+                        "description": "Версия, выбранная после изменения, для удаленного баннера - последняя выбранная"
                       },
-                      "feature_id": {
+                      "target_id": {
                         "type": "integer",
-                        "description": "Идентификатор фичи этой версии"
+                        "description": "Идентификатор эксперимента, фичи, тега или вебхука, в зависимости от action"
                       },
-                      "content": {
+                      "diff": {
                         "type": "object",
-                        "description": "Содержимое баннера этой версии",
-                        "additionalProperties": true,
-                        "example": "{\"title\": \"some_title\", \"text\": \"some_text\", \"url\": \"some_url\"}"
-                      },
-                      "is_active": {
-                        "type": "boolean",
-                        "description": "Флаг активности баннера этой версии"
-                      },
-                      "created_at": {
-                        "type": "string",
-                        "format": "date-time",
-                        "description": "Дата создания баннера"
+                        "description": "Измененные поля с их значениями до и после изменения",
+                        "example": {
+                          "is_active": {
+                            "before": false,
+                            "after": true
+                          }
+                        }
                       },
-                      "updated_at": {
+                      "request_id": {
                         "type": "string",
-                        "format": "date-time",
-                        "description": "Дата обновления баннера (создания этой версии)"
+                        "description": "Идентификатор запроса из заголовка X-Request-ID"
                       },
-                      "is_chosen": {
-                        "type": "boolean",
-                        "description": "Флаг выбранной версии"
+                      "created_at": {
+                        "type": "string"
                       }
                     }
                   }
@@ -950,8 +3802,92 @@ const DocTemplate = `{
               }
             }
           },
-          "404": {
-            "description": "Баннер с указанным ID не найден"
+          "401": {
+            "description": "Пользователь не авторизован"
+          },
+          "403": {
+            "description": "Пользователь не имеет доступа (не админ)"
+          },
+          "500": {
+            "description": "Внутренняя ошибка сервера",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/tag_priority/{tag_id}": {
+      "put": {
+        "description": "Запрос для задания приоритета тега. Если пользователь состоит в нескольких тегах, в /user_banner выдается баннер тега с наибольшим приоритетом",
+        "tags": [
+          "Tags"
+        ],
+        "summary": "Задание приоритета тега",
+        "parameters": [
+          {
+            "in": "path",
+            "name": "tag_id",
+            "required": true,
+            "schema": {
+              "type": "integer",
+              "description": "Идентификатор тега"
+            }
+          },
+          {
+            "in": "header",
+            "name": "token",
+            "description": "Токен админа",
+            "schema": {
+              "type": "string",
+              "example": "admin_token"
+            }
+          }
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {
+                "type": "object",
+                "properties": {
+                  "priority": {
+                    "type": "integer",
+                    "description": "Приоритет тега (по умолчанию у всех тегов 0)",
+                    "example": 10
+                  }
+                }
+              }
+            }
+          }
+        },
+        "responses": {
+          "204": {
+            "description": "Приоритет успешно задан"
+          },
+          "400": {
+            "description": "Некорректные данные",
+            "content": {
+              "application/json": {
+                "schema": {
+                  "type": "object",
+                  "properties": {
+                    "error": {
+                      "type": "string"
+                    }
+                  }
+                }
+              }
+            }
           },
           "401": {
             "description": "Пользователь не авторизован"
@@ -1026,6 +3962,22 @@ const DocTemplate = `{
                 "properties": {
                   "error": {
                     "type": "string"
+                  },
+                  "details": {
+                    "type": "array",
+                    "description": "Места содержимого, не соответствующие схеме фичи, если содержимое ей не соответствует",
+                    "items": {
+                      "type": "object",
+                      "properties": {
+                        "path": {
+                          "type": "string",
+                          "description": "JSON Pointer места в теле запроса, например /content/title или /locales/de/title"
+                        },
+                        "message": {
+                          "type": "string"
+                        }
+                      }
+                    }
                   }
                 }
               }
@@ -22,6 +22,10 @@ var (
 	ErrVersionIDNotInRange      = errors.New("version_id should be more than zero")
 	ErrBannerFieldNotProvided   = errors.New("one or more banner json fields are not provided (tag_ids, feature_id, content and is_active required)")
 	ErrBannerTagUniqueViolation = errors.New("feature and tag pair of chosen banners cannot point to different banners")
-	ErrNoBannerFieldsProvided   = errors.New("no banner json fields provided (tag_ids or feature_id or content or is_active can be provided)")
+	ErrNoBannerFieldsProvided   = errors.New("no banner json fields provided (tag_ids or feature_id or content or is_active or active_from or active_until or targeting or default_locale or locales or is_template or missing_variables can be provided)")
 	ErrUseLastRevisionNotBool   = errors.New("use_last_revision header is not bool (true/false)")
+	ErrTooManyFeatures          = errors.New("no more than 100 feature_id values can be requested at once")
+	ErrTooManyTags              = errors.New("no more than 100 tag_id values can be provided at once")
+	ErrTagPriorityNotProvided   = errors.New("priority json field was not provided")
+	ErrActiveWindowInvalid      = errors.New("active_from should be earlier than active_until")
 )
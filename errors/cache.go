@@ -2,4 +2,7 @@ package errors
 
 import "errors"
 
-var ErrNotFoundInCache = errors.New("the item not found in cache")
+var (
+	ErrNotFoundInCache  = errors.New("the item not found in cache")
+	ErrWarmUpInProgress = errors.New("cache warm-up is already in progress")
+)
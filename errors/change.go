@@ -0,0 +1,8 @@
+package errors
+
+import "errors"
+
+var (
+	ErrChangesNotKept     = errors.New("the changes since the provided event id are not kept anymore")
+	ErrLastEventIDInvalid = errors.New("Last-Event-ID should be a number not less than zero")
+)
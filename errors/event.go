@@ -0,0 +1,13 @@
+package errors
+
+import "errors"
+
+var (
+	ErrNoEventsProvided  = errors.New("no events provided")
+	ErrTooManyEvents     = errors.New("no more than 100 events can be sent at once")
+	ErrEventTypeInvalid  = errors.New("event type should be impression or click")
+	ErrEventFieldInvalid = errors.New("banner_id, version_id and feature_id of an event should be more than zero, tag_id should not be less than zero")
+	ErrEventBufferFull   = errors.New("too many events are waiting to be saved, please, try again later")
+	ErrTimeIsInvalid     = errors.New("from and to should be in RFC 3339 format")
+	ErrStatsRangeInvalid = errors.New("from should be earlier than to")
+)
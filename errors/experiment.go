@@ -0,0 +1,17 @@
+package errors
+
+import "errors"
+
+var (
+	ErrExperimentNotFound         = errors.New("requested experiment not found")
+	ErrExperimentExists           = errors.New("an experiment for this feature and tag pair already exists")
+	ErrExperimentFieldNotProvided = errors.New("one or more experiment json fields are not provided (feature_id, tag_id and variants required)")
+	ErrNoExperimentFieldsProvided = errors.New("no experiment json fields provided (feature_id or tag_id or is_active or variants can be provided)")
+	ErrVariantsInvalid            = errors.New("an experiment needs from 2 to 10 variants with unique non-empty keys of up to 64 characters, positive version_id and weight")
+	ErrVariantVersionMismatch     = errors.New("variant versions should exist and belong to the feature of the experiment")
+	ErrExperimentWithoutBanner    = errors.New("an active experiment needs an active banner chosen for its feature and tag pair")
+	ErrNoExperimentIDProvided     = errors.New("experiment_id not found in path")
+	ErrExperimentIDIsNotANumber   = errors.New("provided experiment id is not a number")
+	ErrExperimentIDNotInRange     = errors.New("experiment id should be more than zero")
+	ErrUserIDTooLong              = errors.New("user_id should not be longer than 256 characters")
+)
@@ -0,0 +1,11 @@
+package errors
+
+import "errors"
+
+var (
+	ErrNoFeatureIDProvided     = errors.New("feature_id not found in path")
+	ErrFeatureSchemaNotFound   = errors.New("the feature has no content schema")
+	ErrContentSchemaViolation  = errors.New("banner content does not conform to the content schema of the feature")
+	ErrFeatureFallbackNotFound = errors.New("the feature has no fallback banner")
+	ErrFallbackOfOtherFeature  = errors.New("the fallback banner should belong to the feature")
+)
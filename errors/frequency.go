@@ -0,0 +1,7 @@
+package errors
+
+import "errors"
+
+var (
+	ErrFrequencyCapInvalid = errors.New("frequency_cap should not be less than zero")
+)
@@ -5,4 +5,6 @@ import "errors"
 var (
 	ErrSomethingWentWrong = errors.New("something went wrong on server side, please, try again later")
 	ErrNoRowsAffected     = errors.New("something went wrong while using database: no rows were affected by insert/update/delete")
+	ErrUnknownEncoding    = errors.New("unknown content encoding")
+	ErrDBUnavailable      = errors.New("database is temporarily unavailable")
 )
@@ -0,0 +1,8 @@
+package errors
+
+import "errors"
+
+var (
+	ErrLocaleInvalid  = errors.New("locale should be a language tag such as en or de-AT")
+	ErrLocalesInvalid = errors.New("no more than 50 locales with unique language tags other than default_locale can be provided")
+)
@@ -0,0 +1,8 @@
+package errors
+
+import "errors"
+
+var (
+	ErrTargetingTooLong      = errors.New("targeting should not be longer than 1024 characters")
+	ErrUserAttributesInvalid = errors.New("no more than 32 user attributes of up to 256 characters can be provided")
+)
@@ -0,0 +1,8 @@
+package errors
+
+import "errors"
+
+var (
+	ErrMissingVariablesInvalid = errors.New("missing_variables should be one of empty, keep or error")
+	ErrTemplateInvalid         = errors.New("template placeholders should look like {{name}}, where the name consists of letters, digits and underscores")
+)
@@ -0,0 +1,16 @@
+package errors
+
+import "errors"
+
+var (
+	ErrWebhookNotFound         = errors.New("requested webhook not found")
+	ErrWebhookFieldNotProvided = errors.New("one or more webhook json fields are not provided (url and events required)")
+	ErrNoWebhookFieldsProvided = errors.New("no webhook json fields provided (url or secret or events or is_active can be provided)")
+	ErrWebhookURLInvalid       = errors.New("webhook url should be an absolute http or https url of up to 2048 characters")
+	ErrWebhookEventsInvalid    = errors.New("webhook events should be a non-empty list of banner.created, banner.updated, banner.version_chosen and banner.deleted")
+	ErrWebhookSecretInvalid    = errors.New("webhook secret should be from 16 to 256 characters long")
+	ErrNoWebhookIDProvided     = errors.New("webhook_id not found in path")
+	ErrWebhookIDIsNotANumber   = errors.New("provided webhook id is not a number")
+	ErrWebhookIDNotInRange     = errors.New("webhook id should be more than zero")
+	ErrDeliveryStatusInvalid   = errors.New("delivery status should be pending, delivered or failed")
+)
@@ -1,19 +1,67 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 type Config struct {
-	PostgresUser        string `env:"POSTGRES_USER"         envDefault:"bannerify"`
-	PostgresPassword    string `env:"POSTGRES_PASSWORD"     envDefault:"bannerify"`
-	PostgresDB          string `env:"POSTGRES_DB"           envDefault:"bannerify"`
-	PostgresPort        int    `env:"POSTGRES_PORT"         envDefault:"5432"`
-	ServicePort         int    `env:"SERVICE_PORT"          envDefault:"8080"`
-	ServiceHost         string `env:"SERVICE_HOST"          envDefault:"0.0.0.0"`
-	MigrationsPath      string `env:"MIGRATIONS_PATH"       envDefault:"migrations"`
-	LogFilePath         string `env:"LOG_FILE_PATH"         envDefault:"logfile.log"`
-	JWTKey              string `env:"JWT_KEY"               envDefault:"notreallysecret"`
-	CachePort           int    `env:"REDIS_PORT"            envDefault:"6379"`
-	DeleteWorkersAmount int    `env:"DELETE_WORKERS_AMOUNT" envDefault:"4"`
+	PostgresUser               string        `env:"POSTGRES_USER"         envDefault:"bannerify"`
+	PostgresPassword           string        `env:"POSTGRES_PASSWORD"     envDefault:"bannerify"`
+	PostgresDB                 string        `env:"POSTGRES_DB"           envDefault:"bannerify"`
+	PostgresPort               int           `env:"POSTGRES_PORT"         envDefault:"5432"`
+	ServicePort                int           `env:"SERVICE_PORT"          envDefault:"8080"`
+	ServiceHost                string        `env:"SERVICE_HOST"          envDefault:"0.0.0.0"`
+	GRPCPort                   int           `env:"GRPC_PORT"             envDefault:"9090"`
+	MigrationsPath             string        `env:"MIGRATIONS_PATH"       envDefault:"migrations"`
+	LogFilePath                string        `env:"LOG_FILE_PATH"         envDefault:"logfile.log"`
+	JWTKey                     string        `env:"JWT_KEY"               envDefault:"notreallysecret"`
+	CacheBackend               string        `env:"CACHE_BACKEND"         envDefault:"redis"`
+	MemoryCacheMaxEntries      int           `env:"MEMORY_CACHE_MAX_ENTRIES" envDefault:"100000"`
+	MemoryCacheMaxBytes        int64         `env:"MEMORY_CACHE_MAX_BYTES" envDefault:"268435456"`
+	CachePort                  int           `env:"REDIS_PORT"            envDefault:"6379"`
+	RedisURL                   string        `env:"REDIS_URL"`
+	RedisAddrs                 []string      `env:"REDIS_ADDRS"           envSeparator:","`
+	RedisUsername              string        `env:"REDIS_USERNAME"`
+	RedisPassword              string        `env:"REDIS_PASSWORD"`
+	RedisDB                    *int          `env:"REDIS_DB"`
+	RedisTLS                   bool          `env:"REDIS_TLS"             envDefault:"false"`
+	RedisTLSCAFile             string        `env:"REDIS_TLS_CA_FILE"`
+	RedisTLSInsecureSkipVerify bool          `env:"REDIS_TLS_INSECURE_SKIP_VERIFY" envDefault:"false"`
+	RedisSentinelMaster        string        `env:"REDIS_SENTINEL_MASTER"`
+	RedisSentinelUsername      string        `env:"REDIS_SENTINEL_USERNAME"`
+	RedisSentinelPassword      string        `env:"REDIS_SENTINEL_PASSWORD"`
+	RedisCluster               bool          `env:"REDIS_CLUSTER"         envDefault:"false"`
+	RedisPoolSize              int           `env:"REDIS_POOL_SIZE"       envDefault:"0"`
+	RedisMinIdleConns          int           `env:"REDIS_MIN_IDLE_CONNS"  envDefault:"0"`
+	RedisPoolTimeout           time.Duration `env:"REDIS_POOL_TIMEOUT"    envDefault:"4s"`
+	RedisDialTimeout           time.Duration `env:"REDIS_DIAL_TIMEOUT"    envDefault:"5s"`
+	RedisReadTimeout           time.Duration `env:"REDIS_READ_TIMEOUT"    envDefault:"3s"`
+	RedisWriteTimeout          time.Duration `env:"REDIS_WRITE_TIMEOUT"   envDefault:"3s"`
+	DeleteWorkersAmount        int           `env:"DELETE_WORKERS_AMOUNT" envDefault:"4"`
+	L1CacheMaxEntries          int           `env:"L1_CACHE_MAX_ENTRIES"  envDefault:"10000"`
+	L1CacheMaxBytes            int64         `env:"L1_CACHE_MAX_BYTES"    envDefault:"67108864"`
+	L1CacheTTL                 time.Duration `env:"L1_CACHE_TTL"          envDefault:"10s"`
+	StaleCacheTTL              time.Duration `env:"STALE_CACHE_TTL"       envDefault:"24h"`
+	MissingCacheTTL            time.Duration `env:"MISSING_CACHE_TTL"     envDefault:"30s"`
+	DBBreakerThreshold         int           `env:"DB_BREAKER_THRESHOLD"  envDefault:"5"`
+	DBBreakerTimeout           time.Duration `env:"DB_BREAKER_TIMEOUT"    envDefault:"5s"`
+	WarmUpOnStart              bool          `env:"WARMUP_ON_START"       envDefault:"true"`
+	WarmUpTimeout              time.Duration `env:"WARMUP_TIMEOUT"        envDefault:"1m"`
+	EventsFlushInterval        time.Duration `env:"EVENTS_FLUSH_INTERVAL" envDefault:"5s"`
+	EventsBatchSize            int           `env:"EVENTS_BATCH_SIZE"     envDefault:"1000"`
+	EventsMaxPending           int           `env:"EVENTS_MAX_PENDING"    envDefault:"100000"`
+	ChangesRetention           time.Duration `env:"CHANGES_RETENTION"     envDefault:"24h"`
+	WebhookTimeout             time.Duration `env:"WEBHOOK_TIMEOUT"       envDefault:"5s"`
+	WebhookMaxAttempts         int           `env:"WEBHOOK_MAX_ATTEMPTS"  envDefault:"10"`
+	WebhookBackoffBase         time.Duration `env:"WEBHOOK_BACKOFF_BASE"  envDefault:"10s"`
+	WebhookBackoffMax          time.Duration `env:"WEBHOOK_BACKOFF_MAX"   envDefault:"1h"`
+	WebhookPollInterval        time.Duration `env:"WEBHOOK_POLL_INTERVAL" envDefault:"1s"`
+	OutboxSink                 string        `env:"OUTBOX_SINK"           envDefault:"log"`
+	OutboxHTTPURL              string        `env:"OUTBOX_HTTP_URL"`
+	OutboxHTTPTimeout          time.Duration `env:"OUTBOX_HTTP_TIMEOUT"   envDefault:"5s"`
+	OutboxPollInterval         time.Duration `env:"OUTBOX_POLL_INTERVAL"  envDefault:"1s"`
+	OutboxRetention            time.Duration `env:"OUTBOX_RETENTION"      envDefault:"24h"`
 }
 
 func (c *Config) DSN() string {
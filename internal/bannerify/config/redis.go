@@ -0,0 +1,119 @@
+package config
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// RedisOptions builds the options of the Redis client. The settings from REDIS_URL are applied first
+// and are overridden by the explicitly set variables. Without any address REDIS_PORT of the redis host is used.
+func (c *Config) RedisOptions() (*redis.UniversalOptions, error) {
+	opts := &redis.UniversalOptions{
+		MasterName:       c.RedisSentinelMaster,
+		SentinelUsername: c.RedisSentinelUsername,
+		SentinelPassword: c.RedisSentinelPassword,
+		PoolSize:         c.RedisPoolSize,
+		MinIdleConns:     c.RedisMinIdleConns,
+		PoolTimeout:      c.RedisPoolTimeout,
+		DialTimeout:      c.RedisDialTimeout,
+		ReadTimeout:      c.RedisReadTimeout,
+		WriteTimeout:     c.RedisWriteTimeout,
+	}
+
+	if c.RedisURL != "" {
+		err := c.applyRedisURL(opts)
+		if err != nil {
+			return nil, fmt.Errorf("config.RedisOptions: %w", err)
+		}
+	}
+
+	if len(c.RedisAddrs) != 0 {
+		opts.Addrs = c.RedisAddrs
+	}
+
+	if len(opts.Addrs) == 0 {
+		opts.Addrs = []string{"redis:" + strconv.Itoa(c.CachePort)}
+	}
+
+	if c.RedisUsername != "" {
+		opts.Username = c.RedisUsername
+	}
+
+	if c.RedisPassword != "" {
+		opts.Password = c.RedisPassword
+	}
+
+	if c.RedisDB != nil {
+		opts.DB = *c.RedisDB
+	}
+
+	if c.RedisTLS && opts.TLSConfig == nil {
+		opts.TLSConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+	}
+
+	if opts.TLSConfig != nil {
+		err := c.configureRedisTLS(opts.TLSConfig)
+		if err != nil {
+			return nil, fmt.Errorf("config.RedisOptions: %w", err)
+		}
+	}
+
+	return opts, nil
+}
+
+func (c *Config) applyRedisURL(opts *redis.UniversalOptions) error {
+	if c.RedisCluster {
+		parsed, err := redis.ParseClusterURL(c.RedisURL)
+		if err != nil {
+			return err
+		}
+
+		opts.Addrs = parsed.Addrs
+		opts.Username = parsed.Username
+		opts.Password = parsed.Password
+		opts.TLSConfig = parsed.TLSConfig
+
+		return nil
+	}
+
+	parsed, err := redis.ParseURL(c.RedisURL)
+	if err != nil {
+		return err
+	}
+
+	opts.Addrs = []string{parsed.Addr}
+	opts.Username = parsed.Username
+	opts.Password = parsed.Password
+	opts.DB = parsed.DB
+	opts.TLSConfig = parsed.TLSConfig
+
+	return nil
+}
+
+func (c *Config) configureRedisTLS(tlsConfig *tls.Config) error {
+	tlsConfig.InsecureSkipVerify = c.RedisTLSInsecureSkipVerify
+
+	if c.RedisTLSCAFile == "" {
+		return nil
+	}
+
+	ca, err := os.ReadFile(c.RedisTLSCAFile)
+	if err != nil {
+		return err
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return errors.New("no certificates found in " + c.RedisTLSCAFile)
+	}
+
+	tlsConfig.RootCAs = pool
+
+	return nil
+}
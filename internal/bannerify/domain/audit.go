@@ -0,0 +1,29 @@
+package domain
+
+import "context"
+
+type actorKey struct{}
+
+type requestIDKey struct{}
+
+// WithActor stores the login of the admin making the request, the audit entries of the changes are attributed to it.
+func WithActor(ctx context.Context, login string) context.Context {
+	return context.WithValue(ctx, actorKey{}, login)
+}
+
+// ActorFromContext returns the login stored by WithActor or an empty string.
+func ActorFromContext(ctx context.Context) string {
+	login, _ := ctx.Value(actorKey{}).(string)
+	return login
+}
+
+// WithRequestID stores the ID of the request, so the audit entries can be matched with it.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext returns the ID stored by WithRequestID or an empty string.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditBannerCreate          = "banner.create"
+	AuditBannerUpdate          = "banner.update"
+	AuditBannerChooseVersion   = "banner.choose_version"
+	AuditBannerDelete          = "banner.delete"
+	AuditTagPrioritySet        = "tag_priority.set"
+	AuditExperimentCreate      = "experiment.create"
+	AuditExperimentUpdate      = "experiment.update"
+	AuditExperimentDelete      = "experiment.delete"
+	AuditFeatureSchemaSet      = "feature_schema.set"
+	AuditFeatureSchemaDelete   = "feature_schema.delete"
+	AuditFeatureFallbackSet    = "feature_fallback.set"
+	AuditFeatureFallbackDelete = "feature_fallback.delete"
+	AuditWebhookCreate         = "webhook.create"
+	AuditWebhookUpdate         = "webhook.update"
+	AuditWebhookDelete         = "webhook.delete"
+)
+
+// AuditEntry is a change made by an admin. TargetID is the experiment, feature, tag or webhook the action is named after,
+// Diff maps the changed fields to their values before and after the change, null if the object did not exist.
+type AuditEntry struct {
+	EntryID   int64           `json:"entry_id"`
+	Actor     string          `json:"actor"`
+	Action    string          `json:"action"`
+	BannerID  *int            `json:"banner_id,omitempty"`
+	VersionID *int            `json:"version_id,omitempty"`
+	TargetID  *int            `json:"target_id,omitempty"`
+	Diff      json.RawMessage `json:"diff"`
+	RequestID string          `json:"request_id"`
+	CreatedAt string          `json:"created_at"`
+}
+
+// AuditFilter selects the entries of the actor and the banner made in [From, To), nil fields are not filtered by.
+type AuditFilter struct {
+	Actor    *string
+	BannerID *int
+	From     *time.Time
+	To       *time.Time
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 )
 
 type BannerServicePingProvider interface {
@@ -9,8 +10,12 @@ type BannerServicePingProvider interface {
 }
 
 type BannerServiceGetter interface {
-	GetBanner(ctx context.Context, tagID int, featureID int, isAdmin bool, dbRequired bool) (string, error)
+	GetBanner(ctx context.Context, tagIDs []int, featureID int, isAdmin bool, dbRequired bool, user UserContext) (UserBanner, error)
+	GetBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, dbRequired bool, user UserContext) (map[int]UserBanner, error)
+	PeekBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, dbRequired bool, user UserContext) (map[int]UserBanner, error)
 	ListBanners(ctx context.Context, tagID *int, featureID *int, limit int, offset int) ([]BannerListElement, error)
+	CacheStats() CacheStats
+	WarmUp(ctx context.Context) (int, error)
 }
 
 type BannerServiceVersioner interface {
@@ -26,18 +31,66 @@ type BannerServiceUpdater interface {
 	UpdateBanner(ctx context.Context, bannerID int, banner Banner) error
 }
 
+type BannerServiceTagPrioritizer interface {
+	SetTagPriority(ctx context.Context, tagID int, priority int) error
+}
+
 type BannerServiceDeleter interface {
 	DeleteBannerByID(ctx context.Context, bannerID int) error
 	DeleteBannerByTagOrFeature(ctx context.Context, deleteCtx context.Context, tagID *int, featureID *int) error
 }
 
+type BannerServiceExperimenter interface {
+	CreateExperiment(ctx context.Context, experiment Experiment) (int, error)
+	ListExperiments(ctx context.Context, featureID *int, tagID *int, limit int, offset int) ([]ExperimentListElement, error)
+	UpdateExperiment(ctx context.Context, experimentID int, experiment Experiment) error
+	DeleteExperiment(ctx context.Context, experimentID int) error
+}
+
+type BannerServiceFeatureRegistry interface {
+	SetFeatureSchema(ctx context.Context, featureID int, schema []byte) error
+	GetFeatureSchema(ctx context.Context, featureID int) ([]byte, error)
+	DeleteFeatureSchema(ctx context.Context, featureID int) error
+	CheckFeatureSchema(ctx context.Context, featureID int, schema []byte) ([]SchemaCheckElement, error)
+	SetFeatureFallback(ctx context.Context, featureID int, bannerID int) error
+	GetFeatureFallback(ctx context.Context, featureID int) (int, error)
+	DeleteFeatureFallback(ctx context.Context, featureID int) error
+}
+
+type BannerServiceEventTracker interface {
+	RecordEvents(ctx context.Context, events []BannerEvent) error
+	GetBannerStats(ctx context.Context, bannerID *int, featureID *int, tagID *int, from time.Time, to time.Time, limit int, offset int) ([]BannerStats, error)
+}
+
+type BannerServiceChangeStreamer interface {
+	Subscribe(tagID int, featureIDs []int) (<-chan BannerChange, func())
+	LastChangeID(ctx context.Context) (int64, error)
+	ChangesSince(ctx context.Context, changeID int64, tagID int, featureIDs []int) ([]BannerChange, error)
+}
+
+type BannerServiceWebhookManager interface {
+	CreateWebhook(ctx context.Context, webhook Webhook) (int, error)
+	ListWebhooks(ctx context.Context, limit int, offset int) ([]WebhookListElement, error)
+	UpdateWebhook(ctx context.Context, webhookID int, webhook Webhook) error
+	DeleteWebhook(ctx context.Context, webhookID int) error
+	ListDeliveries(ctx context.Context, webhookID int, status *string, limit int, offset int) ([]WebhookDelivery, error)
+}
+
+type BannerServiceAuditor interface {
+	SearchAudit(ctx context.Context, filter AuditFilter, limit int, offset int) ([]AuditEntry, error)
+}
+
 type BannerRepositoryPingProvider interface {
 	Ping(ctx context.Context) error
 }
 
 type BannerRepositoryGetter interface {
-	GetBanner(ctx context.Context, tagID int, featureID int, isAdmin bool, dbRequired bool) (string, error)
+	GetBanner(ctx context.Context, tagIDs []int, featureID int, isAdmin bool, dbRequired bool, user UserContext) (UserBanner, error)
+	GetBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, dbRequired bool, user UserContext) (map[int]UserBanner, error)
+	PeekBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, dbRequired bool, user UserContext) (map[int]UserBanner, error)
 	ListBanners(ctx context.Context, tagID *int, featureID *int, limit int, offset int) ([]BannerListElement, error)
+	CacheStats() CacheStats
+	WarmUp(ctx context.Context) (int, error)
 }
 
 type BannerRepositoryVersioner interface {
@@ -53,7 +106,51 @@ type BannerRepositoryUpdater interface {
 	UpdateBanner(ctx context.Context, bannerID int, banner Banner) error
 }
 
+type BannerRepositoryTagPrioritizer interface {
+	SetTagPriority(ctx context.Context, tagID int, priority int) error
+}
+
 type BannerRepositoryDeleter interface {
 	DeleteBannerByID(ctx context.Context, bannerID int) error
 	DeleteBannerByTagOrFeature(ctx context.Context, deleteCtx context.Context, tagID *int, featureID *int) error
 }
+
+type BannerRepositoryExperimenter interface {
+	CreateExperiment(ctx context.Context, experiment Experiment) (int, error)
+	ListExperiments(ctx context.Context, featureID *int, tagID *int, limit int, offset int) ([]ExperimentListElement, error)
+	UpdateExperiment(ctx context.Context, experimentID int, experiment Experiment) error
+	DeleteExperiment(ctx context.Context, experimentID int) error
+}
+
+type BannerRepositoryFeatureRegistry interface {
+	SetFeatureSchema(ctx context.Context, featureID int, schema []byte) error
+	GetFeatureSchema(ctx context.Context, featureID int) ([]byte, error)
+	DeleteFeatureSchema(ctx context.Context, featureID int) error
+	CheckFeatureSchema(ctx context.Context, featureID int, schema []byte) ([]SchemaCheckElement, error)
+	SetFeatureFallback(ctx context.Context, featureID int, bannerID int) error
+	GetFeatureFallback(ctx context.Context, featureID int) (int, error)
+	DeleteFeatureFallback(ctx context.Context, featureID int) error
+}
+
+type BannerRepositoryEventTracker interface {
+	RecordEvents(ctx context.Context, events []BannerEvent) error
+	GetBannerStats(ctx context.Context, bannerID *int, featureID *int, tagID *int, from time.Time, to time.Time, limit int, offset int) ([]BannerStats, error)
+}
+
+type BannerRepositoryChangeStreamer interface {
+	Subscribe(tagID int, featureIDs []int) (<-chan BannerChange, func())
+	LastChangeID(ctx context.Context) (int64, error)
+	ChangesSince(ctx context.Context, changeID int64, tagID int, featureIDs []int) ([]BannerChange, error)
+}
+
+type BannerRepositoryWebhookManager interface {
+	CreateWebhook(ctx context.Context, webhook Webhook) (int, error)
+	ListWebhooks(ctx context.Context, limit int, offset int) ([]WebhookListElement, error)
+	UpdateWebhook(ctx context.Context, webhookID int, webhook Webhook) error
+	DeleteWebhook(ctx context.Context, webhookID int) error
+	ListDeliveries(ctx context.Context, webhookID int, status *string, limit int, offset int) ([]WebhookDelivery, error)
+}
+
+type BannerRepositoryAuditor interface {
+	SearchAudit(ctx context.Context, filter AuditFilter, limit int, offset int) ([]AuditEntry, error)
+}
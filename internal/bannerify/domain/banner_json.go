@@ -1,24 +1,157 @@
 package domain
 
-import "encoding/json"
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
 
 type BannerListElement struct {
-	BannerID  int             `json:"banner_id"`
-	TagIDs    []int           `json:"tag_ids"`
-	FeatureID int             `json:"feature_id"`
-	Content   json.RawMessage `json:"content"`
-	IsActive  bool            `json:"is_active"`
-	CreatedAt string          `json:"created_at"`
-	UpdatedAt string          `json:"updated_at"`
+	BannerID         int                        `json:"banner_id"`
+	TagIDs           []int                      `json:"tag_ids"`
+	FeatureID        int                        `json:"feature_id"`
+	Content          json.RawMessage            `json:"content"`
+	IsActive         bool                       `json:"is_active"`
+	ActiveFrom       *string                    `json:"active_from"`
+	ActiveUntil      *string                    `json:"active_until"`
+	Targeting        *string                    `json:"targeting"`
+	DefaultLocale    *string                    `json:"default_locale"`
+	Locales          map[string]json.RawMessage `json:"locales"`
+	IsTemplate       bool                       `json:"is_template"`
+	MissingVariables string                     `json:"missing_variables"`
+	FrequencyCap     *int                       `json:"frequency_cap"`
+	CreatedAt        string                     `json:"created_at"`
+	UpdatedAt        string                     `json:"updated_at"`
 }
 
 type Banner struct {
-	TagIDs    []int           `json:"tag_ids"`
-	FeatureID *int            `json:"feature_id"`
-	Content   json.RawMessage `json:"content"`
-	IsActive  *bool           `json:"is_active"`
+	TagIDs           []int                      `json:"tag_ids"`
+	FeatureID        *int                       `json:"feature_id"`
+	Content          json.RawMessage            `json:"content"`
+	IsActive         *bool                      `json:"is_active"`
+	ActiveFrom       *time.Time                 `json:"active_from"`
+	ActiveUntil      *time.Time                 `json:"active_until"`
+	Targeting        *string                    `json:"targeting"`
+	DefaultLocale    *string                    `json:"default_locale"`
+	Locales          map[string]json.RawMessage `json:"locales"`
+	IsTemplate       *bool                      `json:"is_template"`
+	MissingVariables *string                    `json:"missing_variables"`
+	FrequencyCap     *int                       `json:"frequency_cap"`
+	ClearActiveFrom  bool                       `json:"-"`
+	ClearActiveUntil bool                       `json:"-"`
+}
+
+// UnmarshalJSON decodes the banner the same way as the default decoder with disallowed unknown fields,
+// but also remembers the bounds of the activation window explicitly set to null, an update removes them.
+func (b *Banner) UnmarshalJSON(data []byte) error {
+	type banner Banner
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.DisallowUnknownFields()
+
+	if err := d.Decode((*banner)(b)); err != nil {
+		return err
+	}
+
+	var window struct {
+		ActiveFrom  json.RawMessage `json:"active_from"`
+		ActiveUntil json.RawMessage `json:"active_until"`
+	}
+
+	if err := json.Unmarshal(data, &window); err != nil {
+		return err
+	}
+
+	b.ClearActiveFrom = string(window.ActiveFrom) == "null"
+	b.ClearActiveUntil = string(window.ActiveUntil) == "null"
+
+	return nil
+}
+
+// UserBanner of a pair with a running experiment keeps all of its visible variants,
+// the one served to a user is chosen per request and is named by Variant.
+// A banner with a targeting rule keeps the banners of the next tags as Fallbacks,
+// they are served to the users the rule does not match.
+// Locale is the language of the content, Locales are all of the languages the banner has.
+// The content of a Template is rendered per request, MissingVariables is the policy for the variables not provided.
+// FeatureFallback is set for the fallback banner of the feature, which is served when no banner of the tags is.
+// A banner with a FrequencyCap keeps the next banners as Fallbacks too, they are served to the users who have seen it enough today.
+// Capped is set if a cap was involved in choosing the served banner, so the clients revalidate it every time it is shown.
+type UserBanner struct {
+	BannerID         int               `json:"banner_id,omitempty"`
+	VersionID        int               `json:"version_id"`
+	TagID            int               `json:"tag_id,omitempty"`
+	Content          json.RawMessage   `json:"content"`
+	Encoded          map[string][]byte `json:"encoded,omitempty"`
+	ETag             string            `json:"etag"`
+	ExpiresAt        time.Time         `json:"expires_at"`
+	ExperimentID     int               `json:"experiment_id,omitempty"`
+	Variants         []BannerVariant   `json:"variants,omitempty"`
+	Targeting        string            `json:"targeting,omitempty"`
+	Fallbacks        []UserBanner      `json:"fallbacks,omitempty"`
+	Locale           string            `json:"locale,omitempty"`
+	Locales          []string          `json:"locales,omitempty"`
+	Template         bool              `json:"template,omitempty"`
+	MissingVariables string            `json:"missing_variables,omitempty"`
+	FeatureFallback  bool              `json:"feature_fallback,omitempty"`
+	FrequencyCap     int               `json:"frequency_cap,omitempty"`
+	Variant          string            `json:"-"`
+	Targeted         bool              `json:"-"`
+	Capped           bool              `json:"-"`
+	Stale            bool              `json:"-"`
+}
+
+type BannerVariant struct {
+	Key              string            `json:"key"`
+	Weight           int               `json:"weight"`
+	BannerID         int               `json:"banner_id,omitempty"`
+	VersionID        int               `json:"version_id"`
+	Content          json.RawMessage   `json:"content"`
+	Encoded          map[string][]byte `json:"encoded,omitempty"`
+	ETag             string            `json:"etag"`
+	Locale           string            `json:"locale,omitempty"`
+	Locales          []string          `json:"locales,omitempty"`
+	Template         bool              `json:"template,omitempty"`
+	MissingVariables string            `json:"missing_variables,omitempty"`
+	FrequencyCap     int               `json:"frequency_cap,omitempty"`
+}
+
+// UserContext is what the client tells about the user, only the locale negotiated from it is a part of the cache key.
+// Attributes are matched against the targeting rules, Locales are the preferred languages in order.
+type UserContext struct {
+	UserID     string
+	Attributes map[string]string
+	Locales    []string
+}
+
+type CacheStats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Entries   int    `json:"entries"`
+	Bytes     int64  `json:"bytes"`
+}
+
+type WarmUpResult struct {
+	Warmed int `json:"warmed"`
+}
+
+type BannerBatchElement struct {
+	Content   json.RawMessage `json:"content,omitempty"`
+	BannerID  int             `json:"banner_id,omitempty"`
+	VersionID int             `json:"version_id,omitempty"`
+	TagID     int             `json:"tag_id,omitempty"`
+	Variant   string          `json:"variant,omitempty"`
+	Locale    string          `json:"locale,omitempty"`
+	Stale     bool            `json:"stale,omitempty"`
+	Fallback  bool            `json:"fallback,omitempty"`
+	Err       string          `json:"error,omitempty"`
 }
 
 type BannerID struct {
 	ID int `json:"banner_id"`
 }
+
+type TagPriority struct {
+	Priority *int `json:"priority"`
+}
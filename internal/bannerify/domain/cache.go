@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// FeatureTag is a pair which a banner is chosen for. Every cached value depends on the pairs it was resolved from.
+type FeatureTag struct {
+	FeatureID int `json:"feature_id"`
+	TagID     int `json:"tag_id"`
+}
+
+// CacheItem is stored only if none of its pairs was invalidated since the generations were read.
+// Zero StaleTTL means that no last known good copy is kept.
+type CacheItem struct {
+	Value       string
+	TTL         time.Duration
+	StaleTTL    time.Duration
+	Pairs       []FeatureTag
+	Generations []string
+}
+
+type BannerCache interface {
+	Get(ctx context.Context, key string) (string, error)
+	GetMany(ctx context.Context, keys []string) (map[string]string, error)
+	GetStale(ctx context.Context, keys []string) (map[string]string, error)
+	Generations(ctx context.Context, pairs []FeatureTag) ([]string, error)
+	SetTracked(ctx context.Context, items map[string]CacheItem) (map[string]bool, error)
+	Invalidate(ctx context.Context, pairs []FeatureTag) error
+	SubscribeInvalidations(ctx context.Context, handle func(keys []string)) error
+}
+
+// ShowCounter counts how many times each banner was shown to each user during the current day,
+// the counters of the previous days expire on their own.
+type ShowCounter interface {
+	Shows(ctx context.Context, userID string, bannerIDs []int) (map[int]int64, error)
+	AddShows(ctx context.Context, userID string, bannerIDs []int) error
+}
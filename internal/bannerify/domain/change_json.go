@@ -0,0 +1,16 @@
+package domain
+
+// BannerChange names a pair whose banner may have changed, TagID is zero if the fallback banner of the feature has,
+// then the banners of all the tags of the feature may have changed.
+type BannerChange struct {
+	ID        int64
+	FeatureID int
+	TagID     int
+}
+
+// BannerChangeEvent is pushed to the stream subscribers with the banner the feature currently has for them,
+// it has Err instead of the content if there is none.
+type BannerChangeEvent struct {
+	FeatureID int `json:"feature_id"`
+	BannerBatchElement
+}
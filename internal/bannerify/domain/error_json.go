@@ -1,5 +1,12 @@
 package domain
 
 type JSONError struct {
-	Err string `json:"error"`
+	Err     string        `json:"error"`
+	Details []ErrorDetail `json:"details,omitempty"`
+}
+
+// ErrorDetail points to the place of the request body the error is about, Path is a JSON Pointer such as /content/title.
+type ErrorDetail struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
 }
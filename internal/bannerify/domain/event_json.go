@@ -0,0 +1,31 @@
+package domain
+
+const (
+	ImpressionEvent = "impression"
+	ClickEvent      = "click"
+)
+
+// BannerEvent is reported by the client for the banner it got from /user_banner or /user_banners,
+// TagID is zero for the fallback banner of the feature.
+type BannerEvent struct {
+	Type      string `json:"type"`
+	BannerID  int    `json:"banner_id"`
+	VersionID int    `json:"version_id"`
+	FeatureID int    `json:"feature_id"`
+	TagID     int    `json:"tag_id"`
+}
+
+type BannerStats struct {
+	BannerID    int            `json:"banner_id"`
+	Impressions int64          `json:"impressions"`
+	Clicks      int64          `json:"clicks"`
+	CTR         float64        `json:"ctr"`
+	Versions    []VersionStats `json:"versions"`
+}
+
+type VersionStats struct {
+	VersionID   int     `json:"version_id"`
+	Impressions int64   `json:"impressions"`
+	Clicks      int64   `json:"clicks"`
+	CTR         float64 `json:"ctr"`
+}
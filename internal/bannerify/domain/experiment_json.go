@@ -0,0 +1,28 @@
+package domain
+
+type Experiment struct {
+	FeatureID *int                `json:"feature_id"`
+	TagID     *int                `json:"tag_id"`
+	IsActive  *bool               `json:"is_active"`
+	Variants  []ExperimentVariant `json:"variants"`
+}
+
+type ExperimentVariant struct {
+	Key       string `json:"key"`
+	VersionID int    `json:"version_id"`
+	Weight    int    `json:"weight"`
+}
+
+type ExperimentListElement struct {
+	ExperimentID int                 `json:"experiment_id"`
+	FeatureID    int                 `json:"feature_id"`
+	TagID        int                 `json:"tag_id"`
+	IsActive     bool                `json:"is_active"`
+	Variants     []ExperimentVariant `json:"variants"`
+	CreatedAt    string              `json:"created_at"`
+	UpdatedAt    string              `json:"updated_at"`
+}
+
+type ExperimentID struct {
+	ID int `json:"experiment_id"`
+}
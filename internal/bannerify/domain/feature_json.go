@@ -0,0 +1,8 @@
+package domain
+
+// SchemaCheckElement is a banner whose chosen version does not conform to the checked content schema.
+type SchemaCheckElement struct {
+	BannerID  int           `json:"banner_id"`
+	VersionID int           `json:"version_id"`
+	Details   []ErrorDetail `json:"details"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+)
+
+const (
+	OutboxBannerCreated          = "banner.created"
+	OutboxBannerUpdated          = "banner.updated"
+	OutboxBannerVersionChosen    = "banner.version_chosen"
+	OutboxBannerDeleted          = "banner.deleted"
+	OutboxTagPrioritySet         = "tag.priority_set"
+	OutboxExperimentCreated      = "experiment.created"
+	OutboxExperimentUpdated      = "experiment.updated"
+	OutboxExperimentDeleted      = "experiment.deleted"
+	OutboxFeatureSchemaSet       = "feature.schema_set"
+	OutboxFeatureSchemaDeleted   = "feature.schema_deleted"
+	OutboxFeatureFallbackSet     = "feature.fallback_set"
+	OutboxFeatureFallbackDeleted = "feature.fallback_deleted"
+)
+
+// ChangePayload describes what a change touched, Affected are the feature and tag pairs whose banners it may change.
+type ChangePayload struct {
+	BannerID     int          `json:"banner_id,omitempty"`
+	VersionID    int          `json:"version_id,omitempty"`
+	ExperimentID int          `json:"experiment_id,omitempty"`
+	FeatureID    int          `json:"feature_id,omitempty"`
+	TagID        int          `json:"tag_id,omitempty"`
+	Priority     *int         `json:"priority,omitempty"`
+	Affected     []FeatureTag `json:"affected,omitempty"`
+}
+
+// OutboxEvent is written in the transaction of the change it describes. It is published at least once,
+// so the consumers have to tolerate duplicates, which have the same ID.
+type OutboxEvent struct {
+	ID        int64           `json:"id"`
+	Type      string          `json:"type"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt string          `json:"created_at"`
+}
+
+// OutboxSink publishes a batch of the events sorted by their IDs, the batch is published again if it returns an error.
+// The delivery is at least once and the IDs are not ordered globally: an event with a lower ID may be committed,
+// and so published in a later batch, after the events with higher IDs have been published already.
+type OutboxSink interface {
+	Publish(ctx context.Context, events []OutboxEvent) error
+}
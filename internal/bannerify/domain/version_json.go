@@ -3,12 +3,20 @@ package domain
 import "encoding/json"
 
 type VersionListElement struct {
-	VersionID int             `json:"version_id"`
-	TagIDs    []int           `json:"tag_ids"`
-	FeatureID int             `json:"feature_id"`
-	Content   json.RawMessage `json:"content"`
-	IsActive  bool            `json:"is_active"`
-	CreatedAt string          `json:"created_at"`
-	UpdatedAt string          `json:"updated_at"`
-	IsChosen  bool            `json:"is_chosen"`
+	VersionID        int                        `json:"version_id"`
+	TagIDs           []int                      `json:"tag_ids"`
+	FeatureID        int                        `json:"feature_id"`
+	Content          json.RawMessage            `json:"content"`
+	IsActive         bool                       `json:"is_active"`
+	ActiveFrom       *string                    `json:"active_from"`
+	ActiveUntil      *string                    `json:"active_until"`
+	Targeting        *string                    `json:"targeting"`
+	DefaultLocale    *string                    `json:"default_locale"`
+	Locales          map[string]json.RawMessage `json:"locales"`
+	IsTemplate       bool                       `json:"is_template"`
+	MissingVariables string                     `json:"missing_variables"`
+	FrequencyCap     *int                       `json:"frequency_cap"`
+	CreatedAt        string                     `json:"created_at"`
+	UpdatedAt        string                     `json:"updated_at"`
+	IsChosen         bool                       `json:"is_chosen"`
 }
@@ -0,0 +1,60 @@
+package domain
+
+import "encoding/json"
+
+const (
+	WebhookBannerCreated       = "banner.created"
+	WebhookBannerUpdated       = "banner.updated"
+	WebhookBannerVersionChosen = "banner.version_chosen"
+	WebhookBannerDeleted       = "banner.deleted"
+)
+
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+type Webhook struct {
+	URL      *string  `json:"url"`
+	Secret   *string  `json:"secret"`
+	Events   []string `json:"events"`
+	IsActive *bool    `json:"is_active"`
+}
+
+// WebhookListElement does not include the secret, it is only returned when the webhook is created.
+type WebhookListElement struct {
+	WebhookID int      `json:"webhook_id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	IsActive  bool     `json:"is_active"`
+	CreatedAt string   `json:"created_at"`
+	UpdatedAt string   `json:"updated_at"`
+}
+
+type WebhookCreated struct {
+	ID     int    `json:"webhook_id"`
+	Secret string `json:"secret"`
+}
+
+// WebhookEvent is the body of a delivery, VersionID is zero for the deleted banners.
+type WebhookEvent struct {
+	Event      string `json:"event"`
+	BannerID   int    `json:"banner_id"`
+	VersionID  int    `json:"version_id,omitempty"`
+	OccurredAt string `json:"occurred_at"`
+}
+
+type WebhookDelivery struct {
+	DeliveryID     int64           `json:"delivery_id"`
+	WebhookID      int             `json:"webhook_id"`
+	Event          string          `json:"event"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  *string         `json:"next_attempt_at,omitempty"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	CreatedAt      string          `json:"created_at"`
+	DeliveredAt    *string         `json:"delivered_at,omitempty"`
+}
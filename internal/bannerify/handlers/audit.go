@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/internal/pkg/errwriter"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+)
+
+type auditor struct {
+	srv domain.BannerServiceAuditor
+}
+
+func NewAuditor(srv domain.BannerServiceAuditor) *auditor {
+	return &auditor{srv: srv}
+}
+
+// SearchAudit returns the newest entries first, the time range is [from, to) and is not limited if they are not provided.
+func (h *auditor) SearchAudit(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.SearchAudit:"
+
+	limit, offset, err := parseLimitAndOffset(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	filter, err := parseAuditFilter(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	entries, err := h.srv.SearchAudit(r.Context(), filter, limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(entries)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err)
+	}
+}
+
+func parseAuditFilter(r *http.Request) (domain.AuditFilter, error) {
+	var filter domain.AuditFilter
+
+	if actor := r.URL.Query().Get("actor"); actor != "" {
+		filter.Actor = &actor
+	}
+
+	if bannerIDStr := r.URL.Query().Get("banner_id"); bannerIDStr != "" {
+		bannerID, err := strconv.Atoi(bannerIDStr)
+		if err != nil {
+			return domain.AuditFilter{}, appErrors.ErrBannerIDIsNotANumber
+		}
+
+		if bannerID < 1 {
+			return domain.AuditFilter{}, appErrors.ErrBannerIDNotInRange
+		}
+
+		filter.BannerID = &bannerID
+	}
+
+	if fromStr := r.URL.Query().Get("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			return domain.AuditFilter{}, appErrors.ErrTimeIsInvalid
+		}
+
+		filter.From = &from
+	}
+
+	if toStr := r.URL.Query().Get("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			return domain.AuditFilter{}, appErrors.ErrTimeIsInvalid
+		}
+
+		filter.To = &to
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return domain.AuditFilter{}, appErrors.ErrStatsRangeInvalid
+	}
+
+	return filter, nil
+}
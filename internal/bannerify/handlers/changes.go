@@ -0,0 +1,245 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/internal/pkg/errwriter"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+)
+
+const (
+	// keepAliveInterval is how often a comment is sent to an idle stream, so the proxies do not close it.
+	keepAliveInterval = time.Second * 15
+
+	lastEventIDHeader = "Last-Event-ID"
+)
+
+type changeStreamer struct {
+	srv    domain.BannerServiceChangeStreamer
+	getter domain.BannerServiceGetter
+}
+
+func NewChangeStreamer(srv domain.BannerServiceChangeStreamer, getter domain.BannerServiceGetter) *changeStreamer {
+	return &changeStreamer{srv: srv, getter: getter}
+}
+
+// StreamBanners pushes the banner of every feature for the tag on connect and then each time it may have changed.
+// The client which reconnects with Last-Event-ID gets only the banners which may have changed since that event.
+func (h *changeStreamer) StreamBanners(isAdmin bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		const logErrPrefix = "handlers.StreamBanners:"
+
+		tagIDStr := r.URL.Query().Get("tag_id")
+		featureIDStrs := r.URL.Query()["feature_id"]
+
+		if tagIDStr == "" || len(featureIDStrs) == 0 {
+			errwriter.WriteHTTPError(w, appErrors.ErrTagOrFeatureNotProvided, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		tagID, err := strconv.Atoi(tagIDStr)
+		if err != nil {
+			errwriter.WriteHTTPError(w, appErrors.ErrTagIsNotANumber, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		featureIDs, err := parseIDList(featureIDStrs)
+		if err != nil {
+			errwriter.WriteHTTPError(w, appErrors.ErrFeatureIsNotANumber, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if len(featureIDs) == 0 {
+			errwriter.WriteHTTPError(w, appErrors.ErrTagOrFeatureNotProvided, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if len(featureIDs) > maxBatchFeatures {
+			errwriter.WriteHTTPError(w, appErrors.ErrTooManyFeatures, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		user, err := parseUserContext(r)
+		if err != nil {
+			errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		var lastEventID *int64
+		if lastEventIDStr := r.Header.Get(lastEventIDHeader); lastEventIDStr != "" {
+			id, err := strconv.ParseInt(lastEventIDStr, 10, 64)
+			if err != nil || id < 0 {
+				errwriter.WriteHTTPError(w, appErrors.ErrLastEventIDInvalid, http.StatusBadRequest, logErrPrefix)
+				return
+			}
+
+			lastEventID = &id
+		}
+
+		// the subscription starts before the missed changes are looked up, so no change falls in between
+		changes, unsubscribe := h.srv.Subscribe(tagID, featureIDs)
+		defer unsubscribe()
+
+		eventID, changedFeatureIDs, err := h.missedChanges(r.Context(), lastEventID, tagID, featureIDs)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			logger.Logger().Errorln(logErrPrefix, err.Error())
+			return
+		}
+
+		rc := http.NewResponseController(w)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("X-Accel-Buffering", "no")
+		w.WriteHeader(http.StatusOK)
+
+		err = h.push(r.Context(), w, eventID, tagID, changedFeatureIDs, isAdmin, false, user, logErrPrefix)
+		if err == nil {
+			err = rc.Flush()
+		}
+
+		if err != nil {
+			logger.Logger().Errorln(logErrPrefix, err.Error())
+			return
+		}
+
+		keepAlive := time.NewTicker(keepAliveInterval)
+		defer keepAlive.Stop()
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case change, ok := <-changes:
+				// the subscription is closed if the client lags behind or the server shuts down, the client resumes from the last event
+				if !ok {
+					return
+				}
+
+				var lastChangeID int64
+				lastChangeID, changedFeatureIDs = collectChanges(append([]domain.BannerChange{change}, pendingChanges(changes)...))
+
+				// a change with a lower id may be committed later, the event id stays at the last change the client has got
+				eventID = max(eventID, lastChangeID)
+				err = h.push(r.Context(), w, eventID, tagID, changedFeatureIDs, isAdmin, true, user, logErrPrefix)
+			case <-keepAlive.C:
+				_, err = fmt.Fprint(w, ": keep-alive\n\n")
+			}
+
+			if err == nil {
+				err = rc.Flush()
+			}
+
+			if err != nil {
+				logger.Logger().Errorln(logErrPrefix, err.Error())
+				return
+			}
+		}
+	}
+}
+
+// missedChanges returns the id of the last change and the features whose banners have to be pushed on connect,
+// all of them unless the client resumes and the changes since its last event are kept. The changes since the last event
+// may have lower ids, so the id does not go below the one of the last event.
+func (h *changeStreamer) missedChanges(ctx context.Context, lastEventID *int64, tagID int, featureIDs []int) (int64, []int, error) {
+	if lastEventID != nil {
+		changes, err := h.srv.ChangesSince(ctx, *lastEventID, tagID, featureIDs)
+		if err == nil {
+			if len(changes) == 0 {
+				return *lastEventID, nil, nil
+			}
+
+			eventID, changedFeatureIDs := collectChanges(changes)
+			return max(eventID, *lastEventID), changedFeatureIDs, nil
+		}
+
+		if !errors.Is(err, appErrors.ErrChangesNotKept) {
+			return 0, nil, err
+		}
+	}
+
+	eventID, err := h.srv.LastChangeID(ctx)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return eventID, featureIDs, nil
+}
+
+// pendingChanges takes the changes which are already waiting, so they are pushed together.
+func pendingChanges(changes <-chan domain.BannerChange) []domain.BannerChange {
+	var pending []domain.BannerChange
+	for {
+		select {
+		case change, ok := <-changes:
+			if !ok {
+				return pending
+			}
+
+			pending = append(pending, change)
+		default:
+			return pending
+		}
+	}
+}
+
+// collectChanges returns the id of the last change and the features changed, a feature changed several times is pushed once.
+func collectChanges(changes []domain.BannerChange) (int64, []int) {
+	var eventID int64
+	featureIDs := make([]int, 0, len(changes))
+	seen := make(map[int]struct{}, len(changes))
+	for _, change := range changes {
+		eventID = max(eventID, change.ID)
+
+		if _, ok := seen[change.FeatureID]; ok {
+			continue
+		}
+
+		seen[change.FeatureID] = struct{}{}
+		featureIDs = append(featureIDs, change.FeatureID)
+	}
+
+	return eventID, featureIDs
+}
+
+// push writes an event per feature, each with the banner the feature has for the user or the error instead of it.
+// The pushed banners are not counted as shown, so the frequency caps are used up only by the explicit requests.
+func (h *changeStreamer) push(ctx context.Context, w http.ResponseWriter, eventID int64, tagID int, featureIDs []int, isAdmin bool, dbRequired bool, user domain.UserContext, logErrPrefix string) error {
+	if len(featureIDs) == 0 {
+		return nil
+	}
+
+	banners, err := h.getter.PeekBanners(ctx, []int{tagID}, featureIDs, isAdmin, dbRequired, user)
+	if err != nil {
+		return err
+	}
+
+	for _, featureID := range featureIDs {
+		event := domain.BannerChangeEvent{FeatureID: featureID, BannerBatchElement: domain.BannerBatchElement{Err: appErrors.ErrBannerNotFound.Error()}}
+		if banner, ok := banners[featureID]; ok {
+			event.BannerBatchElement = newBatchElement(banner, user.Attributes, logErrPrefix)
+		}
+
+		data, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		_, err = fmt.Fprintf(w, "id: %d\nevent: banner\ndata: %s\n\n", eventID, data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
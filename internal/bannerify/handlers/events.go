@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/internal/pkg/errwriter"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+	"github.com/PoorMercymain/bannerify/pkg/reqval"
+)
+
+const (
+	maxEvents = 100
+
+	// defaultStatsRange is used when from is not provided.
+	defaultStatsRange = time.Hour * 24 * 7
+)
+
+type eventTracker struct {
+	srv domain.BannerServiceEventTracker
+}
+
+func NewEventTracker(srv domain.BannerServiceEventTracker) *eventTracker {
+	return &eventTracker{srv: srv}
+}
+
+func (h *eventTracker) RecordEvents(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.RecordEvents:"
+
+	err := reqval.ValidateJSONRequest(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var events []domain.BannerEvent
+	if err = d.Decode(&events); err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = validateEvents(events)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.RecordEvents(r.Context(), events)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrEventBufferFull) {
+			errwriter.WriteHTTPError(w, appErrors.ErrEventBufferFull, http.StatusServiceUnavailable, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func validateEvents(events []domain.BannerEvent) error {
+	if len(events) == 0 {
+		return appErrors.ErrNoEventsProvided
+	}
+
+	if len(events) > maxEvents {
+		return appErrors.ErrTooManyEvents
+	}
+
+	for _, event := range events {
+		if event.Type != domain.ImpressionEvent && event.Type != domain.ClickEvent {
+			return appErrors.ErrEventTypeInvalid
+		}
+
+		if event.BannerID < 1 || event.VersionID < 1 || event.FeatureID < 1 || event.TagID < 0 {
+			return appErrors.ErrEventFieldInvalid
+		}
+	}
+
+	return nil
+}
+
+func (h *eventTracker) GetBannerStats(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.GetBannerStats:"
+
+	bannerIDStr := r.URL.Query().Get("banner_id")
+	featureIDStr := r.URL.Query().Get("feature_id")
+	tagIDStr := r.URL.Query().Get("tag_id")
+	fromStr := r.URL.Query().Get("from")
+	toStr := r.URL.Query().Get("to")
+	limitStr := r.URL.Query().Get("limit")
+	offsetStr := r.URL.Query().Get("offset")
+
+	if limitStr == "" {
+		limitStr = "15"
+	}
+
+	if offsetStr == "" {
+		offsetStr = "0"
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		errwriter.WriteHTTPError(w, appErrors.ErrLimitIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if limit < 1 || limit > 100 {
+		errwriter.WriteHTTPError(w, appErrors.ErrLimitNotInRange, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil {
+		errwriter.WriteHTTPError(w, appErrors.ErrOffsetIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if offset < 0 {
+		errwriter.WriteHTTPError(w, appErrors.ErrOffsetNotInRange, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	to := time.Now()
+	if toStr != "" {
+		to, err = time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			errwriter.WriteHTTPError(w, appErrors.ErrTimeIsInvalid, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+	}
+
+	from := to.Add(-defaultStatsRange)
+	if fromStr != "" {
+		from, err = time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			errwriter.WriteHTTPError(w, appErrors.ErrTimeIsInvalid, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+	}
+
+	if !from.Before(to) {
+		errwriter.WriteHTTPError(w, appErrors.ErrStatsRangeInvalid, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	var bannerID *int
+	var featureID *int
+	var tagID *int
+
+	if bannerIDStr != "" {
+		bannerIDBuf, err := strconv.Atoi(bannerIDStr)
+		if err != nil {
+			errwriter.WriteHTTPError(w, appErrors.ErrBannerIDIsNotANumber, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		bannerID = &bannerIDBuf
+	}
+
+	if featureIDStr != "" {
+		featureIDBuf, err := strconv.Atoi(featureIDStr)
+		if err != nil {
+			errwriter.WriteHTTPError(w, appErrors.ErrFeatureIsNotANumber, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		featureID = &featureIDBuf
+	}
+
+	if tagIDStr != "" {
+		tagIDBuf, err := strconv.Atoi(tagIDStr)
+		if err != nil {
+			errwriter.WriteHTTPError(w, appErrors.ErrTagIsNotANumber, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		tagID = &tagIDBuf
+	}
+
+	stats, err := h.srv.GetBannerStats(r.Context(), bannerID, featureID, tagID, from, to, limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(stats)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err)
+	}
+}
@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/internal/pkg/errwriter"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+	"github.com/PoorMercymain/bannerify/pkg/reqval"
+)
+
+const (
+	minVariants         = 2
+	maxVariants         = 10
+	maxVariantKeyLength = 64
+)
+
+type experimenter struct {
+	srv domain.BannerServiceExperimenter
+}
+
+func NewExperimenter(srv domain.BannerServiceExperimenter) *experimenter {
+	return &experimenter{srv: srv}
+}
+
+// CreateExperiment rejects an active experiment with 409 if its feature and tag pair has no active banner whose activation window
+// has not ended: the variants replace the banner of the pair, so such an experiment would never be served.
+func (h *experimenter) CreateExperiment(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.CreateExperiment:"
+
+	err := reqval.ValidateJSONRequest(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var experiment domain.Experiment
+	if err = d.Decode(&experiment); err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if experiment.FeatureID == nil || experiment.TagID == nil || experiment.Variants == nil {
+		errwriter.WriteHTTPError(w, appErrors.ErrExperimentFieldNotProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = validateExperiment(experiment)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	experimentID, err := h.srv.CreateExperiment(r.Context(), experiment)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrExperimentExists) {
+			errwriter.WriteHTTPError(w, appErrors.ErrExperimentExists, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrVariantVersionMismatch) {
+			errwriter.WriteHTTPError(w, appErrors.ErrVariantVersionMismatch, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrExperimentWithoutBanner) {
+			errwriter.WriteHTTPError(w, appErrors.ErrExperimentWithoutBanner, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		if writeSchemaViolations(w, err, logErrPrefix) {
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	if err = json.NewEncoder(w).Encode(domain.ExperimentID{ID: experimentID}); err != nil {
+		logger.Logger().Errorln(logErrPrefix, err)
+	}
+}
+
+func (h *experimenter) ListExperiments(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ListExperiments:"
+
+	featureIDStr := r.URL.Query().Get("feature_id")
+	tagIDStr := r.URL.Query().Get("tag_id")
+	limitStr := r.URL.Query().Get("limit")
+	offsetStr := r.URL.Query().Get("offset")
+
+	if limitStr == "" {
+		limitStr = "15"
+	}
+
+	if offsetStr == "" {
+		offsetStr = "0"
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		errwriter.WriteHTTPError(w, appErrors.ErrLimitIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if limit < 1 || limit > 100 {
+		errwriter.WriteHTTPError(w, appErrors.ErrLimitNotInRange, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil {
+		errwriter.WriteHTTPError(w, appErrors.ErrOffsetIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if offset < 0 {
+		errwriter.WriteHTTPError(w, appErrors.ErrOffsetNotInRange, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	var featureID *int
+	var tagID *int
+
+	if featureIDStr != "" {
+		featureIDBuf, err := strconv.Atoi(featureIDStr)
+		if err != nil {
+			errwriter.WriteHTTPError(w, appErrors.ErrFeatureIsNotANumber, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		featureID = &featureIDBuf
+	}
+
+	if tagIDStr != "" {
+		tagIDBuf, err := strconv.Atoi(tagIDStr)
+		if err != nil {
+			errwriter.WriteHTTPError(w, appErrors.ErrTagIsNotANumber, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		tagID = &tagIDBuf
+	}
+
+	experiments, err := h.srv.ListExperiments(r.Context(), featureID, tagID, limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(experiments)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err)
+	}
+}
+
+// UpdateExperiment rejects the changes leaving the experiment active on a pair without such a banner the same way as CreateExperiment.
+func (h *experimenter) UpdateExperiment(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.UpdateExperiment:"
+
+	experimentID, err := parseExperimentID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = reqval.ValidateJSONRequest(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var experiment domain.Experiment
+	if err = d.Decode(&experiment); err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if experiment.FeatureID == nil && experiment.TagID == nil && experiment.IsActive == nil && experiment.Variants == nil {
+		errwriter.WriteHTTPError(w, appErrors.ErrNoExperimentFieldsProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = validateExperiment(experiment)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.UpdateExperiment(r.Context(), experimentID, experiment)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrExperimentNotFound) {
+			errwriter.WriteHTTPError(w, appErrors.ErrExperimentNotFound, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrExperimentExists) {
+			errwriter.WriteHTTPError(w, appErrors.ErrExperimentExists, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrVariantVersionMismatch) {
+			errwriter.WriteHTTPError(w, appErrors.ErrVariantVersionMismatch, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrExperimentWithoutBanner) {
+			errwriter.WriteHTTPError(w, appErrors.ErrExperimentWithoutBanner, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		if writeSchemaViolations(w, err, logErrPrefix) {
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *experimenter) DeleteExperiment(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.DeleteExperiment:"
+
+	experimentID, err := parseExperimentID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.DeleteExperiment(r.Context(), experimentID)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrExperimentNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseExperimentID(r *http.Request) (int, error) {
+	experimentIDStr := r.PathValue("id")
+	if experimentIDStr == "" {
+		return 0, appErrors.ErrNoExperimentIDProvided
+	}
+
+	experimentID, err := strconv.Atoi(experimentIDStr)
+	if err != nil {
+		return 0, appErrors.ErrExperimentIDIsNotANumber
+	}
+
+	if experimentID < 1 {
+		return 0, appErrors.ErrExperimentIDNotInRange
+	}
+
+	return experimentID, nil
+}
+
+// validateExperiment checks the provided fields only, so it is used both for creation and for updates.
+func validateExperiment(experiment domain.Experiment) error {
+	if experiment.FeatureID != nil && *experiment.FeatureID < 1 {
+		return appErrors.ErrFeatureNotInRange
+	}
+
+	if experiment.TagID != nil && *experiment.TagID < 1 {
+		return appErrors.ErrTagNotInRange
+	}
+
+	if experiment.Variants == nil {
+		return nil
+	}
+
+	if len(experiment.Variants) < minVariants || len(experiment.Variants) > maxVariants {
+		return appErrors.ErrVariantsInvalid
+	}
+
+	keys := make(map[string]struct{}, len(experiment.Variants))
+	for _, variant := range experiment.Variants {
+		if variant.Key == "" || len(variant.Key) > maxVariantKeyLength || variant.VersionID < 1 || variant.Weight < 1 {
+			return appErrors.ErrVariantsInvalid
+		}
+
+		if _, ok := keys[variant.Key]; ok {
+			return appErrors.ErrVariantsInvalid
+		}
+
+		keys[variant.Key] = struct{}{}
+	}
+
+	return nil
+}
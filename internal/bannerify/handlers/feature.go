@@ -0,0 +1,308 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/internal/pkg/errwriter"
+	"github.com/PoorMercymain/bannerify/pkg/jsonschema"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+	"github.com/PoorMercymain/bannerify/pkg/reqval"
+)
+
+type featureRegistry struct {
+	srv domain.BannerServiceFeatureRegistry
+}
+
+func NewFeatureRegistry(srv domain.BannerServiceFeatureRegistry) *featureRegistry {
+	return &featureRegistry{srv: srv}
+}
+
+func (h *featureRegistry) SetFeatureSchema(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.SetFeatureSchema:"
+
+	featureID, err := parseFeatureID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	schema, err := readSchema(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.SetFeatureSchema(r.Context(), featureID, schema)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *featureRegistry) GetFeatureSchema(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.GetFeatureSchema:"
+
+	featureID, err := parseFeatureID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	schema, err := h.srv.GetFeatureSchema(r.Context(), featureID)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrFeatureSchemaNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, err = w.Write(schema)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+	}
+}
+
+func (h *featureRegistry) DeleteFeatureSchema(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.DeleteFeatureSchema:"
+
+	featureID, err := parseFeatureID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.DeleteFeatureSchema(r.Context(), featureID)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrFeatureSchemaNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// CheckFeatureSchema lists the banners of the feature which would not conform to the schema if it was set.
+func (h *featureRegistry) CheckFeatureSchema(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.CheckFeatureSchema:"
+
+	featureID, err := parseFeatureID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	schema, err := readSchema(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	banners, err := h.srv.CheckFeatureSchema(r.Context(), featureID, schema)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	if banners == nil {
+		banners = []domain.SchemaCheckElement{}
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(banners)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err)
+	}
+}
+
+func (h *featureRegistry) SetFeatureFallback(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.SetFeatureFallback:"
+
+	featureID, err := parseFeatureID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = reqval.ValidateJSONRequest(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var bannerID domain.BannerID
+	if err = d.Decode(&bannerID); err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if bannerID.ID < 1 {
+		errwriter.WriteHTTPError(w, appErrors.ErrBannerIDNotInRange, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.SetFeatureFallback(r.Context(), featureID, bannerID.ID)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrBannerNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if errors.Is(err, appErrors.ErrFallbackOfOtherFeature) {
+			errwriter.WriteHTTPError(w, appErrors.ErrFallbackOfOtherFeature, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if writeSchemaViolations(w, err, logErrPrefix) {
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *featureRegistry) GetFeatureFallback(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.GetFeatureFallback:"
+
+	featureID, err := parseFeatureID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	bannerID, err := h.srv.GetFeatureFallback(r.Context(), featureID)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrFeatureFallbackNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(domain.BannerID{ID: bannerID})
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err)
+	}
+}
+
+func (h *featureRegistry) DeleteFeatureFallback(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.DeleteFeatureFallback:"
+
+	featureID, err := parseFeatureID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.DeleteFeatureFallback(r.Context(), featureID)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrFeatureFallbackNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func parseFeatureID(r *http.Request) (int, error) {
+	featureIDStr := r.PathValue("id")
+	if featureIDStr == "" {
+		return 0, appErrors.ErrNoFeatureIDProvided
+	}
+
+	featureID, err := strconv.Atoi(featureIDStr)
+	if err != nil {
+		return 0, appErrors.ErrFeatureIsNotANumber
+	}
+
+	if featureID < 1 {
+		return 0, appErrors.ErrFeatureNotInRange
+	}
+
+	return featureID, nil
+}
+
+// readSchema returns the request body if it is a JSON Schema which can be compiled.
+func readSchema(r *http.Request) ([]byte, error) {
+	err := reqval.ValidateJSONRequest(r)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, appErrors.ErrSomethingWentWrong
+	}
+
+	_, err = jsonschema.Compile(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// writeSchemaViolations writes the places of the banner content which do not conform to the schema of the feature,
+// it reports false if err is not about them.
+func writeSchemaViolations(w http.ResponseWriter, err error, logErrPrefix string) bool {
+	var validationErr *jsonschema.ValidationError
+	if !errors.Is(err, appErrors.ErrContentSchemaViolation) || !errors.As(err, &validationErr) {
+		return false
+	}
+
+	details := make([]domain.ErrorDetail, 0, len(validationErr.Violations))
+	for _, violation := range validationErr.Violations {
+		details = append(details, domain.ErrorDetail{Path: violation.Path, Message: violation.Message})
+	}
+
+	errwriter.WriteHTTPErrorDetails(w, appErrors.ErrContentSchemaViolation, details, http.StatusBadRequest, logErrPrefix)
+
+	return true
+}
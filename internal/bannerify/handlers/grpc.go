@@ -0,0 +1,535 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/api"
+	"github.com/PoorMercymain/bannerify/pkg/jsonschema"
+	"github.com/PoorMercymain/bannerify/pkg/locale"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+	"github.com/PoorMercymain/bannerify/pkg/template"
+)
+
+var (
+	_ api.BannerServiceServer = (*bannerServer)(nil)
+)
+
+// bannerServer serves the banner endpoints over gRPC with the same services, validation and errors as the REST handlers.
+type bannerServer struct {
+	api.UnimplementedBannerServiceServer
+	getter    domain.BannerServiceGetter
+	versioner domain.BannerServiceVersioner
+	creator   domain.BannerServiceCreator
+	updater   domain.BannerServiceUpdater
+	deleter   domain.BannerServiceDeleter
+	deleteCtx context.Context
+	isAdmin   func(ctx context.Context) bool
+}
+
+// NewBannerServer takes isAdmin to read the role the interceptors authorized the call with.
+func NewBannerServer(getter domain.BannerServiceGetter, versioner domain.BannerServiceVersioner, creator domain.BannerServiceCreator, updater domain.BannerServiceUpdater, deleter domain.BannerServiceDeleter, deleteCtx context.Context, isAdmin func(ctx context.Context) bool) *bannerServer {
+	return &bannerServer{getter: getter, versioner: versioner, creator: creator, updater: updater, deleter: deleter, deleteCtx: deleteCtx, isAdmin: isAdmin}
+}
+
+func (s *bannerServer) GetBanner(ctx context.Context, req *api.GetBannerRequest) (*api.UserBanner, error) {
+	const logErrPrefix = "handlers.grpc.GetBanner:"
+
+	if len(req.TagIds) == 0 || req.FeatureId == 0 {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrTagOrFeatureNotProvided.Error())
+	}
+
+	if len(req.TagIds) > maxUserTags {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrTooManyTags.Error())
+	}
+
+	user, err := newUserContext(req.UserId, req.Attributes, req.Locales)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	banner, err := s.getter.GetBanner(ctx, intIDs(req.TagIds), int(req.FeatureId), s.isAdmin(ctx), req.UseLastRevision, user)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrBannerNotFound) {
+			return nil, status.Error(codes.NotFound, appErrors.ErrBannerNotFound.Error())
+		}
+
+		return nil, internalError(err, logErrPrefix)
+	}
+
+	banner, err = renderBanner(banner, user.Attributes)
+	if err != nil {
+		if errors.Is(err, template.ErrMissingVariable) {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+
+		return nil, internalError(err, logErrPrefix)
+	}
+
+	content, err := toValue(banner.Content)
+	if err != nil {
+		return nil, internalError(err, logErrPrefix)
+	}
+
+	return &api.UserBanner{
+		Content:   content,
+		BannerId:  int32(banner.BannerID),
+		VersionId: int32(banner.VersionID),
+		TagId:     int32(banner.TagID),
+		Variant:   banner.Variant,
+		Locale:    banner.Locale,
+		Stale:     banner.Stale,
+		Fallback:  banner.FeatureFallback,
+		Etag:      banner.ETag,
+	}, nil
+}
+
+func (s *bannerServer) ListBanners(ctx context.Context, req *api.ListBannersRequest) (*api.ListBannersResponse, error) {
+	const logErrPrefix = "handlers.grpc.ListBanners:"
+
+	limit := 15
+	if req.Limit != nil {
+		limit = int(*req.Limit)
+	}
+
+	if limit < 1 || limit > 100 {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrLimitNotInRange.Error())
+	}
+
+	if req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrOffsetNotInRange.Error())
+	}
+
+	banners, err := s.getter.ListBanners(ctx, optionalID(req.TagId), optionalID(req.FeatureId), limit, int(req.Offset))
+	if err != nil {
+		return nil, internalError(err, logErrPrefix)
+	}
+
+	res := &api.ListBannersResponse{Banners: make([]*api.Banner, 0, len(banners))}
+	for _, banner := range banners {
+		content, err := toValue(banner.Content)
+		if err != nil {
+			return nil, internalError(err, logErrPrefix)
+		}
+
+		locales, err := toValues(banner.Locales)
+		if err != nil {
+			return nil, internalError(err, logErrPrefix)
+		}
+
+		res.Banners = append(res.Banners, &api.Banner{
+			BannerId:         int32(banner.BannerID),
+			TagIds:           int32IDs(banner.TagIDs),
+			FeatureId:        int32(banner.FeatureID),
+			Content:          content,
+			IsActive:         banner.IsActive,
+			ActiveFrom:       banner.ActiveFrom,
+			ActiveUntil:      banner.ActiveUntil,
+			Targeting:        banner.Targeting,
+			DefaultLocale:    banner.DefaultLocale,
+			Locales:          locales,
+			IsTemplate:       banner.IsTemplate,
+			MissingVariables: banner.MissingVariables,
+			FrequencyCap:     optionalInt32(banner.FrequencyCap),
+			CreatedAt:        banner.CreatedAt,
+			UpdatedAt:        banner.UpdatedAt,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *bannerServer) ListVersions(ctx context.Context, req *api.ListVersionsRequest) (*api.ListVersionsResponse, error) {
+	const logErrPrefix = "handlers.grpc.ListVersions:"
+
+	if req.BannerId == 0 {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrNoBannerIDProvided.Error())
+	}
+
+	limit := 3
+	if req.Limit != nil {
+		limit = int(*req.Limit)
+	}
+
+	if limit < 0 || limit > 100 {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrLimitNotInRange.Error())
+	}
+
+	if req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrOffsetNotInRange.Error())
+	}
+
+	versions, err := s.versioner.ListVersions(ctx, int(req.BannerId), limit, int(req.Offset))
+	if err != nil {
+		return nil, internalError(err, logErrPrefix)
+	}
+
+	if len(versions) == 0 {
+		return nil, status.Error(codes.NotFound, appErrors.ErrBannerNotFound.Error())
+	}
+
+	res := &api.ListVersionsResponse{Versions: make([]*api.Version, 0, len(versions))}
+	for _, version := range versions {
+		content, err := toValue(version.Content)
+		if err != nil {
+			return nil, internalError(err, logErrPrefix)
+		}
+
+		locales, err := toValues(version.Locales)
+		if err != nil {
+			return nil, internalError(err, logErrPrefix)
+		}
+
+		res.Versions = append(res.Versions, &api.Version{
+			VersionId:        int32(version.VersionID),
+			TagIds:           int32IDs(version.TagIDs),
+			FeatureId:        int32(version.FeatureID),
+			Content:          content,
+			IsActive:         version.IsActive,
+			ActiveFrom:       version.ActiveFrom,
+			ActiveUntil:      version.ActiveUntil,
+			Targeting:        version.Targeting,
+			DefaultLocale:    version.DefaultLocale,
+			Locales:          locales,
+			IsTemplate:       version.IsTemplate,
+			MissingVariables: version.MissingVariables,
+			FrequencyCap:     optionalInt32(version.FrequencyCap),
+			CreatedAt:        version.CreatedAt,
+			UpdatedAt:        version.UpdatedAt,
+			IsChosen:         version.IsChosen,
+		})
+	}
+
+	return res, nil
+}
+
+func (s *bannerServer) ChooseVersion(ctx context.Context, req *api.ChooseVersionRequest) (*api.ChooseVersionResponse, error) {
+	const logErrPrefix = "handlers.grpc.ChooseVersion:"
+
+	if req.BannerId < 1 {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrBannerIDNotInRange.Error())
+	}
+
+	if req.VersionId < 1 {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrVersionIDNotInRange.Error())
+	}
+
+	err := s.versioner.ChooseVersion(ctx, int(req.BannerId), int(req.VersionId))
+	if err != nil {
+		if errors.Is(err, appErrors.ErrBannerTagUniqueViolation) {
+			return nil, status.Error(codes.AlreadyExists, appErrors.ErrBannerTagUniqueViolation.Error())
+		}
+
+		if errors.Is(err, appErrors.ErrBannerNotFound) || errors.Is(err, appErrors.ErrVersionNotFound) {
+			return nil, status.Error(codes.NotFound, appErrors.ErrVersionNotFound.Error())
+		}
+
+		if violations, ok := schemaViolationsError(err); ok {
+			return nil, violations
+		}
+
+		return nil, internalError(err, logErrPrefix)
+	}
+
+	return &api.ChooseVersionResponse{}, nil
+}
+
+func (s *bannerServer) CreateBanner(ctx context.Context, req *api.CreateBannerRequest) (*api.CreateBannerResponse, error) {
+	const logErrPrefix = "handlers.grpc.CreateBanner:"
+
+	banner, err := fromBannerFields(req.Banner)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if banner.Content == nil || banner.FeatureID == nil || banner.IsActive == nil || banner.TagIDs == nil {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrBannerFieldNotProvided.Error())
+	}
+
+	if err = validateBanner(&banner); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	bannerID, err := s.creator.CreateBanner(ctx, banner)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrBannerTagUniqueViolation) {
+			return nil, status.Error(codes.InvalidArgument, appErrors.ErrBannerTagUniqueViolation.Error())
+		}
+
+		if errors.Is(err, appErrors.ErrActiveWindowInvalid) {
+			return nil, status.Error(codes.InvalidArgument, appErrors.ErrActiveWindowInvalid.Error())
+		}
+
+		if violations, ok := schemaViolationsError(err); ok {
+			return nil, violations
+		}
+
+		return nil, internalError(err, logErrPrefix)
+	}
+
+	return &api.CreateBannerResponse{BannerId: int32(bannerID)}, nil
+}
+
+func (s *bannerServer) UpdateBanner(ctx context.Context, req *api.UpdateBannerRequest) (*api.UpdateBannerResponse, error) {
+	const logErrPrefix = "handlers.grpc.UpdateBanner:"
+
+	if req.BannerId == 0 {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrNoBannerIDProvided.Error())
+	}
+
+	banner, err := fromBannerFields(req.Banner)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if banner.Content == nil && banner.FeatureID == nil && banner.IsActive == nil && banner.TagIDs == nil && banner.ActiveFrom == nil && banner.ActiveUntil == nil && !banner.ClearActiveFrom && !banner.ClearActiveUntil && banner.Targeting == nil && banner.DefaultLocale == nil && banner.Locales == nil && banner.IsTemplate == nil && banner.MissingVariables == nil && banner.FrequencyCap == nil {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrNoBannerFieldsProvided.Error())
+	}
+
+	if err = validateBanner(&banner); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	err = s.updater.UpdateBanner(ctx, int(req.BannerId), banner)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrBannerTagUniqueViolation) {
+			return nil, status.Error(codes.InvalidArgument, appErrors.ErrBannerTagUniqueViolation.Error())
+		}
+
+		if errors.Is(err, appErrors.ErrActiveWindowInvalid) {
+			return nil, status.Error(codes.InvalidArgument, appErrors.ErrActiveWindowInvalid.Error())
+		}
+
+		if errors.Is(err, appErrors.ErrBannerNotFound) || errors.Is(err, appErrors.ErrVersionNotFound) {
+			return nil, status.Error(codes.NotFound, appErrors.ErrBannerNotFound.Error())
+		}
+
+		if errors.Is(err, appErrors.ErrTemplateInvalid) {
+			return nil, status.Error(codes.InvalidArgument, appErrors.ErrTemplateInvalid.Error())
+		}
+
+		if violations, ok := schemaViolationsError(err); ok {
+			return nil, violations
+		}
+
+		return nil, internalError(err, logErrPrefix)
+	}
+
+	return &api.UpdateBannerResponse{}, nil
+}
+
+func (s *bannerServer) DeleteBanner(ctx context.Context, req *api.DeleteBannerRequest) (*api.DeleteBannerResponse, error) {
+	const logErrPrefix = "handlers.grpc.DeleteBanner:"
+
+	if req.BannerId == 0 {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrNoBannerIDProvided.Error())
+	}
+
+	err := s.deleter.DeleteBannerByID(ctx, int(req.BannerId))
+	if err != nil {
+		if errors.Is(err, appErrors.ErrBannerNotFound) {
+			return nil, status.Error(codes.NotFound, appErrors.ErrBannerNotFound.Error())
+		}
+
+		return nil, internalError(err, logErrPrefix)
+	}
+
+	return &api.DeleteBannerResponse{}, nil
+}
+
+func (s *bannerServer) DeleteBanners(ctx context.Context, req *api.DeleteBannersRequest) (*api.DeleteBannersResponse, error) {
+	const logErrPrefix = "handlers.grpc.DeleteBanners:"
+
+	if req.TagId == nil && req.FeatureId == nil {
+		return nil, status.Error(codes.InvalidArgument, appErrors.ErrTagOrFeatureNotProvided.Error())
+	}
+
+	err := s.deleter.DeleteBannerByTagOrFeature(ctx, s.deleteCtx, optionalID(req.TagId), optionalID(req.FeatureId))
+	if err != nil {
+		if errors.Is(err, appErrors.ErrBannerNotFound) {
+			return nil, status.Error(codes.NotFound, appErrors.ErrBannerNotFound.Error())
+		}
+
+		return nil, internalError(err, logErrPrefix)
+	}
+
+	return &api.DeleteBannersResponse{}, nil
+}
+
+// internalError does not pass the cause to the client, like the REST handlers do not.
+func internalError(err error, logErrPrefix string) error {
+	logger.Logger().Errorln(logErrPrefix, err.Error())
+	return status.Error(codes.Internal, appErrors.ErrSomethingWentWrong.Error())
+}
+
+// schemaViolationsError describes the violations of the content schema as the field violations of a bad request.
+func schemaViolationsError(err error) (error, bool) {
+	var validationErr *jsonschema.ValidationError
+	if !errors.Is(err, appErrors.ErrContentSchemaViolation) || !errors.As(err, &validationErr) {
+		return nil, false
+	}
+
+	badRequest := &errdetails.BadRequest{}
+	for _, violation := range validationErr.Violations {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{Field: violation.Path, Description: violation.Message})
+	}
+
+	st, detailsErr := status.New(codes.InvalidArgument, appErrors.ErrContentSchemaViolation.Error()).WithDetails(badRequest)
+	if detailsErr != nil {
+		return status.Error(codes.InvalidArgument, appErrors.ErrContentSchemaViolation.Error()), true
+	}
+
+	return st.Err(), true
+}
+
+// newUserContext checks the user like parseUserContext does, the locales are in the order of preference.
+func newUserContext(userID string, attributes map[string]string, locales []string) (domain.UserContext, error) {
+	if len(userID) > maxUserIDLength {
+		return domain.UserContext{}, appErrors.ErrUserIDTooLong
+	}
+
+	user := domain.UserContext{UserID: userID, Attributes: make(map[string]string, len(attributes)), Locales: make([]string, 0, len(locales))}
+	for name, value := range attributes {
+		user.Attributes[strings.ToLower(name)] = value
+	}
+
+	if err := validateUserAttributes(user.Attributes); err != nil {
+		return domain.UserContext{}, err
+	}
+
+	for _, localeStr := range locales {
+		userLocale, ok := locale.Canonical(localeStr)
+		if !ok {
+			return domain.UserContext{}, appErrors.ErrLocaleInvalid
+		}
+
+		user.Locales = append(user.Locales, userLocale)
+	}
+
+	return user, nil
+}
+
+// fromBannerFields returns the banner with only the fields which are set, so it is handled like a decoded JSON body.
+func fromBannerFields(fields *api.BannerFields) (domain.Banner, error) {
+	var banner domain.Banner
+	if fields == nil {
+		return banner, nil
+	}
+
+	if fields.TagIds != nil {
+		banner.TagIDs = intIDs(fields.TagIds.TagIds)
+	}
+
+	if fields.Content != nil {
+		content, err := protojson.Marshal(fields.Content)
+		if err != nil {
+			return banner, err
+		}
+
+		banner.Content = content
+	}
+
+	if fields.Locales != nil {
+		banner.Locales = make(map[string]json.RawMessage, len(fields.Locales.Locales))
+		for tag, localized := range fields.Locales.Locales {
+			content, err := protojson.Marshal(localized)
+			if err != nil {
+				return banner, err
+			}
+
+			banner.Locales[tag] = content
+		}
+	}
+
+	if fields.ActiveFrom != nil {
+		activeFrom := fields.ActiveFrom.AsTime()
+		banner.ActiveFrom = &activeFrom
+	}
+
+	if fields.ActiveUntil != nil {
+		activeUntil := fields.ActiveUntil.AsTime()
+		banner.ActiveUntil = &activeUntil
+	}
+
+	banner.ClearActiveFrom = fields.ClearActiveFrom
+	banner.ClearActiveUntil = fields.ClearActiveUntil
+
+	banner.FeatureID = optionalID(fields.FeatureId)
+	banner.IsActive = fields.IsActive
+	banner.Targeting = fields.Targeting
+	banner.DefaultLocale = fields.DefaultLocale
+	banner.IsTemplate = fields.IsTemplate
+	banner.MissingVariables = fields.MissingVariables
+	banner.FrequencyCap = optionalID(fields.FrequencyCap)
+
+	return banner, nil
+}
+
+func toValue(content json.RawMessage) (*structpb.Value, error) {
+	var res structpb.Value
+	if err := protojson.Unmarshal(content, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+func toValues(locales map[string]json.RawMessage) (map[string]*structpb.Value, error) {
+	res := make(map[string]*structpb.Value, len(locales))
+	for tag, content := range locales {
+		localized, err := toValue(content)
+		if err != nil {
+			return nil, err
+		}
+
+		res[tag] = localized
+	}
+
+	return res, nil
+}
+
+func intIDs(ids []int32) []int {
+	res := make([]int, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, int(id))
+	}
+
+	return res
+}
+
+func int32IDs(ids []int) []int32 {
+	res := make([]int32, 0, len(ids))
+	for _, id := range ids {
+		res = append(res, int32(id))
+	}
+
+	return res
+}
+
+func optionalID(id *int32) *int {
+	if id == nil {
+		return nil
+	}
+
+	res := int(*id)
+	return &res
+}
+
+func optionalInt32(value *int) *int32 {
+	if value == nil {
+		return nil
+	}
+
+	res := int32(*value)
+	return &res
+}
@@ -4,17 +4,52 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	appErrors "github.com/PoorMercymain/bannerify/errors"
 	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
 	"github.com/PoorMercymain/bannerify/internal/pkg/errwriter"
+	"github.com/PoorMercymain/bannerify/pkg/compress"
+	"github.com/PoorMercymain/bannerify/pkg/etag"
 	"github.com/PoorMercymain/bannerify/pkg/jwt"
+	"github.com/PoorMercymain/bannerify/pkg/locale"
 	"github.com/PoorMercymain/bannerify/pkg/logger"
 	"github.com/PoorMercymain/bannerify/pkg/reqval"
+	"github.com/PoorMercymain/bannerify/pkg/targeting"
+	"github.com/PoorMercymain/bannerify/pkg/template"
+)
+
+const (
+	maxBatchFeatures = 100
+	maxUserTags      = 100
+	maxUserIDLength  = 256
+
+	maxUserAttributes      = 32
+	maxUserAttributeLength = 256
+	maxTargetingLength     = 1024
+	maxBannerLocales       = 50
+
+	// userAttributeHeaderPrefix starts the headers with the user attributes, X-User-App-Version is the app_version attribute.
+	userAttributeHeaderPrefix = "X-User-"
+
+	// variantHeader names the experiment variant the banner was chosen from.
+	variantHeader = "X-Experiment-Variant"
+
+	// bannerIDHeader, versionIDHeader and tagIDHeader name the banner which was served, so the client can report its events.
+	bannerIDHeader  = "X-Banner-ID"
+	versionIDHeader = "X-Banner-Version-ID"
+	tagIDHeader     = "X-Banner-Tag-ID"
+
+	// fallbackHeader is sent with the fallback banner of the feature, which is served when no banner of the tags is.
+	fallbackHeader = "X-Banner-Fallback"
+
+	// staleWarning is sent with the banners served from the last known good copy when the database is unavailable.
+	staleWarning = `110 - "Response is Stale"`
 )
 
 type pingProvider struct {
@@ -48,17 +83,17 @@ func (h *bannerGetter) GetBanner(isAdmin bool) http.HandlerFunc {
 		defer r.Body.Close()
 		const logErrPrefix = "handlers.GetBanner:"
 
-		tagIDStr := r.URL.Query().Get("tag_id")
+		tagIDStrs := r.URL.Query()["tag_id"]
 		featureIDStr := r.URL.Query().Get("feature_id")
 
-		if tagIDStr == "" || featureIDStr == "" {
+		if len(tagIDStrs) == 0 || featureIDStr == "" {
 			errwriter.WriteHTTPError(w, appErrors.ErrTagOrFeatureNotProvided, http.StatusBadRequest, logErrPrefix)
 			return
 		}
 
-		tagID, err := strconv.Atoi(tagIDStr)
+		tagIDs, err := parseTagIDs(tagIDStrs)
 		if err != nil {
-			errwriter.WriteHTTPError(w, appErrors.ErrTagIsNotANumber, http.StatusBadRequest, logErrPrefix)
+			errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
 			return
 		}
 
@@ -79,7 +114,13 @@ func (h *bannerGetter) GetBanner(isAdmin bool) http.HandlerFunc {
 			return
 		}
 
-		banner, err := h.srv.GetBanner(r.Context(), tagID, featureID, isAdmin, dbRequired)
+		user, err := parseUserContext(r)
+		if err != nil {
+			errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		banner, err := h.srv.GetBanner(r.Context(), tagIDs, featureID, isAdmin, dbRequired, user)
 		if err != nil {
 			if errors.Is(err, appErrors.ErrBannerNotFound) {
 				w.WriteHeader(http.StatusNotFound)
@@ -91,13 +132,442 @@ func (h *bannerGetter) GetBanner(isAdmin bool) http.HandlerFunc {
 			return
 		}
 
+		banner, err = renderBanner(banner, user.Attributes)
+		if err != nil {
+			if errors.Is(err, template.ErrMissingVariable) {
+				errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+				return
+			}
+
+			w.WriteHeader(http.StatusInternalServerError)
+			logger.Logger().Errorln(logErrPrefix, err.Error())
+			return
+		}
+
+		payload, bannerETag := []byte(banner.Content), banner.ETag
+		encoding := compress.Negotiate(r.Header.Get("Accept-Encoding"))
+		if encoded, ok := banner.Encoded[encoding]; ok {
+			payload, bannerETag = encoded, etag.WithEncoding(banner.ETag, encoding)
+			w.Header().Set("Content-Encoding", encoding)
+		}
+
+		if banner.Stale {
+			w.Header().Set("Warning", staleWarning)
+		}
+
+		if banner.Variant != "" {
+			w.Header().Set(variantHeader, banner.Variant)
+		}
+
+		if banner.FeatureFallback {
+			w.Header().Set(fallbackHeader, "true")
+		}
+
+		if banner.BannerID != 0 {
+			w.Header().Set(bannerIDHeader, strconv.Itoa(banner.BannerID))
+		}
+
+		if banner.TagID != 0 {
+			w.Header().Set(tagIDHeader, strconv.Itoa(banner.TagID))
+		}
+
+		w.Header().Set(versionIDHeader, strconv.Itoa(banner.VersionID))
+
+		if banner.Locale != "" {
+			w.Header().Set("Content-Language", banner.Locale)
+		}
+
+		if len(banner.Locales) != 0 {
+			w.Header().Add("Vary", "Accept-Language")
+		}
+
+		w.Header().Add("Vary", "Accept-Encoding")
+		w.Header().Set("ETag", bannerETag)
+		w.Header().Set("Cache-Control", bannerCacheControl(isAdmin || banner.Targeted || banner.Template, dbRequired || banner.Capped, banner.ExpiresAt))
+
+		if etag.Match(r.Header.Get("If-None-Match"), bannerETag) {
+			w.Header().Del("Content-Encoding")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err = w.Write(payload)
+		if err != nil {
+			logger.Logger().Errorln(logErrPrefix, err.Error())
+		}
+	}
+}
+
+func (h *bannerGetter) GetBanners(isAdmin bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		const logErrPrefix = "handlers.GetBanners:"
+
+		tagIDStrs := r.URL.Query()["tag_id"]
+		featureIDStrs := r.URL.Query()["feature_id"]
+
+		if len(tagIDStrs) == 0 || len(featureIDStrs) == 0 {
+			errwriter.WriteHTTPError(w, appErrors.ErrTagOrFeatureNotProvided, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		tagIDs, err := parseTagIDs(tagIDStrs)
+		if err != nil {
+			errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		featureIDs, err := parseIDList(featureIDStrs)
+		if err != nil {
+			errwriter.WriteHTTPError(w, appErrors.ErrFeatureIsNotANumber, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if len(featureIDs) == 0 {
+			errwriter.WriteHTTPError(w, appErrors.ErrTagOrFeatureNotProvided, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if len(featureIDs) > maxBatchFeatures {
+			errwriter.WriteHTTPError(w, appErrors.ErrTooManyFeatures, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		var dbRequired bool
+
+		if r.URL.Query().Get("use_last_revision") == "true" {
+			dbRequired = true
+		} else if r.URL.Query().Get("use_last_revision") == "false" {
+			dbRequired = false
+		} else if r.URL.Query().Get("use_last_revision") != "" {
+			errwriter.WriteHTTPError(w, appErrors.ErrUseLastRevisionNotBool, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		user, err := parseUserContext(r)
+		if err != nil {
+			errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		banners, err := h.srv.GetBanners(r.Context(), tagIDs, featureIDs, isAdmin, dbRequired, user)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			logger.Logger().Errorln(logErrPrefix, err.Error())
+			return
+		}
+
+		res := make(map[int]domain.BannerBatchElement, len(featureIDs))
+		for _, featureID := range featureIDs {
+			banner, ok := banners[featureID]
+			if !ok {
+				res[featureID] = domain.BannerBatchElement{Err: appErrors.ErrBannerNotFound.Error()}
+				continue
+			}
+
+			if banner.Stale {
+				w.Header().Set("Warning", staleWarning)
+			}
+
+			res[featureID] = newBatchElement(banner, user.Attributes, logErrPrefix)
+		}
+
 		w.Header().Add("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
-		_, err = w.Write([]byte(banner))
+
+		err = json.NewEncoder(w).Encode(res)
 		if err != nil {
+			logger.Logger().Errorln(logErrPrefix, err)
+		}
+	}
+}
+
+// newBatchElement renders the banner for the user, the element has the error instead of the content if it cannot be rendered.
+func newBatchElement(banner domain.UserBanner, variables map[string]string, logErrPrefix string) domain.BannerBatchElement {
+	banner, err := renderBanner(banner, variables)
+	if err != nil {
+		if !errors.Is(err, template.ErrMissingVariable) {
 			logger.Logger().Errorln(logErrPrefix, err.Error())
 		}
+
+		return domain.BannerBatchElement{Err: err.Error()}
+	}
+
+	return domain.BannerBatchElement{Content: banner.Content, BannerID: banner.BannerID, VersionID: banner.VersionID, TagID: banner.TagID, Variant: banner.Variant, Locale: banner.Locale, Stale: banner.Stale, Fallback: banner.FeatureFallback}
+}
+
+// renderBanner fills in the placeholders of a template from the user attributes, the rendered banner gets its own ETag.
+// The rendered content is compressed per request if it is large enough, the other banners are returned as they are.
+func renderBanner(banner domain.UserBanner, variables map[string]string) (domain.UserBanner, error) {
+	if !banner.Template {
+		return banner, nil
+	}
+
+	content, err := template.Render(banner.Content, variables, template.Missing(banner.MissingVariables))
+	if err != nil {
+		return domain.UserBanner{}, err
+	}
+
+	banner.Content = content
+	banner.ETag = etag.Make(banner.VersionID, content)
+	banner.Encoded = nil
+
+	if len(content) < compress.MinSize {
+		return banner, nil
+	}
+
+	banner.Encoded = make(map[string][]byte, len(compress.Encodings))
+	for _, encoding := range compress.Encodings {
+		encoded, err := compress.Encode(encoding, content)
+		if err != nil {
+			return domain.UserBanner{}, err
+		}
+
+		banner.Encoded[encoding] = encoded
 	}
+
+	return banner, nil
+}
+
+// bannerCacheControl lets HTTP caches keep the banner for no longer than the service cache would,
+// so the overall staleness stays within the same budget.
+// The banners for admins, the ones chosen by the targeting rules and the rendered templates are not stored by shared caches,
+// because the rules and the template variables may depend on the headers.
+// The banners chosen by the frequency caps are revalidated every time, so every show is counted.
+func bannerCacheControl(private bool, noCache bool, expiresAt time.Time) string {
+	if noCache {
+		return "no-cache"
+	}
+
+	maxAge := max(int(time.Until(expiresAt)/time.Second), 0)
+	if private {
+		return fmt.Sprintf("private, max-age=%d", maxAge)
+	}
+
+	return fmt.Sprintf("public, max-age=%d", maxAge)
+}
+
+// parseUserContext reads the user id which the experiment variants are chosen by, the attributes for the targeting rules
+// and the preferred locales: the locale query parameter goes before the languages of the Accept-Language header.
+func parseUserContext(r *http.Request) (domain.UserContext, error) {
+	userID := r.URL.Query().Get("user_id")
+	if len(userID) > maxUserIDLength {
+		return domain.UserContext{}, appErrors.ErrUserIDTooLong
+	}
+
+	attributes, err := parseUserAttributes(r)
+	if err != nil {
+		return domain.UserContext{}, err
+	}
+
+	locales := locale.ParseAcceptLanguage(r.Header.Get("Accept-Language"))
+	if localeStr := r.URL.Query().Get("locale"); localeStr != "" {
+		userLocale, ok := locale.Canonical(localeStr)
+		if !ok {
+			return domain.UserContext{}, appErrors.ErrLocaleInvalid
+		}
+
+		locales = append([]string{userLocale}, locales...)
+	}
+
+	return domain.UserContext{UserID: userID, Attributes: attributes, Locales: locales}, nil
+}
+
+// parseUserAttributes reads the attributes matched against the targeting rules and filling in the templates from the query parameters
+// other than the ones of the endpoint, and from the X-User-* headers. The query parameters take precedence.
+func parseUserAttributes(r *http.Request) (map[string]string, error) {
+	attributes := make(map[string]string)
+	for name, values := range r.URL.Query() {
+		switch name {
+		case "tag_id", "feature_id", "use_last_revision", "user_id", "locale":
+			continue
+		}
+
+		attributes[strings.ToLower(name)] = values[0]
+	}
+
+	for name, values := range r.Header {
+		if !strings.HasPrefix(name, userAttributeHeaderPrefix) || len(name) == len(userAttributeHeaderPrefix) {
+			continue
+		}
+
+		attribute := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(name, userAttributeHeaderPrefix), "-", "_"))
+		if _, ok := attributes[attribute]; !ok {
+			attributes[attribute] = values[0]
+		}
+	}
+
+	if err := validateUserAttributes(attributes); err != nil {
+		return nil, err
+	}
+
+	return attributes, nil
+}
+
+func validateUserAttributes(attributes map[string]string) error {
+	if len(attributes) > maxUserAttributes {
+		return appErrors.ErrUserAttributesInvalid
+	}
+
+	for _, value := range attributes {
+		if len(value) > maxUserAttributeLength {
+			return appErrors.ErrUserAttributesInvalid
+		}
+	}
+
+	return nil
+}
+
+// validateLocales stores the language tags in their canonical form, so they are matched exactly by the database.
+// An empty default locale removes it.
+func validateLocales(banner *domain.Banner) error {
+	if banner.DefaultLocale != nil && *banner.DefaultLocale != "" {
+		defaultLocale, ok := locale.Canonical(*banner.DefaultLocale)
+		if !ok {
+			return appErrors.ErrLocaleInvalid
+		}
+
+		banner.DefaultLocale = &defaultLocale
+	}
+
+	if banner.Locales == nil {
+		return nil
+	}
+
+	if len(banner.Locales) > maxBannerLocales {
+		return appErrors.ErrLocalesInvalid
+	}
+
+	locales := make(map[string]json.RawMessage, len(banner.Locales))
+	for tag, content := range banner.Locales {
+		canonical, ok := locale.Canonical(tag)
+		if !ok {
+			return appErrors.ErrLocaleInvalid
+		}
+
+		if _, ok := locales[canonical]; ok || (banner.DefaultLocale != nil && *banner.DefaultLocale == canonical) {
+			return appErrors.ErrLocalesInvalid
+		}
+
+		locales[canonical] = content
+	}
+
+	banner.Locales = locales
+
+	return nil
+}
+
+// validateBanner checks the fields provided for a new version of the banner, the locales are stored in their canonical form.
+func validateBanner(banner *domain.Banner) error {
+	if banner.ActiveFrom != nil && banner.ActiveUntil != nil && !banner.ActiveFrom.Before(*banner.ActiveUntil) {
+		return appErrors.ErrActiveWindowInvalid
+	}
+
+	if (banner.ClearActiveFrom && banner.ActiveFrom != nil) || (banner.ClearActiveUntil && banner.ActiveUntil != nil) {
+		return appErrors.ErrActiveWindowInvalid
+	}
+
+	if err := validateTargeting(banner.Targeting); err != nil {
+		return err
+	}
+
+	if banner.FrequencyCap != nil && *banner.FrequencyCap < 0 {
+		return appErrors.ErrFrequencyCapInvalid
+	}
+
+	if err := validateLocales(banner); err != nil {
+		return err
+	}
+
+	return validateTemplate(*banner)
+}
+
+// validateTemplate checks the placeholders of the provided content and translations if the banner is marked as a template.
+// The content of a banner which was a template before the update is checked by the repository.
+func validateTemplate(banner domain.Banner) error {
+	if banner.MissingVariables != nil && !template.Missing(*banner.MissingVariables).Valid() {
+		return appErrors.ErrMissingVariablesInvalid
+	}
+
+	if banner.IsTemplate == nil || !*banner.IsTemplate {
+		return nil
+	}
+
+	if banner.Content != nil {
+		if _, err := template.Variables(banner.Content); err != nil {
+			return appErrors.ErrTemplateInvalid
+		}
+	}
+
+	for _, content := range banner.Locales {
+		if _, err := template.Variables(content); err != nil {
+			return appErrors.ErrTemplateInvalid
+		}
+	}
+
+	return nil
+}
+
+// validateTargeting rejects the rules which can't be parsed, an empty rule removes the targeting.
+func validateTargeting(expr *string) error {
+	if expr == nil || *expr == "" {
+		return nil
+	}
+
+	if len(*expr) > maxTargetingLength {
+		return appErrors.ErrTargetingTooLong
+	}
+
+	_, err := targeting.Parse(*expr)
+	return err
+}
+
+func parseTagIDs(values []string) ([]int, error) {
+	tagIDs, err := parseIDList(values)
+	if err != nil {
+		return nil, appErrors.ErrTagIsNotANumber
+	}
+
+	if len(tagIDs) == 0 {
+		return nil, appErrors.ErrTagOrFeatureNotProvided
+	}
+
+	if len(tagIDs) > maxUserTags {
+		return nil, appErrors.ErrTooManyTags
+	}
+
+	return tagIDs, nil
+}
+
+// parseIDList accepts both repeated query parameters and comma-separated lists, duplicates are dropped.
+func parseIDList(values []string) ([]int, error) {
+	ids := make([]int, 0, len(values))
+	seen := make(map[int]struct{}, len(values))
+
+	for _, value := range values {
+		for _, idStr := range strings.Split(value, ",") {
+			idStr = strings.TrimSpace(idStr)
+			if idStr == "" {
+				continue
+			}
+
+			id, err := strconv.Atoi(idStr)
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := seen[id]; ok {
+				continue
+			}
+
+			seen[id] = struct{}{}
+			ids = append(ids, id)
+		}
+	}
+
+	return ids, nil
 }
 
 func (h *bannerGetter) ListBanners(w http.ResponseWriter, r *http.Request) {
@@ -179,6 +649,47 @@ func (h *bannerGetter) ListBanners(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (h *bannerGetter) CacheStats(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.CacheStats:"
+
+	w.Header().Add("Content-Type", "application/json")
+
+	w.WriteHeader(http.StatusOK)
+
+	err := json.NewEncoder(w).Encode(h.srv.CacheStats())
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err)
+	}
+}
+
+// WarmUp is not canceled if the admin does not wait for the response, because the work is useful anyway.
+func (h *bannerGetter) WarmUp(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.WarmUp:"
+
+	warmed, err := h.srv.WarmUp(context.WithoutCancel(r.Context()))
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWarmUpInProgress) {
+			errwriter.WriteHTTPError(w, appErrors.ErrWarmUpInProgress, http.StatusConflict, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, appErrors.ErrSomethingWentWrong, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(domain.WarmUpResult{Warmed: warmed})
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err)
+	}
+}
+
 type bannerVersioner struct {
 	srv domain.BannerServiceVersioner
 }
@@ -307,6 +818,10 @@ func (h *bannerVersioner) ChooseVersion(w http.ResponseWriter, r *http.Request)
 			return
 		}
 
+		if writeSchemaViolations(w, err, logErrPrefix) {
+			return
+		}
+
 		logger.Logger().Errorln(logErrPrefix, err.Error())
 		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
 		return
@@ -347,6 +862,11 @@ func (h *bannerCreator) CreateBanner(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if err = validateBanner(&banner); err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
 	bannerID, err := h.srv.CreateBanner(r.Context(), banner)
 	if err != nil {
 		if errors.Is(err, appErrors.ErrBannerTagUniqueViolation) {
@@ -354,6 +874,15 @@ func (h *bannerCreator) CreateBanner(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if errors.Is(err, appErrors.ErrActiveWindowInvalid) {
+			errwriter.WriteHTTPError(w, appErrors.ErrActiveWindowInvalid, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if writeSchemaViolations(w, err, logErrPrefix) {
+			return
+		}
+
 		logger.Logger().Errorln(logErrPrefix, err.Error())
 		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
 		return
@@ -407,11 +936,16 @@ func (h *bannerUpdater) UpdateBanner(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if banner.Content == nil && banner.FeatureID == nil && banner.IsActive == nil && banner.TagIDs == nil {
+	if banner.Content == nil && banner.FeatureID == nil && banner.IsActive == nil && banner.TagIDs == nil && banner.ActiveFrom == nil && banner.ActiveUntil == nil && !banner.ClearActiveFrom && !banner.ClearActiveUntil && banner.Targeting == nil && banner.DefaultLocale == nil && banner.Locales == nil && banner.IsTemplate == nil && banner.MissingVariables == nil && banner.FrequencyCap == nil {
 		errwriter.WriteHTTPError(w, appErrors.ErrNoBannerFieldsProvided, http.StatusBadRequest, logErrPrefix)
 		return
 	}
 
+	if err = validateBanner(&banner); err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
 	err = h.srv.UpdateBanner(r.Context(), bannerID, banner)
 	if err != nil {
 		if errors.Is(err, appErrors.ErrBannerTagUniqueViolation) {
@@ -419,11 +953,25 @@ func (h *bannerUpdater) UpdateBanner(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		if errors.Is(err, appErrors.ErrActiveWindowInvalid) {
+			errwriter.WriteHTTPError(w, appErrors.ErrActiveWindowInvalid, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
 		if errors.Is(err, appErrors.ErrBannerNotFound) || errors.Is(err, appErrors.ErrVersionNotFound) {
 			errwriter.WriteHTTPError(w, appErrors.ErrBannerNotFound, http.StatusNotFound, logErrPrefix)
 			return
 		}
 
+		if errors.Is(err, appErrors.ErrTemplateInvalid) {
+			errwriter.WriteHTTPError(w, appErrors.ErrTemplateInvalid, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		if writeSchemaViolations(w, err, logErrPrefix) {
+			return
+		}
+
 		logger.Logger().Errorln(logErrPrefix, err.Error())
 		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
 		return
@@ -432,6 +980,61 @@ func (h *bannerUpdater) UpdateBanner(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 }
 
+type tagPrioritizer struct {
+	srv domain.BannerServiceTagPrioritizer
+}
+
+func NewTagPrioritizer(srv domain.BannerServiceTagPrioritizer) *tagPrioritizer {
+	return &tagPrioritizer{srv: srv}
+}
+
+func (h *tagPrioritizer) SetTagPriority(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.SetTagPriority:"
+
+	tagIDStr := r.PathValue("tag_id")
+
+	tagID, err := strconv.Atoi(tagIDStr)
+	if err != nil {
+		errwriter.WriteHTTPError(w, appErrors.ErrTagIsNotANumber, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if tagID < 1 {
+		errwriter.WriteHTTPError(w, appErrors.ErrTagNotInRange, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = reqval.ValidateJSONRequest(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var tagPriority domain.TagPriority
+	if err = d.Decode(&tagPriority); err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if tagPriority.Priority == nil {
+		errwriter.WriteHTTPError(w, appErrors.ErrTagPriorityNotProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.SetTagPriority(r.Context(), tagID, *tagPriority.Priority)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 type bannerDeleter struct {
 	srv domain.BannerServiceDeleter
 }
@@ -576,7 +1179,7 @@ func (h *authorization) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokenStr, err := jwt.CreateJWT(isAdmin, []byte(h.JWTKey), time.Now().Add(24*time.Hour))
+	tokenStr, err := jwt.CreateJWT(authData.Login, isAdmin, []byte(h.JWTKey), time.Now().Add(24*time.Hour))
 	if err != nil {
 		logger.Logger().Errorln(logErrPrefix, err)
 		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
@@ -645,7 +1248,7 @@ func (h *authorization) LogIn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	tokenStr, err := jwt.CreateJWT(isAdmin, []byte(h.JWTKey), time.Now().Add(24*time.Hour))
+	tokenStr, err := jwt.CreateJWT(authData.Login, isAdmin, []byte(h.JWTKey), time.Now().Add(24*time.Hour))
 	if err != nil {
 		logger.Logger().Errorln(logErrPrefix, err)
 		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
@@ -0,0 +1,329 @@
+package handlers
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/internal/pkg/errwriter"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+	"github.com/PoorMercymain/bannerify/pkg/reqval"
+)
+
+const (
+	maxWebhookURLLength    = 2048
+	minWebhookSecretLength = 16
+	maxWebhookSecretLength = 256
+	generatedSecretBytes   = 32
+)
+
+var webhookEvents = []string{domain.WebhookBannerCreated, domain.WebhookBannerUpdated, domain.WebhookBannerVersionChosen, domain.WebhookBannerDeleted}
+
+type webhookManager struct {
+	srv domain.BannerServiceWebhookManager
+}
+
+func NewWebhookManager(srv domain.BannerServiceWebhookManager) *webhookManager {
+	return &webhookManager{srv: srv}
+}
+
+// CreateWebhook generates the secret if it is not provided, it is returned only in the response.
+func (h *webhookManager) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.CreateWebhook:"
+
+	err := reqval.ValidateJSONRequest(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var webhook domain.Webhook
+	if err = d.Decode(&webhook); err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if webhook.URL == nil || webhook.Events == nil {
+		errwriter.WriteHTTPError(w, appErrors.ErrWebhookFieldNotProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = validateWebhook(&webhook)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if webhook.Secret == nil {
+		secret := make([]byte, generatedSecretBytes)
+		if _, err = rand.Read(secret); err != nil {
+			logger.Logger().Errorln(logErrPrefix, err.Error())
+			errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+			return
+		}
+
+		secretStr := hex.EncodeToString(secret)
+		webhook.Secret = &secretStr
+	}
+
+	webhookID, err := h.srv.CreateWebhook(r.Context(), webhook)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+
+	if err = json.NewEncoder(w).Encode(domain.WebhookCreated{ID: webhookID, Secret: *webhook.Secret}); err != nil {
+		logger.Logger().Errorln(logErrPrefix, err)
+	}
+}
+
+func (h *webhookManager) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ListWebhooks:"
+
+	limit, offset, err := parseLimitAndOffset(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	webhooks, err := h.srv.ListWebhooks(r.Context(), limit, offset)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(webhooks)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err)
+	}
+}
+
+func (h *webhookManager) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.UpdateWebhook:"
+
+	webhookID, err := parseWebhookID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = reqval.ValidateJSONRequest(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	d := json.NewDecoder(r.Body)
+	d.DisallowUnknownFields()
+
+	var webhook domain.Webhook
+	if err = d.Decode(&webhook); err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	if webhook.URL == nil && webhook.Secret == nil && webhook.Events == nil && webhook.IsActive == nil {
+		errwriter.WriteHTTPError(w, appErrors.ErrNoWebhookFieldsProvided, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = validateWebhook(&webhook)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.UpdateWebhook(r.Context(), webhookID, webhook)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWebhookNotFound) {
+			errwriter.WriteHTTPError(w, appErrors.ErrWebhookNotFound, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *webhookManager) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.DeleteWebhook:"
+
+	webhookID, err := parseWebhookID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	err = h.srv.DeleteWebhook(r.Context(), webhookID)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWebhookNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		errwriter.WriteHTTPError(w, err, http.StatusInternalServerError, logErrPrefix)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListDeliveries returns the latest deliveries of the webhook first, optionally only the ones with the status.
+func (h *webhookManager) ListDeliveries(w http.ResponseWriter, r *http.Request) {
+	defer r.Body.Close()
+	const logErrPrefix = "handlers.ListDeliveries:"
+
+	webhookID, err := parseWebhookID(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	limit, offset, err := parseLimitAndOffset(r)
+	if err != nil {
+		errwriter.WriteHTTPError(w, err, http.StatusBadRequest, logErrPrefix)
+		return
+	}
+
+	var status *string
+	if statusStr := r.URL.Query().Get("status"); statusStr != "" {
+		if statusStr != domain.DeliveryPending && statusStr != domain.DeliveryDelivered && statusStr != domain.DeliveryFailed {
+			errwriter.WriteHTTPError(w, appErrors.ErrDeliveryStatusInvalid, http.StatusBadRequest, logErrPrefix)
+			return
+		}
+
+		status = &statusStr
+	}
+
+	deliveries, err := h.srv.ListDeliveries(r.Context(), webhookID, status, limit, offset)
+	if err != nil {
+		if errors.Is(err, appErrors.ErrWebhookNotFound) {
+			errwriter.WriteHTTPError(w, appErrors.ErrWebhookNotFound, http.StatusNotFound, logErrPrefix)
+			return
+		}
+
+		w.WriteHeader(http.StatusInternalServerError)
+		logger.Logger().Errorln(logErrPrefix, err.Error())
+		return
+	}
+
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(deliveries)
+	if err != nil {
+		logger.Logger().Errorln(logErrPrefix, err)
+	}
+}
+
+func parseWebhookID(r *http.Request) (int, error) {
+	webhookIDStr := r.PathValue("id")
+	if webhookIDStr == "" {
+		return 0, appErrors.ErrNoWebhookIDProvided
+	}
+
+	webhookID, err := strconv.Atoi(webhookIDStr)
+	if err != nil {
+		return 0, appErrors.ErrWebhookIDIsNotANumber
+	}
+
+	if webhookID < 1 {
+		return 0, appErrors.ErrWebhookIDNotInRange
+	}
+
+	return webhookID, nil
+}
+
+// parseLimitAndOffset reads the pagination of the lists, limit is 15 if it is not provided.
+func parseLimitAndOffset(r *http.Request) (int, int, error) {
+	limitStr := r.URL.Query().Get("limit")
+	offsetStr := r.URL.Query().Get("offset")
+
+	if limitStr == "" {
+		limitStr = "15"
+	}
+
+	if offsetStr == "" {
+		offsetStr = "0"
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil {
+		return 0, 0, appErrors.ErrLimitIsNotANumber
+	}
+
+	if limit < 1 || limit > 100 {
+		return 0, 0, appErrors.ErrLimitNotInRange
+	}
+
+	offset, err := strconv.Atoi(offsetStr)
+	if err != nil {
+		return 0, 0, appErrors.ErrOffsetIsNotANumber
+	}
+
+	if offset < 0 {
+		return 0, 0, appErrors.ErrOffsetNotInRange
+	}
+
+	return limit, offset, nil
+}
+
+// validateWebhook checks the provided fields only, so it is used both for creation and for updates.
+// The events are deduplicated.
+func validateWebhook(webhook *domain.Webhook) error {
+	if webhook.URL != nil {
+		u, err := url.Parse(*webhook.URL)
+		if err != nil || len(*webhook.URL) > maxWebhookURLLength || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return appErrors.ErrWebhookURLInvalid
+		}
+	}
+
+	if webhook.Secret != nil && (len(*webhook.Secret) < minWebhookSecretLength || len(*webhook.Secret) > maxWebhookSecretLength) {
+		return appErrors.ErrWebhookSecretInvalid
+	}
+
+	if webhook.Events == nil {
+		return nil
+	}
+
+	if len(webhook.Events) == 0 {
+		return appErrors.ErrWebhookEventsInvalid
+	}
+
+	for _, event := range webhook.Events {
+		if !slices.Contains(webhookEvents, event) {
+			return appErrors.ErrWebhookEventsInvalid
+		}
+	}
+
+	slices.Sort(webhook.Events)
+	webhook.Events = slices.Compact(webhook.Events)
+
+	return nil
+}
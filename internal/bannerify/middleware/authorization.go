@@ -4,23 +4,25 @@ import (
 	"net/http"
 
 	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
 	"github.com/PoorMercymain/bannerify/pkg/jwt"
 )
 
+// AdminRequired stores the login of the admin in the request context, the changes are attributed to it in the audit log.
 func AdminRequired(next http.Handler, jwtKey string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		isAdmin, err := checkIsAdmin(r, jwtKey)
+		claims, err := parseToken(r, jwtKey)
 		if err != nil {
 			w.WriteHeader(http.StatusUnauthorized)
 			return
 		}
 
-		if !isAdmin {
+		if !claims.IsAdmin {
 			w.WriteHeader(http.StatusForbidden)
 			return
 		}
 
-		next.ServeHTTP(w, r)
+		next.ServeHTTP(w, r.WithContext(domain.WithActor(r.Context(), claims.Login)))
 	})
 }
 
@@ -49,15 +51,24 @@ func ProvideIsAdmin(next func(bool) http.HandlerFunc, jwtKey string) http.Handle
 }
 
 func checkIsAdmin(r *http.Request, jwtKey string) (bool, error) {
+	claims, err := parseToken(r, jwtKey)
+	if err != nil {
+		return false, err
+	}
+
+	return claims.IsAdmin, nil
+}
+
+func parseToken(r *http.Request, jwtKey string) (*jwt.Claims, error) {
 	authToken := r.Header.Get("token")
 	if authToken == "" {
-		return false, appErrors.ErrNoTokenProvided
+		return nil, appErrors.ErrNoTokenProvided
 	}
 
-	isAdmin, err := jwt.CheckIsAdminInJWT(authToken, jwtKey)
+	claims, err := jwt.ParseJWT(authToken, jwtKey)
 	if err != nil {
-		return false, appErrors.ErrTokenIsInvalid
+		return nil, appErrors.ErrTokenIsInvalid
 	}
 
-	return isAdmin, nil
+	return claims, nil
 }
@@ -58,13 +58,13 @@ func TestAdminRequired(t *testing.T) {
 
 	defer ts.Close()
 
-	tokenStrNoAdmin, err := jwt.CreateJWT(false, []byte(""), time.Now().Add(24*time.Hour))
+	tokenStrNoAdmin, err := jwt.CreateJWT("", false, []byte(""), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
-	tokenStrAdmin, err := jwt.CreateJWT(true, []byte(""), time.Now().Add(24*time.Hour))
+	tokenStrAdmin, err := jwt.CreateJWT("", true, []byte(""), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
-	wrongToken, err := jwt.CreateJWT(true, []byte("abcd"), time.Now().Add(24*time.Hour))
+	wrongToken, err := jwt.CreateJWT("", true, []byte("abcd"), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
 	var testTable = []struct {
@@ -120,13 +120,13 @@ func TestAuthorizationRequired(t *testing.T) {
 
 	defer ts.Close()
 
-	tokenStrNoAdmin, err := jwt.CreateJWT(false, []byte(""), time.Now().Add(24*time.Hour))
+	tokenStrNoAdmin, err := jwt.CreateJWT("", false, []byte(""), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
-	tokenStrAdmin, err := jwt.CreateJWT(true, []byte(""), time.Now().Add(24*time.Hour))
+	tokenStrAdmin, err := jwt.CreateJWT("", true, []byte(""), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
-	wrongToken, err := jwt.CreateJWT(true, []byte("abcd"), time.Now().Add(24*time.Hour))
+	wrongToken, err := jwt.CreateJWT("", true, []byte("abcd"), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
 	var testTable = []struct {
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/PoorMercymain/bannerify/pkg/compress"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+)
+
+// compressResponseWriter buffers the beginning of the response to find out if it is large enough to be compressed.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding   string
+	statusCode int
+	buf        []byte
+	encoder    io.WriteCloser
+	decided    bool
+}
+
+func (crw *compressResponseWriter) WriteHeader(statusCode int) {
+	if crw.decided {
+		return
+	}
+
+	crw.statusCode = statusCode
+	if statusCode < http.StatusOK || statusCode == http.StatusNoContent || statusCode == http.StatusNotModified {
+		crw.decide(false)
+	}
+}
+
+func (crw *compressResponseWriter) Write(b []byte) (int, error) {
+	if !crw.decided {
+		crw.buf = append(crw.buf, b...)
+		if len(crw.buf) < compress.MinSize {
+			return len(b), nil
+		}
+
+		err := crw.decide(crw.Header().Get("Content-Encoding") == "")
+		return len(b), err
+	}
+
+	if crw.encoder != nil {
+		return crw.encoder.Write(b)
+	}
+
+	return crw.ResponseWriter.Write(b)
+}
+
+func (crw *compressResponseWriter) decide(compressed bool) error {
+	crw.decided = true
+	if compressed {
+		crw.Header().Set("Content-Encoding", crw.encoding)
+		crw.Header().Del("Content-Length")
+		crw.encoder = compress.NewWriter(crw.encoding, crw.ResponseWriter)
+	}
+
+	crw.ResponseWriter.WriteHeader(crw.statusCode)
+
+	if len(crw.buf) == 0 {
+		return nil
+	}
+
+	buf := crw.buf
+	crw.buf = nil
+	if crw.encoder != nil {
+		_, err := crw.encoder.Write(buf)
+		return err
+	}
+
+	_, err := crw.ResponseWriter.Write(buf)
+	return err
+}
+
+func (crw *compressResponseWriter) close() error {
+	if !crw.decided {
+		err := crw.decide(false)
+		if err != nil {
+			return err
+		}
+	}
+
+	if crw.encoder != nil {
+		return crw.encoder.Close()
+	}
+
+	return nil
+}
+
+func Compress(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := compress.Negotiate(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		crw := &compressResponseWriter{ResponseWriter: w, encoding: encoding, statusCode: http.StatusOK}
+		next.ServeHTTP(crw, r)
+
+		err := crw.close()
+		if err != nil {
+			logger.Logger().Errorln("middleware.Compress:", err.Error())
+		}
+	})
+}
@@ -0,0 +1,120 @@
+package middleware
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/require"
+)
+
+func testCompressRouter(t *testing.T, largeBody []byte) *http.ServeMux {
+	mux := http.NewServeMux()
+
+	mux.Handle("GET /large", Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write(largeBody[:len(largeBody)/2])
+		require.NoError(t, err)
+		_, err = w.Write(largeBody[len(largeBody)/2:])
+		require.NoError(t, err)
+	})))
+	mux.Handle("GET /small", Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, err := w.Write([]byte("abc"))
+		require.NoError(t, err)
+	})))
+	mux.Handle("GET /empty", Compress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})))
+
+	return mux
+}
+
+func TestCompress(t *testing.T) {
+	largeBody := bytes.Repeat([]byte(`{"title": "some_title"}`), 200)
+
+	ts := httptest.NewServer(testCompressRouter(t, largeBody))
+
+	defer ts.Close()
+
+	var testTable = []struct {
+		endpoint         string
+		acceptEncoding   string
+		code             int
+		expectedEncoding string
+		body             []byte
+	}{
+		{
+			"/large",
+			"gzip",
+			http.StatusOK,
+			"gzip",
+			largeBody,
+		},
+		{
+			"/large",
+			"gzip, br",
+			http.StatusOK,
+			"br",
+			largeBody,
+		},
+		{
+			"/large",
+			"identity",
+			http.StatusOK,
+			"",
+			largeBody,
+		},
+		{
+			"/small",
+			"gzip",
+			http.StatusCreated,
+			"",
+			[]byte("abc"),
+		},
+		{
+			"/empty",
+			"br",
+			http.StatusNoContent,
+			"",
+			[]byte{},
+		},
+	}
+
+	transport := &http.Transport{DisableCompression: true}
+	client := &http.Client{Transport: transport}
+
+	for _, testCase := range testTable {
+		req, err := http.NewRequest(http.MethodGet, ts.URL+testCase.endpoint, nil)
+		require.NoError(t, err)
+		req.Header.Set("Accept-Encoding", testCase.acceptEncoding)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+
+		require.Equal(t, testCase.code, resp.StatusCode)
+		require.Equal(t, testCase.expectedEncoding, resp.Header.Get("Content-Encoding"))
+		require.True(t, strings.Contains(resp.Header.Get("Vary"), "Accept-Encoding"))
+
+		var body io.Reader = resp.Body
+		switch testCase.expectedEncoding {
+		case "gzip":
+			body, err = gzip.NewReader(resp.Body)
+			require.NoError(t, err)
+		case "br":
+			body = brotli.NewReader(resp.Body)
+		}
+
+		decoded, err := io.ReadAll(body)
+		require.NoError(t, err)
+		require.Equal(t, testCase.body, decoded)
+
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/jwt"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+)
+
+type isAdminKey struct{}
+
+// GRPCAuthorization mirrors AdminRequired for the methods other than userMethods, which accept user tokens too,
+// like the endpoints wrapped with ProvideIsAdmin. The role is read by IsAdmin, the token is passed in the token metadata.
+// The login is stored for the audit log like AdminRequired does.
+func GRPCAuthorization(jwtKey string, userMethods ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		claims, err := parseTokenInMetadata(ctx, jwtKey)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+
+		if !claims.IsAdmin && !slices.Contains(userMethods, info.FullMethod) {
+			return nil, status.Error(codes.PermissionDenied, appErrors.ErrAdminRequired.Error())
+		}
+
+		return handler(domain.WithActor(context.WithValue(ctx, isAdminKey{}, claims.IsAdmin), claims.Login), req)
+	}
+}
+
+// IsAdmin tells if the call was authorized with an admin token by GRPCAuthorization.
+func IsAdmin(ctx context.Context) bool {
+	isAdmin, _ := ctx.Value(isAdminKey{}).(bool)
+	return isAdmin
+}
+
+func parseTokenInMetadata(ctx context.Context, jwtKey string) (*jwt.Claims, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	tokens := md.Get("token")
+	if len(tokens) == 0 || tokens[0] == "" {
+		return nil, appErrors.ErrNoTokenProvided
+	}
+
+	claims, err := jwt.ParseJWT(tokens[0], jwtKey)
+	if err != nil {
+		return nil, appErrors.ErrTokenIsInvalid
+	}
+
+	return claims, nil
+}
+
+// GRPCRequestID is RequestID for gRPC, the ID is passed in the x-request-id metadata and returned in the header.
+func GRPCRequestID(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var requestID string
+	if ids := md.Get(requestIDHeader); len(ids) != 0 && validRequestID(ids[0]) {
+		requestID = ids[0]
+	} else {
+		requestID = newRequestID()
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, requestID))
+
+	return handler(domain.WithRequestID(ctx, requestID), req)
+}
+
+func GRPCLog(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	logger.Logger().Info("Request gRPC method: ", info.FullMethod)
+
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	duration := time.Since(start)
+
+	logger.Logger().Info("Response status for ", info.FullMethod, ": ", status.Code(err), ", processing duration: ", duration)
+
+	return resp, err
+}
@@ -0,0 +1,59 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/PoorMercymain/bannerify/pkg/jwt"
+)
+
+func TestGRPCAuthorization(t *testing.T) {
+	tokenStrNoAdmin, err := jwt.CreateJWT("", false, []byte(""), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+
+	tokenStrAdmin, err := jwt.CreateJWT("", true, []byte(""), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+
+	wrongToken, err := jwt.CreateJWT("", true, []byte("abcd"), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+
+	interceptor := GRPCAuthorization("", "/test/User")
+
+	var testTable = []struct {
+		method        string
+		code          codes.Code
+		isAdmin       bool
+		authorization string
+	}{
+		{"/test/Admin", codes.Unauthenticated, false, ""},
+		{"/test/Admin", codes.PermissionDenied, false, tokenStrNoAdmin},
+		{"/test/Admin", codes.Unauthenticated, false, wrongToken},
+		{"/test/Admin", codes.OK, true, tokenStrAdmin},
+		{"/test/User", codes.Unauthenticated, false, ""},
+		{"/test/User", codes.OK, false, tokenStrNoAdmin},
+		{"/test/User", codes.OK, true, tokenStrAdmin},
+	}
+
+	for _, testCase := range testTable {
+		ctx := context.Background()
+		if testCase.authorization != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("token", testCase.authorization))
+		}
+
+		var isAdmin bool
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: testCase.method}, func(ctx context.Context, req any) (any, error) {
+			isAdmin = IsAdmin(ctx)
+			return nil, nil
+		})
+
+		require.Equal(t, testCase.code, status.Code(err))
+		require.Equal(t, testCase.isAdmin, isAdmin)
+	}
+}
@@ -29,6 +29,11 @@ func (irw *informativeResponseWriter) Write(b []byte) (int, error) {
 	return count, err
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, so the streams can be flushed.
+func (irw *informativeResponseWriter) Unwrap() http.ResponseWriter {
+	return irw.ResponseWriter
+}
+
 func Log(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		logger.Logger().Info("Request HTTP method: ", r.Method, ", request route: ", r.URL.String(), ", length of content in request: ", r.ContentLength)
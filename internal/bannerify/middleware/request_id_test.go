@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/jwt"
+)
+
+func TestRequestID(t *testing.T) {
+	var requestID string
+	handler := RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID = domain.RequestIDFromContext(r.Context())
+	}))
+
+	var testTable = []struct {
+		header    string
+		generated bool
+	}{
+		{"", true},
+		{"abc-123", false},
+		{"with space", true},
+		{strings.Repeat("a", maxRequestIDLength+1), true},
+	}
+
+	for _, testCase := range testTable {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if testCase.header != "" {
+			req.Header.Set(requestIDHeader, testCase.header)
+		}
+
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		require.Equal(t, requestID, w.Header().Get(requestIDHeader))
+		if testCase.generated {
+			require.Len(t, requestID, 32)
+		} else {
+			require.Equal(t, testCase.header, requestID)
+		}
+	}
+}
+
+func TestAdminRequiredActor(t *testing.T) {
+	token, err := jwt.CreateJWT("admin", true, []byte(""), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+
+	var actor string
+	handler := AdminRequired(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actor = domain.ActorFromContext(r.Context())
+	}), "")
+
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("token", token)
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "admin", actor)
+}
+
+func TestGRPCRequestID(t *testing.T) {
+	var requestID string
+	handler := func(ctx context.Context, req any) (any, error) {
+		requestID = domain.RequestIDFromContext(ctx)
+		return nil, nil
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "abc-123"))
+	_, err := GRPCRequestID(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	require.Equal(t, "abc-123", requestID)
+
+	_, err = GRPCRequestID(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+	require.NoError(t, err)
+	require.Len(t, requestID, 32)
+}
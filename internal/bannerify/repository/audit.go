@@ -0,0 +1,198 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/jsondiff"
+)
+
+// The snapshots are the objects as JSON for the audit log, the diff of the snapshots before and after a change is saved.
+const (
+	bannerSnapshotsQuery = "SELECT b.banner_id, bv.version_id, json_build_object('version_id', bv.version_id, 'feature_id', bv.feature, 'tag_ids', COALESCE((SELECT json_agg(bvt.tag ORDER BY bvt.tag) FROM banner_version_tags bvt WHERE bvt.version_id = bv.version_id), '[]'), 'content', bv.data, 'is_active', bv.is_active, 'active_from', bv.active_from, 'active_until', bv.active_until, 'targeting', bv.targeting, 'default_locale', bv.default_locale, 'translations', (SELECT json_object_agg(bvl.locale, bvl.data) FROM banner_version_locales bvl WHERE bvl.version_id = bv.version_id), 'is_template', bv.is_template, 'missing_variables', bv.missing_variables, 'frequency_cap', bv.frequency_cap) FROM banners b JOIN banner_versions bv ON b.chosen_version_id = bv.version_id WHERE b.banner_id = ANY($1)"
+
+	tagPrioritySnapshotQuery = "SELECT json_build_object('priority', priority) FROM tag_priorities WHERE tag = $1"
+
+	experimentSnapshotQuery = "SELECT json_build_object('feature_id', e.feature, 'tag_id', e.tag, 'is_active', e.is_active, 'variants', COALESCE((SELECT json_agg(json_build_object('key', ev.variant_key, 'version_id', ev.version_id, 'weight', ev.weight) ORDER BY ev.variant_key) FROM experiment_variants ev WHERE ev.experiment_id = e.experiment_id), '[]')) FROM experiments e WHERE e.experiment_id = $1"
+
+	featureSnapshotQuery = "SELECT json_build_object('content_schema', content_schema, 'fallback_banner_id', fallback_banner_id) FROM features WHERE feature_id = $1"
+
+	// the secret is not saved to the audit log
+	webhookSnapshotQuery = "SELECT json_build_object('url', url, 'events', events, 'is_active', is_active) FROM webhooks WHERE webhook_id = $1"
+)
+
+// auditChange is an action to write to the audit log, before and after are nil if the object did not exist.
+type auditChange struct {
+	action    string
+	bannerID  *int
+	versionID *int
+	targetID  *int
+	before    []byte
+	after     []byte
+}
+
+// bannerSnapshot is the state of a banner, it is described by its chosen version.
+type bannerSnapshot struct {
+	versionID int
+	snapshot  []byte
+}
+
+// writeAudit adds the changes to the audit log in tx, they are attributed to the actor and the request ID stored in ctx.
+func writeAudit(ctx context.Context, tx pgx.Tx, changes ...auditChange) error {
+	actor := domain.ActorFromContext(ctx)
+	requestID := domain.RequestIDFromContext(ctx)
+
+	for _, change := range changes {
+		diff, err := jsondiff.Diff(change.before, change.after)
+		if err != nil {
+			return err
+		}
+
+		diffBytes, err := json.Marshal(diff)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "INSERT INTO audit_log (actor, action, banner_id, version_id, target_id, diff, request_id) VALUES ($1, $2, $3, $4, $5, $6, $7)", actor, change.action, change.bannerID, change.versionID, change.targetID, string(diffBytes), requestID)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// snapshot returns nil if the object does not exist.
+func snapshot(ctx context.Context, tx pgx.Tx, query string, id int) ([]byte, error) {
+	var res []byte
+	err := tx.QueryRow(ctx, query, id).Scan(&res)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+
+	return res, err
+}
+
+// writeSnapshotAudit writes the change with the state after it returned by the snapshot query of the object with the id.
+func writeSnapshotAudit(ctx context.Context, tx pgx.Tx, change auditChange, query string, id int) error {
+	after, err := snapshot(ctx, tx, query, id)
+	if err != nil {
+		return err
+	}
+
+	change.after = after
+	return writeAudit(ctx, tx, change)
+}
+
+// bannerSnapshots returns the snapshots of the banners which exist and have a chosen version.
+func bannerSnapshots(ctx context.Context, tx pgx.Tx, bannerIDs []int) (map[int]bannerSnapshot, error) {
+	rows, err := tx.Query(ctx, bannerSnapshotsQuery, bannerIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshots := make(map[int]bannerSnapshot, len(bannerIDs))
+	for rows.Next() {
+		var bannerID int
+		var curSnapshot bannerSnapshot
+		if err = rows.Scan(&bannerID, &curSnapshot.versionID, &curSnapshot.snapshot); err != nil {
+			return nil, err
+		}
+
+		snapshots[bannerID] = curSnapshot
+	}
+
+	return snapshots, rows.Err()
+}
+
+// bannerSnapshotByID returns an empty snapshot if the banner does not exist or has no chosen version.
+func bannerSnapshotByID(ctx context.Context, tx pgx.Tx, bannerID int) (bannerSnapshot, error) {
+	snapshots, err := bannerSnapshots(ctx, tx, []int{bannerID})
+	return snapshots[bannerID], err
+}
+
+// writeBannerAudit writes the change of the banner from before to its current state.
+func writeBannerAudit(ctx context.Context, tx pgx.Tx, action string, bannerID int, before bannerSnapshot) error {
+	after, err := bannerSnapshotByID(ctx, tx, bannerID)
+	if err != nil {
+		return err
+	}
+
+	return writeAudit(ctx, tx, bannerAudit(action, bannerID, before, after))
+}
+
+// bannerAudit describes the change of the banner by its snapshots, the version is the one chosen after the change
+// or the last chosen one for the deleted banners.
+func bannerAudit(action string, bannerID int, before bannerSnapshot, after bannerSnapshot) auditChange {
+	versionID := after.versionID
+	if after.snapshot == nil {
+		versionID = before.versionID
+	}
+
+	change := auditChange{action: action, bannerID: &bannerID, before: before.snapshot, after: after.snapshot}
+	if versionID != 0 {
+		change.versionID = &versionID
+	}
+
+	return change
+}
+
+var (
+	_ domain.BannerRepositoryAuditor = (*auditor)(nil)
+)
+
+type auditor struct {
+	db *postgres
+}
+
+func NewAuditor(pg *postgres) *auditor {
+	return &auditor{db: pg}
+}
+
+func (r *auditor) SearchAudit(ctx context.Context, filter domain.AuditFilter, limit int, offset int) ([]domain.AuditEntry, error) {
+	const logErrPrefix = "repository.SearchAudit: %w"
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, "SELECT entry_id, actor, action, banner_id, version_id, target_id, diff, request_id, created_at FROM audit_log WHERE ($1::TEXT IS NULL OR actor = $1::TEXT) AND ($2::INT IS NULL OR banner_id = $2::INT) AND ($3::TIMESTAMPTZ IS NULL OR created_at >= $3::TIMESTAMPTZ) AND ($4::TIMESTAMPTZ IS NULL OR created_at < $4::TIMESTAMPTZ) ORDER BY entry_id DESC LIMIT $5 OFFSET $6", filter.Actor, filter.BannerID, filter.From, filter.To, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer rows.Close()
+
+	var (
+		entries   []domain.AuditEntry
+		curElem   domain.AuditEntry
+		diff      []byte
+		createdAt time.Time
+	)
+
+	for rows.Next() {
+		curElem.BannerID, curElem.VersionID, curElem.TargetID = nil, nil, nil
+		if err = rows.Scan(&curElem.EntryID, &curElem.Actor, &curElem.Action, &curElem.BannerID, &curElem.VersionID, &curElem.TargetID, &diff, &curElem.RequestID, &createdAt); err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
+		}
+
+		curElem.Diff = json.RawMessage(diff)
+		curElem.CreatedAt = createdAt.Format(time.RFC3339Nano)
+
+		entries = append(entries, curElem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+func TestBannerAudit(t *testing.T) {
+	before := bannerSnapshot{versionID: 1, snapshot: []byte(`{"version_id": 1, "is_active": false}`)}
+	after := bannerSnapshot{versionID: 2, snapshot: []byte(`{"version_id": 2, "is_active": true}`)}
+
+	change := bannerAudit(domain.AuditBannerUpdate, 5, before, after)
+	require.Equal(t, domain.AuditBannerUpdate, change.action)
+	require.Equal(t, 5, *change.bannerID)
+	require.Equal(t, 2, *change.versionID)
+	require.Nil(t, change.targetID)
+
+	change = bannerAudit(domain.AuditBannerDelete, 5, before, bannerSnapshot{})
+	require.Equal(t, 1, *change.versionID)
+	require.Nil(t, change.after)
+
+	change = bannerAudit(domain.AuditBannerDelete, 5, bannerSnapshot{}, bannerSnapshot{})
+	require.Nil(t, change.versionID)
+}
@@ -5,7 +5,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"strconv"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/jackc/pgerrcode"
@@ -16,7 +20,12 @@ import (
 
 	appErrors "github.com/PoorMercymain/bannerify/errors"
 	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/breaker"
+	"github.com/PoorMercymain/bannerify/pkg/compress"
+	"github.com/PoorMercymain/bannerify/pkg/etag"
 	"github.com/PoorMercymain/bannerify/pkg/logger"
+	"github.com/PoorMercymain/bannerify/pkg/lru"
+	"github.com/PoorMercymain/bannerify/pkg/template"
 )
 
 var (
@@ -46,74 +55,827 @@ var (
 
 type bannerGetter struct {
 	db *postgres
-	cache *cache
+	cache domain.BannerCache
+	l1 *lru.Cache[string, domain.UserBanner]
 	sf *singleflight.Group
+	breaker *breaker.Breaker
+	staleTTL time.Duration
+	missingTTL time.Duration
+	warmingUp atomic.Bool
+	rules sync.Map
+	counter domain.ShowCounter
 }
 
-func NewGetter(pg *postgres, cache *cache) *bannerGetter {
-	return &bannerGetter{db: pg, cache: cache, sf: &singleflight.Group{}}
+func NewGetter(pg *postgres, cache domain.BannerCache, l1 *lru.Cache[string, domain.UserBanner], cb *breaker.Breaker, staleTTL time.Duration, missingTTL time.Duration, counter domain.ShowCounter) *bannerGetter {
+	return &bannerGetter{db: pg, cache: cache, l1: l1, sf: &singleflight.Group{}, breaker: cb, staleTTL: staleTTL, missingTTL: missingTTL, counter: counter}
 }
 
-func (r *bannerGetter) GetBanner(ctx context.Context, tagID int, featureID int, isAdmin bool, dbRequired bool) (string, error) {
+// GetBanner loads the banner in its default locale first, and then in the locale negotiated for the user if it is another one.
+// The banner served is counted as shown to the user if it has a frequency cap.
+func (r *bannerGetter) GetBanner(ctx context.Context, tagIDs []int, featureID int, isAdmin bool, dbRequired bool, user domain.UserContext) (domain.UserBanner, error) {
 	const logErrPrefix = "repository.GetBanner: %w"
-	singleflightKey := fmt.Sprintf("%d_%d_%t_%t", tagID, featureID, isAdmin, dbRequired)
-	cacheKey := fmt.Sprintf("%d_%d_%t", tagID, featureID, isAdmin)
 
-	var res string
+	banner, err := r.getUserBanner(ctx, tagIDs, featureID, isAdmin, dbRequired, "")
+	if err != nil {
+		return domain.UserBanner{}, err
+	}
+
+	shows := r.readShows(ctx, isAdmin, user.UserID, []domain.UserBanner{banner})
+
+	banner, ok := r.personalize(banner, user, shows)
+	if !ok {
+		return domain.UserBanner{}, fmt.Errorf(logErrPrefix, appErrors.ErrBannerNotFound)
+	}
+
+	if userLocale := negotiateLocale(banner, user.Locales); userLocale != "" {
+		localized, err := r.getUserBanner(ctx, tagIDs, featureID, isAdmin, dbRequired, userLocale)
+		if err != nil {
+			logger.Logger().Errorln("serving banner in the default locale because of", err.Error())
+		} else if localized, ok := r.personalize(localized, user, shows); ok {
+			banner = localized
+		}
+	}
+
+	r.addShows(ctx, shows, user.UserID, []domain.UserBanner{banner})
+
+	return banner, nil
+}
+
+func (r *bannerGetter) getUserBanner(ctx context.Context, tagIDs []int, featureID int, isAdmin bool, dbRequired bool, userLocale string) (domain.UserBanner, error) {
+	const logErrPrefix = "repository.GetBanner: %w"
+	cacheKey := bannerCacheKey(tagIDs, featureID, isAdmin, userLocale)
+	singleflightKey := fmt.Sprintf("%s_%t", cacheKey, dbRequired)
+
+	var res domain.UserBanner
 	var cacheErr error
 	if !dbRequired {
-		res, cacheErr = r.cache.Get(ctx, cacheKey)
+		res, cacheErr = r.getCached(ctx, cacheKey, logErrPrefix)
+		if errors.Is(cacheErr, appErrors.ErrBannerNotFound) {
+			return domain.UserBanner{}, cacheErr
+		}
 	}
 
 	if cacheErr != nil || dbRequired {
 		data, err, _ := r.sf.Do(singleflightKey, func() (interface{}, error) {
-			data, err := r.getBanner(ctx, tagID, featureID, isAdmin, logErrPrefix)
-			if err != nil {
-				return "", err
+			cacheable := errors.Is(cacheErr, appErrors.ErrNotFoundInCache) || dbRequired
+			if !cacheable {
+				cacheErr = fmt.Errorf(logErrPrefix, cacheErr)
+				logger.Logger().Error(cacheErr.Error())
 			}
 
-			if errors.Is(cacheErr, appErrors.ErrNotFoundInCache) || dbRequired {
-				cacheErr := r.cache.Set(ctx, cacheKey, data)
+			var generations map[int][]string
+			if cacheable {
+				generations, cacheErr = r.readGenerations(ctx, tagIDs, []int{featureID})
 				if cacheErr != nil {
 					cacheErr = fmt.Errorf(logErrPrefix, cacheErr)
 					logger.Logger().Error(cacheErr.Error())
+					cacheable = false
 				}
-			} else {
-				cacheErr = fmt.Errorf(logErrPrefix, cacheErr)
-				logger.Logger().Error(cacheErr.Error())
 			}
 
-			return data, nil
+			resolved, err := r.getBanner(ctx, tagIDs, featureID, isAdmin, userLocale, logErrPrefix)
+			if err != nil {
+				if errors.Is(err, appErrors.ErrBannerNotFound) {
+					if cacheable {
+						r.storeMissing(ctx, tagIDs, isAdmin, userLocale, map[int]resolvedBanner{featureID: resolved}, generations, logErrPrefix)
+					}
+
+					return domain.UserBanner{}, err
+				}
+
+				stale, staleErr := r.getStale(ctx, []string{cacheKey}, logErrPrefix)
+				if staleErr != nil || len(stale) == 0 {
+					return domain.UserBanner{}, err
+				}
+
+				logger.Logger().Warnln("serving stale banner", cacheKey, "because of", err.Error())
+				return stale[cacheKey], nil
+			}
+
+			banner := resolved.userBanner()
+
+			if cacheable {
+				r.storeBanners(ctx, tagIDs, isAdmin, userLocale, map[int]domain.UserBanner{featureID: banner}, generations, logErrPrefix)
+			}
+
+			return banner, nil
 		})
 
 		if err != nil {
-			return "", err
+			return domain.UserBanner{}, err
+		}
+
+		return data.(domain.UserBanner), nil
+	}
+
+	return res, nil
+}
+
+func (r *bannerGetter) getCached(ctx context.Context, cacheKey string, logErrPrefix string) (domain.UserBanner, error) {
+	if banner, ok := r.l1.Get(cacheKey); ok {
+		return banner, nil
+	}
+
+	cached, err := r.cache.Get(ctx, cacheKey)
+	if err != nil {
+		return domain.UserBanner{}, err
+	}
+
+	if cached == missingBanner {
+		return domain.UserBanner{}, fmt.Errorf(logErrPrefix, appErrors.ErrBannerNotFound)
+	}
+
+	banner, err := decodeCached(cached, logErrPrefix)
+	if err != nil {
+		return domain.UserBanner{}, err
+	}
+
+	r.setL1(cacheKey, banner)
+
+	return banner, nil
+}
+
+// setL1 keeps the banner in memory no longer than it is kept in Redis.
+func (r *bannerGetter) setL1(cacheKey string, banner domain.UserBanner) {
+	size := int64(len(cacheKey) + len(banner.Content) + len(banner.ETag))
+	for encoding, encoded := range banner.Encoded {
+		size += int64(len(encoding) + len(encoded))
+	}
+
+	r.l1.Set(cacheKey, banner, size, time.Until(banner.ExpiresAt))
+}
+
+func (r *bannerGetter) CacheStats() domain.CacheStats {
+	stats := r.l1.Stats()
+
+	return domain.CacheStats{
+		Hits:      stats.Hits,
+		Misses:    stats.Misses,
+		Evictions: stats.Evictions,
+		Entries:   stats.Entries,
+		Bytes:     stats.Bytes,
+	}
+}
+
+// decodeCached treats the values it is unable to decode as missing, so that they are overwritten.
+func decodeCached(cached string, logErrPrefix string) (domain.UserBanner, error) {
+	var banner domain.UserBanner
+	err := json.Unmarshal([]byte(cached), &banner)
+	if err != nil {
+		err = fmt.Errorf(logErrPrefix, err)
+		logger.Logger().Error(err.Error())
+		return domain.UserBanner{}, appErrors.ErrNotFoundInCache
+	}
+
+	return banner, nil
+}
+
+// getStale returns the last known good copies of the banners which are found.
+func (r *bannerGetter) getStale(ctx context.Context, cacheKeys []string, logErrPrefix string) (map[string]domain.UserBanner, error) {
+	cached, err := r.cache.GetStale(ctx, cacheKeys)
+	if err != nil {
+		err = fmt.Errorf(logErrPrefix, err)
+		logger.Logger().Error(err.Error())
+		return nil, err
+	}
+
+	stale := make(map[string]domain.UserBanner, len(cached))
+	for cacheKey, data := range cached {
+		banner, err := decodeCached(data, logErrPrefix)
+		if err != nil {
+			continue
 		}
 
-		return data.(string), nil
+		banner.Stale = true
+		stale[cacheKey] = banner
+	}
+
+	return stale, nil
+}
+
+// readGenerations has to be called before the banners are loaded from the database, see cache.SetTracked.
+func (r *bannerGetter) readGenerations(ctx context.Context, tagIDs []int, featureIDs []int) (map[int][]string, error) {
+	pairs := make([]domain.FeatureTag, 0, (len(tagIDs)+1)*len(featureIDs))
+	for _, featureID := range featureIDs {
+		pairs = append(pairs, bannerPairs(featureID, tagIDs)...)
+	}
+
+	generations, err := r.cache.Generations(ctx, pairs)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int][]string, len(featureIDs))
+	for i, featureID := range featureIDs {
+		res[featureID] = generations[i*(len(tagIDs)+1) : (i+1)*(len(tagIDs)+1)]
 	}
 
 	return res, nil
 }
 
-func (r *bannerGetter) getBanner(ctx context.Context, tagID int, featureID int, isAdmin bool, logErrPrefix string) (string, error) {
+// storeBanners keeps the banners in memory only if Redis has accepted them,
+// so that a banner invalidated during the load is not cached anywhere.
+func (r *bannerGetter) storeBanners(ctx context.Context, tagIDs []int, isAdmin bool, userLocale string, banners map[int]domain.UserBanner, generations map[int][]string, logErrPrefix string) {
+	items := make(map[string]domain.CacheItem, len(banners))
+	byKey := make(map[string]domain.UserBanner, len(banners))
+	for featureID, banner := range banners {
+		cacheKey := bannerCacheKey(tagIDs, featureID, isAdmin, userLocale)
+		item, ok := r.newCacheItem(banner, bannerPairs(featureID, tagIDs), generations[featureID], logErrPrefix)
+		if !ok {
+			continue
+		}
+
+		items[cacheKey] = item
+		byKey[cacheKey] = banner
+	}
+
+	if len(items) == 0 {
+		return
+	}
+
+	stored, err := r.cache.SetTracked(ctx, items)
+	if err != nil {
+		err = fmt.Errorf(logErrPrefix, err)
+		logger.Logger().Error(err.Error())
+		return
+	}
+
+	for cacheKey, ok := range stored {
+		if ok {
+			r.setL1(cacheKey, byKey[cacheKey])
+		}
+	}
+}
+
+// storeMissing caches the absence of the banners for a short time, so that the requests for the pairs without banners
+// do not reach the database. The entries are removed by the invalidation when a banner is created or a version is chosen
+// for any of the pairs, and they expire no later than a hidden candidate enters its activation window.
+// They are not kept in memory, because a single Redis lookup is cheap enough for them.
+func (r *bannerGetter) storeMissing(ctx context.Context, tagIDs []int, isAdmin bool, userLocale string, missing map[int]resolvedBanner, generations map[int][]string, logErrPrefix string) {
+	if r.missingTTL <= 0 {
+		return
+	}
+
+	items := make(map[string]domain.CacheItem, len(missing))
+	for featureID, resolved := range missing {
+		ttl := r.missingTTL
+		if resolved.nextBoundary != nil {
+			ttl = min(ttl, time.Until(*resolved.nextBoundary))
+		}
+
+		if ttl <= 0 {
+			continue
+		}
+
+		items[bannerCacheKey(tagIDs, featureID, isAdmin, userLocale)] = domain.CacheItem{Value: missingBanner, TTL: ttl, Pairs: bannerPairs(featureID, tagIDs), Generations: generations[featureID]}
+	}
+
+	if len(items) == 0 {
+		return
+	}
+
+	_, err := r.cache.SetTracked(ctx, items)
+	if err != nil {
+		err = fmt.Errorf(logErrPrefix, err)
+		logger.Logger().Error(err.Error())
+	}
+}
+
+func (r *bannerGetter) newCacheItem(banner domain.UserBanner, pairs []domain.FeatureTag, generations []string, logErrPrefix string) (domain.CacheItem, bool) {
+	ttl := time.Until(banner.ExpiresAt)
+	if ttl <= 0 {
+		return domain.CacheItem{}, false
+	}
+
+	encoded, err := json.Marshal(banner)
+	if err != nil {
+		err = fmt.Errorf(logErrPrefix, err)
+		logger.Logger().Error(err.Error())
+		return domain.CacheItem{}, false
+	}
+
+	return domain.CacheItem{Value: string(encoded), TTL: ttl, StaleTTL: r.staleTTL, Pairs: pairs, Generations: generations}, true
+}
+
+// ListenInvalidations drops the banners invalidated by any instance from the memory until ctx is done.
+func (r *bannerGetter) ListenInvalidations(ctx context.Context) error {
+	err := r.cache.SubscribeInvalidations(ctx, func(keys []string) {
+		for _, key := range keys {
+			r.l1.Delete(key)
+		}
+	})
+
+	if err != nil {
+		return fmt.Errorf("repository.ListenInvalidations: %w", err)
+	}
+
+	return nil
+}
+
+func (r *bannerGetter) getBanner(ctx context.Context, tagIDs []int, featureID int, isAdmin bool, userLocale string, logErrPrefix string) (resolvedBanner, error) {
+	banners, err := r.getBanners(ctx, tagIDs, []int{featureID}, isAdmin, userLocale, logErrPrefix)
+	if err != nil {
+		return resolvedBanner{}, err
+	}
+
+	banner := banners[featureID]
+	if banner.missing {
+		return banner, fmt.Errorf(logErrPrefix, appErrors.ErrBannerNotFound)
+	}
+
+	return banner, nil
+}
+
+// GetBanners loads the banners in their default locales first, and then the ones which have the locale negotiated for the user,
+// grouped by the locale. The banners served are counted as shown to the user if they have frequency caps.
+func (r *bannerGetter) GetBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, dbRequired bool, user domain.UserContext) (map[int]domain.UserBanner, error) {
+	return r.getPersonalBanners(ctx, tagIDs, featureIDs, isAdmin, dbRequired, user, true)
+}
+
+// PeekBanners chooses the banners the same way as GetBanners, the frequency caps apply too, but does not count them as shown,
+// because the banners pushed to the streams may never be shown to the user.
+func (r *bannerGetter) PeekBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, dbRequired bool, user domain.UserContext) (map[int]domain.UserBanner, error) {
+	return r.getPersonalBanners(ctx, tagIDs, featureIDs, isAdmin, dbRequired, user, false)
+}
+
+func (r *bannerGetter) getPersonalBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, dbRequired bool, user domain.UserContext, countShows bool) (map[int]domain.UserBanner, error) {
+	banners, err := r.getUserBanners(ctx, tagIDs, featureIDs, isAdmin, dbRequired, "")
+	if err != nil {
+		return nil, err
+	}
+
+	resolved := make([]domain.UserBanner, 0, len(banners))
+	for _, banner := range banners {
+		resolved = append(resolved, banner)
+	}
+
+	shows := r.readShows(ctx, isAdmin, user.UserID, resolved)
+
+	byLocale := make(map[string][]int)
+	for featureID, banner := range banners {
+		banner, ok := r.personalize(banner, user, shows)
+		if !ok {
+			delete(banners, featureID)
+			continue
+		}
+
+		banners[featureID] = banner
+
+		if userLocale := negotiateLocale(banner, user.Locales); userLocale != "" {
+			byLocale[userLocale] = append(byLocale[userLocale], featureID)
+		}
+	}
+
+	for userLocale, localizedFeatureIDs := range byLocale {
+		localized, err := r.getUserBanners(ctx, tagIDs, localizedFeatureIDs, isAdmin, dbRequired, userLocale)
+		if err != nil {
+			logger.Logger().Errorln("serving banners in the default locale because of", err.Error())
+			continue
+		}
+
+		for featureID, banner := range localized {
+			if banner, ok := r.personalize(banner, user, shows); ok {
+				banners[featureID] = banner
+			}
+		}
+	}
+
+	if !countShows {
+		return banners, nil
+	}
+
+	served := make([]domain.UserBanner, 0, len(banners))
+	for _, banner := range banners {
+		served = append(served, banner)
+	}
+
+	r.addShows(ctx, shows, user.UserID, served)
+
+	return banners, nil
+}
+
+func (r *bannerGetter) getUserBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, dbRequired bool, userLocale string) (map[int]domain.UserBanner, error) {
+	const logErrPrefix = "repository.GetBanners: %w"
+
+	banners := make(map[int]domain.UserBanner, len(featureIDs))
+	missing := featureIDs
+	cacheable := true
+
+	if !dbRequired {
+		notInL1 := make([]int, 0, len(featureIDs))
+		for _, featureID := range featureIDs {
+			if banner, ok := r.l1.Get(bannerCacheKey(tagIDs, featureID, isAdmin, userLocale)); ok {
+				banners[featureID] = banner
+				continue
+			}
+
+			notInL1 = append(notInL1, featureID)
+		}
+
+		missing = notInL1
+		if len(notInL1) == 0 {
+			return banners, nil
+		}
+
+		cacheKeys := make([]string, 0, len(notInL1))
+		for _, featureID := range notInL1 {
+			cacheKeys = append(cacheKeys, bannerCacheKey(tagIDs, featureID, isAdmin, userLocale))
+		}
+
+		cached, err := r.cache.GetMany(ctx, cacheKeys)
+		if err != nil {
+			err = fmt.Errorf(logErrPrefix, err)
+			logger.Logger().Error(err.Error())
+			cacheable = false
+		} else {
+			missing = make([]int, 0, len(notInL1))
+			for i, featureID := range notInL1 {
+				data, ok := cached[cacheKeys[i]]
+				if !ok {
+					missing = append(missing, featureID)
+					continue
+				}
+
+				if data == missingBanner {
+					continue
+				}
+
+				banner, err := decodeCached(data, logErrPrefix)
+				if err != nil {
+					missing = append(missing, featureID)
+					continue
+				}
+
+				r.setL1(cacheKeys[i], banner)
+				banners[featureID] = banner
+			}
+		}
+	}
+
+	if len(missing) == 0 {
+		return banners, nil
+	}
+
+	singleflightKey := fmt.Sprintf("%s_%v_%t_%t_%s", tagsKey(tagIDs), missing, isAdmin, dbRequired, userLocale)
+	data, err, _ := r.sf.Do(singleflightKey, func() (interface{}, error) {
+		var generations map[int][]string
+		if cacheable {
+			var cacheErr error
+			generations, cacheErr = r.readGenerations(ctx, tagIDs, missing)
+			if cacheErr != nil {
+				cacheErr = fmt.Errorf(logErrPrefix, cacheErr)
+				logger.Logger().Error(cacheErr.Error())
+				cacheable = false
+			}
+		}
+
+		resolved, err := r.getBanners(ctx, tagIDs, missing, isAdmin, userLocale, logErrPrefix)
+		if err != nil {
+			return r.getStaleBatch(ctx, tagIDs, missing, isAdmin, userLocale, err, logErrPrefix)
+		}
+
+		found := make(map[int]domain.UserBanner, len(resolved))
+		notFound := make(map[int]resolvedBanner, len(missing))
+		for _, featureID := range missing {
+			resolvedBanner := resolved[featureID]
+			if resolvedBanner.missing {
+				notFound[featureID] = resolvedBanner
+				continue
+			}
+
+			found[featureID] = resolvedBanner.userBanner()
+		}
+
+		if cacheable {
+			r.storeBanners(ctx, tagIDs, isAdmin, userLocale, found, generations, logErrPrefix)
+			r.storeMissing(ctx, tagIDs, isAdmin, userLocale, notFound, generations, logErrPrefix)
+		}
+
+		return found, nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for featureID, banner := range data.(map[int]domain.UserBanner) {
+		banners[featureID] = banner
+	}
+
+	return banners, nil
+}
+
+// getStaleBatch serves the last known good copies only if they are found for all of the features,
+// otherwise the features without them would be reported as missing, while they may exist.
+func (r *bannerGetter) getStaleBatch(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, userLocale string, dbErr error, logErrPrefix string) (map[int]domain.UserBanner, error) {
+	cacheKeys := make([]string, 0, len(featureIDs))
+	for _, featureID := range featureIDs {
+		cacheKeys = append(cacheKeys, bannerCacheKey(tagIDs, featureID, isAdmin, userLocale))
+	}
+
+	stale, err := r.getStale(ctx, cacheKeys, logErrPrefix)
+	if err != nil || len(stale) != len(cacheKeys) {
+		return nil, dbErr
+	}
+
+	logger.Logger().Warnln("serving stale banners", cacheKeys, "because of", dbErr.Error())
+
+	banners := make(map[int]domain.UserBanner, len(featureIDs))
+	for i, featureID := range featureIDs {
+		banners[featureID] = stale[cacheKeys[i]]
+	}
+
+	return banners, nil
+}
+
+// getBanners does not query the database while the circuit breaker is open, so an unavailable database is not overloaded.
+func (r *bannerGetter) getBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, userLocale string, logErrPrefix string) (map[int]resolvedBanner, error) {
+	if !r.breaker.Allow() {
+		return nil, fmt.Errorf(logErrPrefix, appErrors.ErrDBUnavailable)
+	}
+
+	banners, err := r.queryBanners(ctx, tagIDs, featureIDs, isAdmin, userLocale, logErrPrefix)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			r.breaker.Failure()
+		}
+
+		return nil, err
+	}
+
+	r.breaker.Success()
+
+	return banners, nil
+}
+
+// queryBanners resolves a single banner per feature for the user's tags: among the chosen and visible banners of the feature
+// the one whose tag has the highest priority in tag_priorities wins (tags without a priority have 0),
+// ties are broken by the lowest tag id. A banner is visible to a user if it is active and the current time
+// is inside of its activation window, admins see all of the chosen banners.
+// Every requested feature is present in the result, the ones without a visible banner are marked as missing.
+// The banners with a targeting rule or a frequency cap do not win on their own: the next banners are kept as their fallbacks
+// up to the first one without either, and the rules and the caps are checked per request, see personalize.
+// If a pair of the banners has a running experiment, its visible variants are attached to the banner.
+// The features without a visible banner for the tags are served their fallback banners, see attachFallbacks.
+// The content of the versions having a translation to userLocale is translated, the other ones keep their default locale.
+func (r *bannerGetter) queryBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, userLocale string, logErrPrefix string) (map[int]resolvedBanner, error) {
 	conn, err := r.db.Acquire(ctx)
 	if err != nil {
-		return "", fmt.Errorf(logErrPrefix, err)
+		return nil, fmt.Errorf(logErrPrefix, err)
 	}
 	defer conn.Release()
 
-	var data string
-	err = conn.QueryRow(ctx, "SELECT bv.data FROM banner_versions bv JOIN banner_version_tags bvt ON bv.version_id = bvt.version_id WHERE ((bv.is_active = TRUE) OR ($1 = TRUE)) AND bv.feature = $2 AND bvt.tag = $3 AND bv.banner_id IN (SELECT banner_id FROM banners WHERE chosen_version_id = bv.version_id)", isAdmin, featureID, tagID).Scan(&data)
+	rows, err := conn.Query(ctx, "WITH candidates AS (SELECT cv.feature, cv.tag, cv.banner_id, bv.version_id, COALESCE(bvl.data, bv.data) AS data, CASE WHEN bvl.data IS NULL THEN bv.default_locale ELSE bvl.locale END AS locale, ARRAY(SELECT l.locale FROM banner_version_locales l WHERE l.version_id = bv.version_id ORDER BY l.locale) AS locales, bv.targeting, bv.is_template, bv.missing_variables, bv.frequency_cap, bv.active_from, bv.active_until, COALESCE(tp.priority, 0) AS priority, CASE WHEN $1 = TRUE THEN NULL ELSE LEAST(CASE WHEN bv.active_from > now() THEN bv.active_from END, CASE WHEN bv.active_until > now() THEN bv.active_until END) END AS boundary FROM chosen_versions cv JOIN banner_versions bv ON cv.version_id = bv.version_id LEFT JOIN banner_version_locales bvl ON bv.version_id = bvl.version_id AND bvl.locale = $4 LEFT JOIN tag_priorities tp ON cv.tag = tp.tag WHERE ((bv.is_active = TRUE) OR ($1 = TRUE)) AND cv.feature = ANY($2) AND cv.tag = ANY($3)), boundaries AS (SELECT feature, MIN(boundary) AS next_boundary FROM candidates GROUP BY feature), visible AS (SELECT c.feature, c.tag, c.banner_id, c.version_id, c.data, c.locale, c.locales, c.targeting, c.is_template, c.missing_variables, c.frequency_cap, c.priority, COUNT((c.targeting IS NULL AND c.frequency_cap IS NULL) OR NULL) OVER (PARTITION BY c.feature ORDER BY c.priority DESC, c.tag ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS untargeted_before FROM candidates c WHERE ($1 = TRUE) OR ((c.active_from IS NULL OR c.active_from <= now()) AND (c.active_until IS NULL OR c.active_until > now()))) SELECT b.feature, w.tag, w.banner_id, w.version_id, w.data, w.locale, w.locales, w.targeting, w.is_template, w.missing_variables, w.frequency_cap, b.next_boundary FROM boundaries b LEFT JOIN (SELECT v.feature, v.tag, v.banner_id, v.version_id, v.data, v.locale, v.locales, v.targeting, v.is_template, v.missing_variables, v.frequency_cap, v.priority FROM visible v WHERE v.untargeted_before = 0) w ON b.feature = w.feature ORDER BY b.feature, w.priority DESC, w.tag", isAdmin, featureIDs, tagIDs, userLocale)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return "", fmt.Errorf(logErrPrefix, appErrors.ErrBannerNotFound)
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer rows.Close()
+
+	banners := make(map[int]resolvedBanner, len(featureIDs))
+	for _, featureID := range featureIDs {
+		banners[featureID] = resolvedBanner{missing: true}
+	}
+
+	var (
+		featureID        int
+		tagID            *int
+		bannerID         *int
+		versionID        *int
+		content          *string
+		locale           *string
+		locales          []string
+		targeting        *string
+		isTemplate       *bool
+		missingVariables *string
+		frequencyCap     *int
+		boundary         *time.Time
+	)
+
+	var winners []domain.FeatureTag
+	for rows.Next() {
+		if err = rows.Scan(&featureID, &tagID, &bannerID, &versionID, &content, &locale, &locales, &targeting, &isTemplate, &missingVariables, &frequencyCap, &boundary); err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
+		}
+
+		if tagID == nil || bannerID == nil || versionID == nil || content == nil {
+			banners[featureID] = resolvedBanner{missing: true, nextBoundary: boundary}
+			continue
+		}
+
+		candidate := resolvedBanner{
+			tagID:            *tagID,
+			bannerID:         *bannerID,
+			versionID:        *versionID,
+			content:          *content,
+			locale:           optionalString(locale),
+			locales:          locales,
+			targeting:        optionalString(targeting),
+			template:         isTemplate != nil && *isTemplate,
+			missingVariables: optionalString(missingVariables),
+			frequencyCap:     optionalInt(frequencyCap),
+			nextBoundary:     boundary,
+		}
+
+		if banner := banners[featureID]; !banner.missing {
+			banner.fallbacks = append(banner.fallbacks, candidate)
+			banners[featureID] = banner
+		} else {
+			banners[featureID] = candidate
+		}
+
+		winners = append(winners, domain.FeatureTag{FeatureID: featureID, TagID: *tagID})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	rows.Close()
+
+	if len(winners) != 0 {
+		err = attachVariants(ctx, conn, banners, winners, isAdmin, userLocale)
+		if err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
 		}
+	}
 
-		return "", fmt.Errorf(logErrPrefix, err)
+	err = attachFallbacks(ctx, conn, banners, isAdmin, userLocale)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
 	}
 
-	return data, nil
+	return banners, nil
+}
+
+// missingBanner is cached for the pairs without a visible banner, it can't be confused with an encoded banner.
+const missingBanner = "missing"
+
+// resolvedBanner is missing if the feature has no visible banner for the tags,
+// its nextBoundary is then the closest moment when one of the hidden candidates becomes visible.
+// The nextBoundary of the fallbacks is not used, the boundaries are kept by the first banner.
+type resolvedBanner struct {
+	tagID            int
+	bannerID         int
+	versionID        int
+	content          string
+	locale           string
+	locales          []string
+	targeting        string
+	template         bool
+	missingVariables string
+	frequencyCap     int
+	nextBoundary     *time.Time
+	missing          bool
+	featureFallback  bool
+	experimentID     int
+	variants         []resolvedVariant
+	fallbacks        []resolvedBanner
+}
+
+func (b resolvedBanner) userBanner() domain.UserBanner {
+	banner := domain.UserBanner{
+		BannerID:         b.bannerID,
+		VersionID:        b.versionID,
+		TagID:            b.tagID,
+		Content:          json.RawMessage(b.content),
+		ETag:             etag.Make(b.versionID, []byte(b.content)),
+		ExpiresAt:        time.Now().Add(max(b.cacheTTL(), 0)),
+		Targeting:        b.targeting,
+		Locale:           b.locale,
+		Locales:          b.locales,
+		Template:         b.template,
+		MissingVariables: b.missingVariables,
+		FeatureFallback:  b.featureFallback,
+		FrequencyCap:     b.frequencyCap,
+	}
+
+	if !b.template {
+		banner.Encoded = encodeContent([]byte(b.content))
+	}
+
+	for _, fallback := range b.fallbacks {
+		fallbackBanner := fallback.userBanner()
+		fallbackBanner.ExpiresAt = banner.ExpiresAt
+		banner.Fallbacks = append(banner.Fallbacks, fallbackBanner)
+	}
+
+	if len(b.variants) == 0 {
+		return banner
+	}
+
+	banner.ExperimentID = b.experimentID
+	banner.Variants = make([]domain.BannerVariant, 0, len(b.variants))
+	for _, variant := range b.variants {
+		bannerVariant := domain.BannerVariant{
+			Key:              variant.key,
+			Weight:           variant.weight,
+			BannerID:         variant.bannerID,
+			VersionID:        variant.versionID,
+			Content:          json.RawMessage(variant.content),
+			ETag:             etag.Make(variant.versionID, []byte(variant.content)),
+			Locale:           variant.locale,
+			Locales:          variant.locales,
+			Template:         variant.template,
+			MissingVariables: variant.missingVariables,
+			FrequencyCap:     variant.frequencyCap,
+		}
+
+		if !variant.template {
+			bannerVariant.Encoded = encodeContent([]byte(variant.content))
+		}
+
+		banner.Variants = append(banner.Variants, bannerVariant)
+	}
+
+	return banner
+}
+
+// encodeContent compresses the content once when it is loaded from the database,
+// so that the cache hits are served without compressing the banner again.
+// The templates are not compressed, since their content is rendered per request.
+func encodeContent(content []byte) map[string][]byte {
+	if len(content) < compress.MinSize {
+		return nil
+	}
+
+	encoded := make(map[string][]byte, len(compress.Encodings))
+	for _, encoding := range compress.Encodings {
+		data, err := compress.Encode(encoding, content)
+		if err != nil {
+			logger.Logger().Errorln("repository.encodeContent:", err.Error())
+			continue
+		}
+
+		encoded[encoding] = data
+	}
+
+	return encoded
+}
+
+// cacheTTL is capped by the closest activation window boundary of the candidates,
+// so that the cache never serves a banner outside of its window.
+func (b resolvedBanner) cacheTTL() time.Duration {
+	if b.nextBoundary == nil {
+		return bannerCacheTTL
+	}
+
+	return min(bannerCacheTTL, time.Until(*b.nextBoundary))
+}
+
+// bannerCacheKey of the banners in their default locales has no locale, so the keys of the cached banners do not change.
+func bannerCacheKey(tagIDs []int, featureID int, isAdmin bool, userLocale string) string {
+	if userLocale == "" {
+		return fmt.Sprintf("%s_{%d}_%t", tagsKey(tagIDs), featureID, isAdmin)
+	}
+
+	return fmt.Sprintf("%s_{%d}_%t_%s", tagsKey(tagIDs), featureID, isAdmin, userLocale)
+}
+
+// tagsKey does not depend on the order of tags, because the order does not affect which banner is chosen.
+func tagsKey(tagIDs []int) string {
+	sorted := slices.Clone(tagIDs)
+	slices.Sort(sorted)
+
+	tagStrs := make([]string, 0, len(sorted))
+	for _, tagID := range sorted {
+		tagStrs = append(tagStrs, strconv.Itoa(tagID))
+	}
+
+	return strings.Join(tagStrs, ",")
+}
+
+// chosenPairsQuery also selects the pairs of the experiments whose variants point at the versions of the banner
+// and the fallback pairs of the features the banner is the fallback of.
+const chosenPairsQuery = "SELECT feature, tag FROM chosen_versions WHERE banner_id = $1 UNION SELECT e.feature, e.tag FROM experiments e JOIN experiment_variants ev ON e.experiment_id = ev.experiment_id JOIN banner_versions bv ON ev.version_id = bv.version_id WHERE bv.banner_id = $1 UNION SELECT feature_id, 0 FROM features WHERE fallback_banner_id = $1"
+
+func selectPairs(ctx context.Context, tx pgx.Tx, query string, args ...interface{}) ([]domain.FeatureTag, error) {
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pairs []domain.FeatureTag
+	for rows.Next() {
+		var pair domain.FeatureTag
+		if err = rows.Scan(&pair.FeatureID, &pair.TagID); err != nil {
+			return nil, err
+		}
+
+		pairs = append(pairs, pair)
+	}
+
+	return pairs, rows.Err()
+}
+
+// invalidate is called after the commit, so the banners which may have been loaded before it are not served anymore.
+// The request is not failed if the cache is unavailable, because the change is already committed.
+func invalidate(ctx context.Context, c domain.BannerCache, pairs []domain.FeatureTag, logErrPrefix string) {
+	err := c.Invalidate(ctx, pairs)
+	if err != nil {
+		err = fmt.Errorf(logErrPrefix, err)
+		logger.Logger().Error(err.Error())
+	}
 }
 
 func (r *bannerGetter) ListBanners(ctx context.Context, tagID *int, featureID *int, limit int, offset int) ([]domain.BannerListElement, error) {
@@ -125,7 +887,7 @@ func (r *bannerGetter) ListBanners(ctx context.Context, tagID *int, featureID *i
 	}
 	defer conn.Release()
 
-	query := "SELECT bv.banner_id, bv.feature AS feature_id, bv.data, bv.is_active, bv.created_at, bv.updated_at, array_agg(DISTINCT bvt.tag) AS tag_ids FROM banner_versions bv JOIN banners b ON bv.banner_id = b.banner_id AND b.chosen_version_id = bv.version_id LEFT JOIN banner_version_tags bvt ON bv.version_id = bvt.version_id WHERE ($1::INT IS NULL OR bv.feature = $1::INT) GROUP BY bv.banner_id, bv.feature, bv.data, bv.is_active, bv.created_at, bv.updated_at HAVING ($2::INT IS NULL OR bool_or(bvt.tag = $2::INT)) ORDER BY bv.updated_at DESC LIMIT $3 OFFSET $4"
+	query := "SELECT bv.banner_id, bv.feature AS feature_id, bv.data, bv.is_active, bv.active_from, bv.active_until, bv.targeting, bv.default_locale, (SELECT json_object_agg(l.locale, l.data::json) FROM banner_version_locales l WHERE l.version_id = bv.version_id) AS locales, bv.is_template, bv.missing_variables, bv.frequency_cap, bv.created_at, bv.updated_at, array_agg(DISTINCT bvt.tag) AS tag_ids FROM banner_versions bv JOIN banners b ON bv.banner_id = b.banner_id AND b.chosen_version_id = bv.version_id LEFT JOIN banner_version_tags bvt ON bv.version_id = bvt.version_id WHERE ($1::INT IS NULL OR bv.feature = $1::INT) GROUP BY bv.banner_id, bv.version_id, bv.feature, bv.data, bv.is_active, bv.active_from, bv.active_until, bv.targeting, bv.default_locale, bv.is_template, bv.missing_variables, bv.frequency_cap, bv.created_at, bv.updated_at HAVING ($2::INT IS NULL OR bool_or(bvt.tag = $2::INT)) ORDER BY bv.updated_at DESC LIMIT $3 OFFSET $4"
 
 	rows, err := conn.Query(ctx, query, featureID, tagID, limit, offset)
 	if err != nil {
@@ -134,29 +896,78 @@ func (r *bannerGetter) ListBanners(ctx context.Context, tagID *int, featureID *i
 	defer rows.Close()
 
 	var (
-		banners              []domain.BannerListElement
-		curElem              domain.BannerListElement
-		content              string
-		createdAt, updatedAt time.Time
+		banners                 []domain.BannerListElement
+		curElem                 domain.BannerListElement
+		content                 string
+		locales                 []byte
+		createdAt, updatedAt    time.Time
+		activeFrom, activeUntil *time.Time
 	)
 
-	for rows.Next() {
-		if err = rows.Scan(&curElem.BannerID, &curElem.FeatureID, &content, &curElem.IsActive, &createdAt, &updatedAt, &curElem.TagIDs); err != nil {
-			return nil, fmt.Errorf(logErrPrefix, err)
-		}
+	for rows.Next() {
+		if err = rows.Scan(&curElem.BannerID, &curElem.FeatureID, &content, &curElem.IsActive, &activeFrom, &activeUntil, &curElem.Targeting, &curElem.DefaultLocale, &locales, &curElem.IsTemplate, &curElem.MissingVariables, &curElem.FrequencyCap, &createdAt, &updatedAt, &curElem.TagIDs); err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
+		}
+
+		curElem.Locales, err = decodeLocales(locales)
+		if err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
+		}
+
+		curElem.Content = json.RawMessage(content)
+		curElem.ActiveFrom = formatOptionalTime(activeFrom)
+		curElem.ActiveUntil = formatOptionalTime(activeUntil)
+		curElem.CreatedAt = createdAt.Format(time.RFC3339)
+		curElem.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+		banners = append(banners, curElem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return banners, nil
+}
+
+// decodeLocales decodes the translations aggregated by json_object_agg, which is NULL if there are none.
+func decodeLocales(data []byte) (map[string]json.RawMessage, error) {
+	if data == nil {
+		return nil, nil
+	}
+
+	var locales map[string]json.RawMessage
+	err := json.Unmarshal(data, &locales)
+	if err != nil {
+		return nil, err
+	}
+
+	return locales, nil
+}
+
+func optionalString(s *string) string {
+	if s == nil {
+		return ""
+	}
 
-		curElem.Content = json.RawMessage(content)
-		curElem.CreatedAt = createdAt.Format(time.RFC3339)
-		curElem.UpdatedAt = updatedAt.Format(time.RFC3339)
+	return *s
+}
 
-		banners = append(banners, curElem)
+func optionalInt(i *int) int {
+	if i == nil {
+		return 0
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf(logErrPrefix, err)
+	return *i
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
 	}
 
-	return banners, nil
+	formatted := t.Format(time.RFC3339)
+	return &formatted
 }
 
 var (
@@ -164,11 +975,12 @@ var (
 )
 
 type bannerVersioner struct {
-	db *postgres
+	db    *postgres
+	cache domain.BannerCache
 }
 
-func NewVersioner(pg *postgres) *bannerVersioner {
-	return &bannerVersioner{db: pg}
+func NewVersioner(pg *postgres, cache domain.BannerCache) *bannerVersioner {
+	return &bannerVersioner{db: pg, cache: cache}
 }
 
 func (r *bannerVersioner) ListVersions(ctx context.Context, bannerID int, limit int, offset int) ([]domain.VersionListElement, error) {
@@ -180,7 +992,7 @@ func (r *bannerVersioner) ListVersions(ctx context.Context, bannerID int, limit
 	}
 	defer conn.Release()
 
-	query := "SELECT bv.version_id, array_agg(DISTINCT bvt.tag) AS tag_ids, bv.feature, bv.data, bv.is_active, bv.created_at, bv.updated_at, (b.chosen_version_id = bv.version_id) AS is_chosen FROM banner_versions bv LEFT JOIN banner_version_tags bvt ON bv.version_id = bvt.version_id JOIN banners b ON bv.banner_id = b.banner_id WHERE bv.banner_id = $1 GROUP BY bv.version_id, b.chosen_version_id ORDER BY bv.updated_at DESC LIMIT $2 OFFSET $3"
+	query := "SELECT bv.version_id, array_agg(DISTINCT bvt.tag) AS tag_ids, bv.feature, bv.data, bv.is_active, bv.active_from, bv.active_until, bv.targeting, bv.default_locale, (SELECT json_object_agg(l.locale, l.data::json) FROM banner_version_locales l WHERE l.version_id = bv.version_id) AS locales, bv.is_template, bv.missing_variables, bv.frequency_cap, bv.created_at, bv.updated_at, (b.chosen_version_id = bv.version_id) AS is_chosen FROM banner_versions bv LEFT JOIN banner_version_tags bvt ON bv.version_id = bvt.version_id JOIN banners b ON bv.banner_id = b.banner_id WHERE bv.banner_id = $1 GROUP BY bv.version_id, b.chosen_version_id ORDER BY bv.updated_at DESC LIMIT $2 OFFSET $3"
 
 	rows, err := conn.Query(ctx, query, bannerID, limit, offset)
 	if err != nil {
@@ -189,18 +1001,27 @@ func (r *bannerVersioner) ListVersions(ctx context.Context, bannerID int, limit
 	defer rows.Close()
 
 	var (
-		versions             []domain.VersionListElement
-		curElem              domain.VersionListElement
-		content              string
-		createdAt, updatedAt time.Time
+		versions                []domain.VersionListElement
+		curElem                 domain.VersionListElement
+		content                 string
+		locales                 []byte
+		createdAt, updatedAt    time.Time
+		activeFrom, activeUntil *time.Time
 	)
 
 	for rows.Next() {
-		if err = rows.Scan(&curElem.VersionID, &curElem.TagIDs, &curElem.FeatureID, &content, &curElem.IsActive, &createdAt, &updatedAt, &curElem.IsChosen); err != nil {
+		if err = rows.Scan(&curElem.VersionID, &curElem.TagIDs, &curElem.FeatureID, &content, &curElem.IsActive, &activeFrom, &activeUntil, &curElem.Targeting, &curElem.DefaultLocale, &locales, &curElem.IsTemplate, &curElem.MissingVariables, &curElem.FrequencyCap, &createdAt, &updatedAt, &curElem.IsChosen); err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
+		}
+
+		curElem.Locales, err = decodeLocales(locales)
+		if err != nil {
 			return nil, fmt.Errorf(logErrPrefix, err)
 		}
 
 		curElem.Content = json.RawMessage(content)
+		curElem.ActiveFrom = formatOptionalTime(activeFrom)
+		curElem.ActiveUntil = formatOptionalTime(activeUntil)
 		curElem.CreatedAt = createdAt.Format(time.RFC3339)
 		curElem.UpdatedAt = updatedAt.Format(time.RFC3339)
 
@@ -217,11 +1038,17 @@ func (r *bannerVersioner) ListVersions(ctx context.Context, bannerID int, limit
 func (r *bannerVersioner) ChooseVersion(ctx context.Context, bannerID int, versionID int) error {
 	const logErrPrefix = "repository.ChooseVersion: %w"
 
+	var oldPairs, newPairs []domain.FeatureTag
 	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		before, err := bannerSnapshotByID(ctx, tx, bannerID)
+		if err != nil {
+			return err
+		}
+
 		tag, err := tx.Exec(ctx, "UPDATE banners SET chosen_version_id = $1 WHERE banner_id = $2", versionID, bannerID)
 		if err != nil {
 			var pgErr *pgconn.PgError
-			if errors.As(err, &pgErr); pgErr.Code == pgerrcode.ForeignKeyViolation {
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
 				return appErrors.ErrVersionNotFound
 			}
 
@@ -242,6 +1069,16 @@ func (r *bannerVersioner) ChooseVersion(ctx context.Context, bannerID int, versi
 			return err
 		}
 
+		err = checkContentSchema(ctx, tx, versionID)
+		if err != nil {
+			return err
+		}
+
+		oldPairs, err = selectPairs(ctx, tx, chosenPairsQuery, bannerID)
+		if err != nil {
+			return err
+		}
+
 		_, err = tx.Exec(ctx, "DELETE FROM chosen_versions WHERE banner_id = $1", bannerID)
 		if err != nil {
 			return err
@@ -250,20 +1087,42 @@ func (r *bannerVersioner) ChooseVersion(ctx context.Context, bannerID int, versi
 		_, err = tx.Exec(ctx, "INSERT INTO chosen_versions (banner_id, version_id, feature, tag) SELECT $1 AS banner_id, $2 AS version_id, $3 AS feature, bvt.tag FROM banner_version_tags bvt WHERE bvt.version_id = $2", bannerID, versionID, featureID)
 		if err != nil {
 			var pgErr *pgconn.PgError
-			if errors.As(err, &pgErr); pgErr.Code == pgerrcode.UniqueViolation {
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
 				return appErrors.ErrBannerTagUniqueViolation
 			}
 
 			return err
 		}
 
-		return nil
+		newPairs, err = selectPairs(ctx, tx, chosenPairsQuery, bannerID)
+		if err != nil {
+			return err
+		}
+
+		err = enqueueWebhooks(ctx, tx, []domain.WebhookEvent{{Event: domain.WebhookBannerVersionChosen, BannerID: bannerID, VersionID: versionID}})
+		if err != nil {
+			return err
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxBannerVersionChosen, domain.ChangePayload{BannerID: bannerID, VersionID: versionID, Affected: append(oldPairs, newPairs...)})
+		if err != nil {
+			return err
+		}
+
+		err = writeBannerAudit(ctx, tx, domain.AuditBannerChooseVersion, bannerID, before)
+		if err != nil {
+			return err
+		}
+
+		return recordChanges(ctx, tx, append(oldPairs, newPairs...))
 	})
 
 	if err != nil {
 		return fmt.Errorf(logErrPrefix, err)
 	}
 
+	invalidate(ctx, r.cache, append(oldPairs, newPairs...), logErrPrefix)
+
 	return nil
 }
 
@@ -272,25 +1131,41 @@ var (
 )
 
 type bannerCreator struct {
-	db *postgres
+	db    *postgres
+	cache domain.BannerCache
 }
 
-func NewCreator(pg *postgres) *bannerCreator {
-	return &bannerCreator{db: pg}
+func NewCreator(pg *postgres, cache domain.BannerCache) *bannerCreator {
+	return &bannerCreator{db: pg, cache: cache}
 }
 
 func (r *bannerCreator) CreateBanner(ctx context.Context, banner domain.Banner) (int, error) {
 	const logErrPrefix = "repository.CreateBanner: %w"
 
-	var bannerID int
+	var bannerID, versionID int
+	var pairs []domain.FeatureTag
 	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
 		err := tx.QueryRow(ctx, "INSERT INTO banners DEFAULT VALUES RETURNING banner_id").Scan(&bannerID)
 		if err != nil {
 			return err
 		}
 
-		var versionID int
-		err = tx.QueryRow(ctx, "INSERT INTO banner_versions (banner_id, feature, data, is_active) VALUES ($1, $2, $3, $4) RETURNING version_id", bannerID, banner.FeatureID, string(banner.Content), banner.IsActive).Scan(&versionID)
+		err = tx.QueryRow(ctx, "INSERT INTO banner_versions (banner_id, feature, data, is_active, active_from, active_until, targeting, default_locale, is_template, missing_variables, frequency_cap) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, ''), NULLIF($8, ''), COALESCE($9, FALSE), COALESCE($10, 'empty'), NULLIF($11, 0)) RETURNING version_id", bannerID, banner.FeatureID, string(banner.Content), banner.IsActive, banner.ActiveFrom, banner.ActiveUntil, banner.Targeting, banner.DefaultLocale, banner.IsTemplate, banner.MissingVariables, banner.FrequencyCap).Scan(&versionID)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+				return appErrors.ErrActiveWindowInvalid
+			}
+
+			return err
+		}
+
+		err = insertLocales(ctx, tx, versionID, banner.Locales)
+		if err != nil {
+			return err
+		}
+
+		err = checkContentSchema(ctx, tx, versionID)
 		if err != nil {
 			return err
 		}
@@ -317,7 +1192,7 @@ func (r *bannerCreator) CreateBanner(ctx context.Context, banner domain.Banner)
 			_, err = tx.Exec(ctx, "INSERT INTO chosen_versions (banner_id, version_id, feature, tag) VALUES ($1, $2, $3, $4)", bannerID, versionID, banner.FeatureID, tagID)
 			if err != nil {
 				var pgErr *pgconn.PgError
-				if errors.As(err, &pgErr); pgErr.Code == pgerrcode.UniqueViolation {
+				if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
 					logger.Logger().Infoln(pgErr.Message)
 					return appErrors.ErrBannerTagUniqueViolation
 				}
@@ -326,34 +1201,97 @@ func (r *bannerCreator) CreateBanner(ctx context.Context, banner domain.Banner)
 			}
 		}
 
-		return nil
+		pairs, err = selectPairs(ctx, tx, chosenPairsQuery, bannerID)
+		if err != nil {
+			return err
+		}
+
+		err = enqueueWebhooks(ctx, tx, []domain.WebhookEvent{{Event: domain.WebhookBannerCreated, BannerID: bannerID, VersionID: versionID}})
+		if err != nil {
+			return err
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxBannerCreated, domain.ChangePayload{BannerID: bannerID, VersionID: versionID, Affected: pairs})
+		if err != nil {
+			return err
+		}
+
+		err = writeBannerAudit(ctx, tx, domain.AuditBannerCreate, bannerID, bannerSnapshot{})
+		if err != nil {
+			return err
+		}
+
+		return recordChanges(ctx, tx, pairs)
 	})
 
 	if err != nil {
 		return 0, fmt.Errorf(logErrPrefix, err)
 	}
 
+	invalidate(ctx, r.cache, pairs, logErrPrefix)
+
 	return bannerID, nil
 }
 
+func insertLocales(ctx context.Context, tx pgx.Tx, versionID int, locales map[string]json.RawMessage) error {
+	for locale, content := range locales {
+		_, err := tx.Exec(ctx, "INSERT INTO banner_version_locales (version_id, locale, data) VALUES ($1, $2, $3)", versionID, locale, string(content))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// checkTemplate validates the content and the translations of the version if it is a template,
+// since the fields of an update are merged with the ones of the previous version.
+func checkTemplate(ctx context.Context, tx pgx.Tx, versionID int) error {
+	rows, err := tx.Query(ctx, "SELECT bv.data FROM banner_versions bv WHERE bv.version_id = $1 AND bv.is_template = TRUE UNION ALL SELECT l.data FROM banner_version_locales l JOIN banner_versions bv ON l.version_id = bv.version_id WHERE bv.version_id = $1 AND bv.is_template = TRUE", versionID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var content string
+	for rows.Next() {
+		if err = rows.Scan(&content); err != nil {
+			return err
+		}
+
+		if _, err = template.Variables([]byte(content)); err != nil {
+			return fmt.Errorf("%w: %w", appErrors.ErrTemplateInvalid, err)
+		}
+	}
+
+	return rows.Err()
+}
+
 var (
 	_ domain.BannerRepositoryUpdater = (*bannerUpdater)(nil)
 )
 
 type bannerUpdater struct {
-	db *postgres
+	db    *postgres
+	cache domain.BannerCache
 }
 
-func NewUpdater(pg *postgres) *bannerUpdater {
-	return &bannerUpdater{db: pg}
+func NewUpdater(pg *postgres, cache domain.BannerCache) *bannerUpdater {
+	return &bannerUpdater{db: pg, cache: cache}
 }
 
 func (r *bannerUpdater) UpdateBanner(ctx context.Context, bannerID int, banner domain.Banner) error {
 	const logErrPrefix = "repository.UpdateBanner: %w"
 
 	var versionID int
+	var oldPairs, newPairs []domain.FeatureTag
 	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
-		err := tx.QueryRow(ctx, "SELECT chosen_version_id FROM banners WHERE banner_id = $1", bannerID).Scan(&versionID)
+		before, err := bannerSnapshotByID(ctx, tx, bannerID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, "SELECT chosen_version_id FROM banners WHERE banner_id = $1", bannerID).Scan(&versionID)
 		if err != nil {
 			if errors.Is(err, pgx.ErrNoRows) {
 				return appErrors.ErrBannerNotFound
@@ -369,7 +1307,37 @@ func (r *bannerUpdater) UpdateBanner(ctx context.Context, bannerID int, banner d
 			contentStr = &str
 		}
 
-		err = tx.QueryRow(ctx, "INSERT INTO banner_versions (banner_id, feature, data, is_active, created_at, updated_at) SELECT COALESCE($1, bv.banner_id), COALESCE($2, bv.feature), COALESCE($3, bv.data), COALESCE($4, bv.is_active), bv.created_at, CURRENT_TIMESTAMP FROM banner_versions bv WHERE bv.version_id = $5 RETURNING version_id", bannerID, banner.FeatureID, contentStr, banner.IsActive, versionID).Scan(&newVersionID)
+		err = tx.QueryRow(ctx, "INSERT INTO banner_versions (banner_id, feature, data, is_active, active_from, active_until, targeting, default_locale, is_template, missing_variables, frequency_cap, created_at, updated_at) SELECT COALESCE($1, bv.banner_id), COALESCE($2, bv.feature), COALESCE($3, bv.data), COALESCE($4, bv.is_active), CASE WHEN $13 THEN NULL ELSE COALESCE($5, bv.active_from) END, CASE WHEN $14 THEN NULL ELSE COALESCE($6, bv.active_until) END, NULLIF(COALESCE($8, bv.targeting), ''), NULLIF(COALESCE($9, bv.default_locale), ''), COALESCE($10, bv.is_template), COALESCE($11, bv.missing_variables), NULLIF(COALESCE($12, bv.frequency_cap), 0), bv.created_at, CURRENT_TIMESTAMP FROM banner_versions bv WHERE bv.version_id = $7 RETURNING version_id", bannerID, banner.FeatureID, contentStr, banner.IsActive, banner.ActiveFrom, banner.ActiveUntil, versionID, banner.Targeting, banner.DefaultLocale, banner.IsTemplate, banner.MissingVariables, banner.FrequencyCap, banner.ClearActiveFrom, banner.ClearActiveUntil).Scan(&newVersionID)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.CheckViolation {
+				return appErrors.ErrActiveWindowInvalid
+			}
+
+			return err
+		}
+
+		if banner.Locales != nil {
+			err = insertLocales(ctx, tx, newVersionID, banner.Locales)
+		} else {
+			_, err = tx.Exec(ctx, "INSERT INTO banner_version_locales (version_id, locale, data) SELECT $1, locale, data FROM banner_version_locales WHERE version_id = $2", newVersionID, versionID)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		err = checkTemplate(ctx, tx, newVersionID)
+		if err != nil {
+			return err
+		}
+
+		err = checkContentSchema(ctx, tx, newVersionID)
+		if err != nil {
+			return err
+		}
+
+		oldPairs, err = selectPairs(ctx, tx, chosenPairsQuery, bannerID)
 		if err != nil {
 			return err
 		}
@@ -393,7 +1361,7 @@ func (r *bannerUpdater) UpdateBanner(ctx context.Context, bannerID int, banner d
 				_, err = tx.Exec(ctx, "INSERT INTO chosen_versions (banner_id, version_id, feature, tag) SELECT $1, $2, COALESCE($3, bv.feature), $4 FROM banner_versions bv WHERE bv.version_id = $5", bannerID, newVersionID, banner.FeatureID, tagID, versionID)
 				if err != nil {
 					var pgErr *pgconn.PgError
-					if errors.As(err, &pgErr); pgErr.Code == pgerrcode.UniqueViolation {
+					if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
 						return appErrors.ErrBannerTagUniqueViolation
 					}
 
@@ -411,7 +1379,7 @@ func (r *bannerUpdater) UpdateBanner(ctx context.Context, bannerID int, banner d
 			if err != nil {
 				logger.Logger().Infoln("second", err)
 				var pgErr *pgconn.PgError
-				if errors.As(err, &pgErr); pgErr.Code == pgerrcode.UniqueViolation {
+				if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
 					return appErrors.ErrBannerTagUniqueViolation
 				}
 
@@ -422,7 +1390,7 @@ func (r *bannerUpdater) UpdateBanner(ctx context.Context, bannerID int, banner d
 		tag, err := tx.Exec(ctx, "UPDATE banners SET chosen_version_id = $1 WHERE banner_id = $2", newVersionID, bannerID)
 		if err != nil {
 			var pgErr *pgconn.PgError
-			if errors.As(err, &pgErr); pgErr.Code == pgerrcode.ForeignKeyViolation {
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.ForeignKeyViolation {
 				return appErrors.ErrVersionNotFound
 			}
 
@@ -433,13 +1401,94 @@ func (r *bannerUpdater) UpdateBanner(ctx context.Context, bannerID int, banner d
 			return appErrors.ErrBannerNotFound
 		}
 
-		return nil
+		newPairs, err = selectPairs(ctx, tx, chosenPairsQuery, bannerID)
+		if err != nil {
+			return err
+		}
+
+		err = enqueueWebhooks(ctx, tx, []domain.WebhookEvent{{Event: domain.WebhookBannerUpdated, BannerID: bannerID, VersionID: newVersionID}})
+		if err != nil {
+			return err
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxBannerUpdated, domain.ChangePayload{BannerID: bannerID, VersionID: newVersionID, Affected: append(oldPairs, newPairs...)})
+		if err != nil {
+			return err
+		}
+
+		err = writeBannerAudit(ctx, tx, domain.AuditBannerUpdate, bannerID, before)
+		if err != nil {
+			return err
+		}
+
+		return recordChanges(ctx, tx, append(oldPairs, newPairs...))
+	})
+
+	if err != nil {
+		return fmt.Errorf(logErrPrefix, err)
+	}
+
+	invalidate(ctx, r.cache, append(oldPairs, newPairs...), logErrPrefix)
+
+	return nil
+}
+
+var (
+	_ domain.BannerRepositoryTagPrioritizer = (*tagPrioritizer)(nil)
+)
+
+type tagPrioritizer struct {
+	db    *postgres
+	cache domain.BannerCache
+}
+
+func NewTagPrioritizer(pg *postgres, cache domain.BannerCache) *tagPrioritizer {
+	return &tagPrioritizer{db: pg, cache: cache}
+}
+
+func (r *tagPrioritizer) SetTagPriority(ctx context.Context, tagID int, priority int) error {
+	const logErrPrefix = "repository.SetTagPriority: %w"
+
+	var pairs []domain.FeatureTag
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, tagPrioritySnapshotQuery, tagID)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, "INSERT INTO tag_priorities (tag, priority) VALUES ($1, $2) ON CONFLICT (tag) DO UPDATE SET priority = EXCLUDED.priority", tagID, priority)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrNoRowsAffected
+		}
+
+		pairs, err = selectPairs(ctx, tx, "SELECT feature, tag FROM chosen_versions WHERE tag = $1", tagID)
+		if err != nil {
+			return err
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxTagPrioritySet, domain.ChangePayload{TagID: tagID, Priority: &priority, Affected: pairs})
+		if err != nil {
+			return err
+		}
+
+		after, err := snapshot(ctx, tx, tagPrioritySnapshotQuery, tagID)
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, tx, auditChange{action: domain.AuditTagPrioritySet, targetID: &tagID, before: before, after: after})
 	})
 
 	if err != nil {
 		return fmt.Errorf(logErrPrefix, err)
 	}
 
+	invalidate(ctx, r.cache, pairs, logErrPrefix)
+
 	return nil
 }
 
@@ -449,18 +1498,30 @@ var (
 
 type bannerDeleter struct {
 	db *postgres
+	cache domain.BannerCache
 	sem *semaphore.Weighted
 	wg *sync.WaitGroup
 }
 
-func NewDeleter(pg *postgres, wg *sync.WaitGroup, semCap int) *bannerDeleter {
-	return &bannerDeleter{db: pg, wg: wg, sem: semaphore.NewWeighted(int64(semCap))}
+func NewDeleter(pg *postgres, cache domain.BannerCache, wg *sync.WaitGroup, semCap int) *bannerDeleter {
+	return &bannerDeleter{db: pg, cache: cache, wg: wg, sem: semaphore.NewWeighted(int64(semCap))}
 }
 
 func (r *bannerDeleter) DeleteBannerByID(ctx context.Context, bannerID int) error {
 	const logErrPrefix = "repository.DeleteBanner: %w"
 
+	var pairs []domain.FeatureTag
 	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		before, err := bannerSnapshotByID(ctx, tx, bannerID)
+		if err != nil {
+			return err
+		}
+
+		pairs, err = selectPairs(ctx, tx, chosenPairsQuery, bannerID)
+		if err != nil {
+			return err
+		}
+
 		tag, err := tx.Exec(ctx, "DELETE FROM banners WHERE banner_id = $1", bannerID)
 		if err != nil {
 			return err
@@ -470,13 +1531,30 @@ func (r *bannerDeleter) DeleteBannerByID(ctx context.Context, bannerID int) erro
 			return appErrors.ErrBannerNotFound
 		}
 
-		return nil
+		err = enqueueWebhooks(ctx, tx, []domain.WebhookEvent{{Event: domain.WebhookBannerDeleted, BannerID: bannerID}})
+		if err != nil {
+			return err
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxBannerDeleted, domain.ChangePayload{BannerID: bannerID, Affected: pairs})
+		if err != nil {
+			return err
+		}
+
+		err = writeAudit(ctx, tx, bannerAudit(domain.AuditBannerDelete, bannerID, before, bannerSnapshot{}))
+		if err != nil {
+			return err
+		}
+
+		return recordChanges(ctx, tx, pairs)
 	})
 
 	if err != nil {
 		return fmt.Errorf(logErrPrefix, err)
 	}
 
+	invalidate(ctx, r.cache, pairs, logErrPrefix)
+
 	return nil
 }
 
@@ -501,6 +1579,9 @@ func (r *bannerDeleter) DeleteBannerByTagOrFeature(ctx context.Context, deleteCt
 		return fmt.Errorf(logErrPrefix, err)
 	}
 
+	// the banners are deleted after the request is finished, so its actor and ID are passed to the audit log explicitly
+	auditCtx := domain.WithRequestID(domain.WithActor(deleteCtx, domain.ActorFromContext(ctx)), domain.RequestIDFromContext(ctx))
+
 	r.wg.Add(1)
 
 	go func() {
@@ -513,22 +1594,88 @@ func (r *bannerDeleter) DeleteBannerByTagOrFeature(ctx context.Context, deleteCt
 		}
 		defer r.sem.Release(1)
 
+		var pairs []domain.FeatureTag
 		err := r.db.WithTransaction(deleteCtx, func(tx pgx.Tx) error {
-			tag, err := tx.Exec(deleteCtx, "DELETE FROM banners WHERE banner_id IN (SELECT b.banner_id FROM banners b JOIN banner_versions bv ON b.chosen_version_id = bv.version_id LEFT JOIN banner_version_tags bvt ON bv.version_id = bvt.version_id WHERE ($1::INT IS NULL OR bv.feature = $1::INT) AND ($2::INT IS NULL OR bvt.tag = $2::INT))", featureID, tagID)
+			const bannersQuery = "SELECT b.banner_id FROM banners b JOIN banner_versions bv ON b.chosen_version_id = bv.version_id LEFT JOIN banner_version_tags bvt ON bv.version_id = bvt.version_id WHERE ($1::INT IS NULL OR bv.feature = $1::INT) AND ($2::INT IS NULL OR bvt.tag = $2::INT)"
+
+			rows, err := tx.Query(deleteCtx, bannersQuery, featureID, tagID)
 			if err != nil {
 				return fmt.Errorf(logErrPrefix, err)
 			}
 
-			if tag.RowsAffected() == 0 {
+			bannerIDs, err := pgx.CollectRows(rows, pgx.RowTo[int])
+			if err != nil {
+				return fmt.Errorf(logErrPrefix, err)
+			}
+
+			before, err := bannerSnapshots(deleteCtx, tx, bannerIDs)
+			if err != nil {
+				return fmt.Errorf(logErrPrefix, err)
+			}
+
+			pairs, err = selectPairs(deleteCtx, tx, "SELECT feature, tag FROM chosen_versions WHERE banner_id IN ("+bannersQuery+") UNION SELECT e.feature, e.tag FROM experiments e JOIN experiment_variants ev ON e.experiment_id = ev.experiment_id JOIN banner_versions bv ON ev.version_id = bv.version_id WHERE bv.banner_id IN ("+bannersQuery+") UNION SELECT feature_id, 0 FROM features WHERE fallback_banner_id IN ("+bannersQuery+")", featureID, tagID)
+			if err != nil {
+				return fmt.Errorf(logErrPrefix, err)
+			}
+
+			rows, err = tx.Query(deleteCtx, "DELETE FROM banners WHERE banner_id IN ("+bannersQuery+") RETURNING banner_id", featureID, tagID)
+			if err != nil {
+				return fmt.Errorf(logErrPrefix, err)
+			}
+
+			deleted, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.WebhookEvent, error) {
+				event := domain.WebhookEvent{Event: domain.WebhookBannerDeleted}
+				err := row.Scan(&event.BannerID)
+				return event, err
+			})
+			if err != nil {
+				return fmt.Errorf(logErrPrefix, err)
+			}
+
+			if len(deleted) == 0 {
 				return fmt.Errorf(logErrPrefix, appErrors.ErrNoRowsAffected)
 			}
 
+			err = enqueueWebhooks(deleteCtx, tx, deleted)
+			if err != nil {
+				return fmt.Errorf(logErrPrefix, err)
+			}
+
+			// the pairs are not split between the banners, so each event lists all of them
+			payloads := make([]domain.ChangePayload, 0, len(deleted))
+			for _, event := range deleted {
+				payloads = append(payloads, domain.ChangePayload{BannerID: event.BannerID, Affected: pairs})
+			}
+
+			err = writeOutbox(deleteCtx, tx, domain.OutboxBannerDeleted, payloads...)
+			if err != nil {
+				return fmt.Errorf(logErrPrefix, err)
+			}
+
+			changes := make([]auditChange, 0, len(deleted))
+			for _, event := range deleted {
+				changes = append(changes, bannerAudit(domain.AuditBannerDelete, event.BannerID, before[event.BannerID], bannerSnapshot{}))
+			}
+
+			err = writeAudit(auditCtx, tx, changes...)
+			if err != nil {
+				return fmt.Errorf(logErrPrefix, err)
+			}
+
+			err = recordChanges(deleteCtx, tx, pairs)
+			if err != nil {
+				return fmt.Errorf(logErrPrefix, err)
+			}
+
 			return nil
 		})
 
 		if err != nil {
 			logger.Logger().Errorln(err.Error())
+			return
 		}
+
+		invalidate(deleteCtx, r.cache, pairs, logErrPrefix)
 	}()
 
 	return nil
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/breaker"
+	"github.com/PoorMercymain/bannerify/pkg/lru"
+)
+
+// The getter is built without a database, so every test fails if the banner is not served from the cache.
+func newTestGetter(c domain.BannerCache, cb *breaker.Breaker) *bannerGetter {
+	l1 := lru.New[string, domain.UserBanner](100, 0, time.Minute)
+
+	return NewGetter(nil, c, l1, cb, time.Hour, time.Minute, NewMemoryShowCounter())
+}
+
+func TestGetBannerFromCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	c := NewMemoryCache(100, 0)
+	r := newTestGetter(c, breaker.New(1, time.Minute))
+	go r.ListenInvalidations(ctx)
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2})
+	require.NoError(t, err)
+
+	banner := resolvedBanner{versionID: 3, content: "{\"title\": \"cached\"}"}.userBanner()
+	r.storeBanners(ctx, []int{1}, false, "", map[int]domain.UserBanner{2: banner}, generations, "%w")
+
+	res, err := r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{})
+	require.NoError(t, err)
+	require.Equal(t, banner.ETag, res.ETag)
+	require.JSONEq(t, string(banner.Content), string(res.Content))
+
+	banners, err := r.GetBanners(ctx, []int{1}, []int{2}, false, false, domain.UserContext{})
+	require.NoError(t, err)
+	require.Equal(t, banner.ETag, banners[2].ETag)
+
+	require.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.subscribers) == 1
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, c.Invalidate(ctx, []domain.FeatureTag{{FeatureID: 2, TagID: 1}}))
+	require.False(t, r.l1.Contains(bannerCacheKey([]int{1}, 2, false, "")))
+
+	_, err = c.Get(ctx, bannerCacheKey([]int{1}, 2, false, ""))
+	require.ErrorIs(t, err, appErrors.ErrNotFoundInCache)
+}
+
+func TestGetMissingBannerFromCache(t *testing.T) {
+	ctx := context.Background()
+	r := newTestGetter(NewMemoryCache(100, 0), breaker.New(1, time.Minute))
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2})
+	require.NoError(t, err)
+
+	r.storeMissing(ctx, []int{1}, false, "", map[int]resolvedBanner{2: {missing: true}}, generations, "%w")
+
+	_, err = r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{})
+	require.ErrorIs(t, err, appErrors.ErrBannerNotFound)
+
+	banners, err := r.GetBanners(ctx, []int{1}, []int{2}, false, false, domain.UserContext{})
+	require.NoError(t, err)
+	require.Empty(t, banners)
+}
+
+func TestGetStaleBanner(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(100, 0)
+	cb := breaker.New(1, time.Minute)
+	r := newTestGetter(c, cb)
+
+	banner := resolvedBanner{versionID: 3, content: "{}"}.userBanner()
+	item, ok := r.newCacheItem(banner, featureTags(2, []int{1}), []string{""}, "%w")
+	require.True(t, ok)
+
+	_, err := c.SetTracked(ctx, map[string]domain.CacheItem{bannerCacheKey([]int{1}, 2, false, ""): item})
+	require.NoError(t, err)
+
+	cb.Failure()
+
+	res, err := r.GetBanner(ctx, []int{1}, 2, false, true, domain.UserContext{})
+	require.NoError(t, err)
+	require.True(t, res.Stale)
+	require.Equal(t, banner.ETag, res.ETag)
+
+	_, err = r.GetBanner(ctx, []int{1}, 4, false, true, domain.UserContext{})
+	require.ErrorIs(t, err, appErrors.ErrDBUnavailable)
+}
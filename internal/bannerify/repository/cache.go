@@ -2,40 +2,125 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
-	"strconv"
 	"time"
 
 	"github.com/redis/go-redis/v9"
 
 	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
 )
 
-type cache struct {
-	*redis.Client
+const (
+	bannerCacheTTL = time.Minute * 5
+
+	// generationTTL outlives any request which has read the generations before going to the database.
+	generationTTL = bannerCacheTTL * 2
+
+	invalidationChannel = "bannerify_invalidations"
+)
+
+// setTrackedScript stores the value and its stale copy only if no pair it depends on was invalidated
+// after the generations were read, and registers the key in the dependency sets of the pairs.
+// KEYS: the key, the stale key, generation keys, dependency keys.
+// ARGV: the value, ttl and stale ttl in milliseconds (zero stale ttl means no stale copy), expected generations.
+var setTrackedScript = redis.NewScript(`
+local n = (#KEYS - 2) / 2
+for i = 1, n do
+	local generation = redis.call('GET', KEYS[2 + i]) or ''
+	if generation ~= ARGV[3 + i] then
+		return 0
+	end
+end
+
+redis.call('SET', KEYS[1], ARGV[1], 'PX', ARGV[2])
+local ttl = tonumber(ARGV[2])
+if tonumber(ARGV[3]) > 0 then
+	redis.call('SET', KEYS[2], ARGV[1], 'PX', ARGV[3])
+	ttl = math.max(ttl, tonumber(ARGV[3]))
+end
+
+for i = 3 + n, #KEYS do
+	redis.call('SADD', KEYS[i], KEYS[1])
+	if redis.call('PTTL', KEYS[i]) < ttl then
+		redis.call('PEXPIRE', KEYS[i], ttl)
+	end
+end
+
+return 1
+`)
+
+func featureTags(featureID int, tagIDs []int) []domain.FeatureTag {
+	pairs := make([]domain.FeatureTag, 0, len(tagIDs))
+	for _, tagID := range tagIDs {
+		pairs = append(pairs, domain.FeatureTag{FeatureID: featureID, TagID: tagID})
+	}
+
+	return pairs
+}
+
+// fallbackPair has no tag, because the banners never have the tag 0.
+// Every banner resolved for the feature depends on it, since the fallback banner of the feature is served instead of a missing one.
+func fallbackPair(featureID int) domain.FeatureTag {
+	return domain.FeatureTag{FeatureID: featureID}
+}
+
+// bannerPairs are the pairs a banner resolved for the tags of the feature depends on.
+func bannerPairs(featureID int, tagIDs []int) []domain.FeatureTag {
+	return append(featureTags(featureID, tagIDs), fallbackPair(featureID))
+}
+
+// The feature is used as a hash tag, so all the keys of a feature are stored in the same Redis Cluster slot.
+func dependencyKey(pair domain.FeatureTag) string {
+	return fmt.Sprintf("deps_{%d}_%d", pair.FeatureID, pair.TagID)
 }
 
-func NewCache(cachePort int) (*cache, error) {
-	rdb := redis.NewClient(&redis.Options{
-		Addr:     "redis:" + strconv.Itoa(cachePort),
-		Password: "",
-		DB:       0,
-	})
+func generationKey(pair domain.FeatureTag) string {
+	return fmt.Sprintf("gen_{%d}_%d", pair.FeatureID, pair.TagID)
+}
+
+// staleKey is the key of the last known good copy, which is served only when the database is unavailable.
+func staleKey(key string) string {
+	return "stale_" + key
+}
+
+var (
+	_ domain.BannerCache = (*redisCache)(nil)
+)
+
+type redisCache struct {
+	redis.UniversalClient
+}
+
+// NewRedisCache connects to a single Redis node, a Sentinel-managed master or a Redis Cluster.
+// The cluster mode must be requested explicitly, several addresses alone do not enable it.
+func NewRedisCache(opts *redis.UniversalOptions, cluster bool) (*redisCache, error) {
+	var rdb redis.UniversalClient
+	switch {
+	case opts.MasterName != "":
+		rdb = redis.NewFailoverClient(opts.Failover())
+	case cluster:
+		rdb = redis.NewClusterClient(opts.Cluster())
+	default:
+		rdb = redis.NewClient(opts.Simple())
+	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*3)
 	defer cancel()
 
 	_, err := rdb.Ping(ctx).Result()
 	if err != nil {
-		return nil, fmt.Errorf("repository.NewCache: %w", err)
+		return nil, fmt.Errorf("repository.NewRedisCache: %w", err)
 	}
 
-	return &cache{rdb}, nil
+	return &redisCache{rdb}, nil
 }
 
-func (c *cache) Get(ctx context.Context, key string) (string, error) {
-	res, err := c.Client.Get(ctx, key).Result()
+func (c *redisCache) Get(ctx context.Context, key string) (string, error) {
+	res, err := c.UniversalClient.Get(ctx, key).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
 			return "", appErrors.ErrNotFoundInCache
@@ -47,11 +132,197 @@ func (c *cache) Get(ctx context.Context, key string) (string, error) {
 	return res, nil
 }
 
-func (c *cache) Set(ctx context.Context, key string, value string) error {
-	err := c.Client.Set(ctx, key, value, time.Minute*5).Err()
+// GetMany uses a pipeline instead of MGET because the keys of different features belong to different cluster slots.
+func (c *redisCache) GetMany(ctx context.Context, keys []string) (map[string]string, error) {
+	pipe := c.UniversalClient.Pipeline()
+	cmds := make([]*redis.StringCmd, 0, len(keys))
+	for _, key := range keys {
+		cmds = append(cmds, pipe.Get(ctx, key))
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	res := make(map[string]string, len(keys))
+	for i, cmd := range cmds {
+		value, err := cmd.Result()
+		if err == nil {
+			res[keys[i]] = value
+		}
+	}
+
+	return res, nil
+}
+
+// GetStale returns the last known good copies of the found keys.
+func (c *redisCache) GetStale(ctx context.Context, keys []string) (map[string]string, error) {
+	staleKeys := make([]string, 0, len(keys))
+	for _, key := range keys {
+		staleKeys = append(staleKeys, staleKey(key))
+	}
+
+	cached, err := c.GetMany(ctx, staleKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[string]string, len(cached))
+	for i, key := range keys {
+		if value, ok := cached[staleKeys[i]]; ok {
+			res[key] = value
+		}
+	}
+
+	return res, nil
+}
+
+// Generations should be read before the data is loaded from the database, see SetTracked.
+func (c *redisCache) Generations(ctx context.Context, pairs []domain.FeatureTag) ([]string, error) {
+	pipe := c.UniversalClient.Pipeline()
+	cmds := make([]*redis.StringCmd, 0, len(pairs))
+	for _, pair := range pairs {
+		cmds = append(cmds, pipe.Get(ctx, generationKey(pair)))
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	generations := make([]string, 0, len(pairs))
+	for _, cmd := range cmds {
+		generation, err := cmd.Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+
+		generations = append(generations, generation)
+	}
+
+	return generations, nil
+}
+
+// SetTracked returns the keys which were stored. The item is skipped if any of its pairs was invalidated
+// since its generations were read, because then the value may have been loaded before the change was committed.
+func (c *redisCache) SetTracked(ctx context.Context, items map[string]domain.CacheItem) (map[string]bool, error) {
+	pipe := c.UniversalClient.Pipeline()
+	cmds := make(map[string]*redis.Cmd, len(items))
+	for key, item := range items {
+		keys := make([]string, 0, 2+len(item.Pairs)*2)
+		keys = append(keys, key, staleKey(key))
+		for _, pair := range item.Pairs {
+			keys = append(keys, generationKey(pair))
+		}
+
+		for _, pair := range item.Pairs {
+			keys = append(keys, dependencyKey(pair))
+		}
+
+		args := make([]interface{}, 0, 3+len(item.Generations))
+		args = append(args, item.Value, item.TTL.Milliseconds(), item.StaleTTL.Milliseconds())
+		for _, generation := range item.Generations {
+			args = append(args, generation)
+		}
+
+		cmds[key] = setTrackedScript.Eval(ctx, pipe, keys, args...)
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stored := make(map[string]bool, len(items))
+	for key, cmd := range cmds {
+		res, err := cmd.Int()
+		if err != nil {
+			return nil, err
+		}
+
+		stored[key] = res == 1
+	}
+
+	return stored, nil
+}
+
+// Invalidate removes every cached banner (and its stale copy) which depends on the pairs, and notifies the other instances,
+// so they drop the banners from the memory as well.
+func (c *redisCache) Invalidate(ctx context.Context, pairs []domain.FeatureTag) error {
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	pipe := c.UniversalClient.Pipeline()
+	cmds := make([]*redis.StringSliceCmd, 0, len(pairs))
+	for _, pair := range pairs {
+		pipe.Incr(ctx, generationKey(pair))
+		pipe.PExpire(ctx, generationKey(pair), generationTTL)
+		cmds = append(cmds, pipe.SMembers(ctx, dependencyKey(pair)))
+	}
+
+	_, err := pipe.Exec(ctx)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	keys := make([]string, 0)
+	pipe = c.UniversalClient.Pipeline()
+	for i, cmd := range cmds {
+		keys = append(keys, cmd.Val()...)
+		for _, key := range cmd.Val() {
+			pipe.Del(ctx, key, staleKey(key))
+		}
+
+		pipe.Del(ctx, dependencyKey(pairs[i]))
+	}
+
+	_, err = pipe.Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	message, err := json.Marshal(keys)
+	if err != nil {
+		return err
+	}
+
+	return c.UniversalClient.Publish(ctx, invalidationChannel, message).Err()
+}
+
+// SubscribeInvalidations calls handle with the keys invalidated by any instance until ctx is done.
+func (c *redisCache) SubscribeInvalidations(ctx context.Context, handle func(keys []string)) error {
+	pubsub := c.UniversalClient.Subscribe(ctx, invalidationChannel)
+	defer pubsub.Close()
+
+	_, err := pubsub.Receive(ctx)
+	if err != nil {
+		return err
+	}
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case msg, ok := <-ch:
+			if !ok {
+				return nil
+			}
+
+			var keys []string
+			err = json.Unmarshal([]byte(msg.Payload), &keys)
+			if err != nil {
+				logger.Logger().Errorln("repository.SubscribeInvalidations:", err.Error())
+				continue
+			}
+
+			handle(keys)
+		}
+	}
 }
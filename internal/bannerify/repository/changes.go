@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// changesChannel is notified with the range of the change ids committed, so every instance pushes the changes to its subscribers.
+	changesChannel = "banner_changes"
+
+	// subscriberBuffer is the amount of the changes a subscriber may lag behind,
+	// the subscription of a slower one is closed, so it resumes from the last event it received.
+	subscriberBuffer = 64
+
+	// listenRetryInterval is the pause before listening again after the connection is lost.
+	listenRetryInterval = time.Second
+
+	// pruneInterval is how often the changes older than the retention are deleted.
+	pruneInterval = time.Hour
+
+	// sentHistory is the amount of the last published change ids remembered, so the changes read again after
+	// the connection is restored are not published twice.
+	sentHistory = 1024
+
+	// missedChangesCondition selects the changes the reader of the change $1 may have missed. The ids are allocated before commit,
+	// so a change with a lower id may be committed after $1. It is made by a transaction which was running when $1 was made,
+	// so its tx_id is not below the tx_xmin of $1. The changes with such tx_id which were committed earlier are selected again.
+	missedChangesCondition = "(change_id > $1 OR (change_id < $1 AND tx_id >= (SELECT tx_xmin FROM banner_changes WHERE change_id = $1)))"
+)
+
+var (
+	_ domain.BannerRepositoryChangeStreamer = (*changeStreamer)(nil)
+)
+
+type changeSubscriber struct {
+	tagID      int
+	featureIDs map[int]struct{}
+	changes    chan domain.BannerChange
+}
+
+// changeStreamer fans the changes committed by any instance out to the subscribers of this one.
+type changeStreamer struct {
+	db          *postgres
+	retention   time.Duration
+	mu          sync.Mutex
+	subscribers map[int]*changeSubscriber
+	nextID      int
+	lastID      int64
+	sent        map[int64]struct{}
+	sentOrder   []int64
+	closed      bool
+}
+
+func NewChangeStreamer(pg *postgres, retention time.Duration) *changeStreamer {
+	return &changeStreamer{db: pg, retention: retention, subscribers: make(map[int]*changeSubscriber), sent: make(map[int64]struct{})}
+}
+
+// recordChanges keeps the pairs changed in tx for the subscribers to resume from, the instances are notified on commit.
+func recordChanges(ctx context.Context, tx pgx.Tx, pairs []domain.FeatureTag) error {
+	pairs = slices.Clone(pairs)
+	slices.SortFunc(pairs, func(a, b domain.FeatureTag) int {
+		return cmp.Or(cmp.Compare(a.FeatureID, b.FeatureID), cmp.Compare(a.TagID, b.TagID))
+	})
+	pairs = slices.Compact(pairs)
+
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	featureIDs := make([]int, 0, len(pairs))
+	tagIDs := make([]int, 0, len(pairs))
+	for _, pair := range pairs {
+		featureIDs = append(featureIDs, pair.FeatureID)
+		tagIDs = append(tagIDs, pair.TagID)
+	}
+
+	var first, last int64
+	err := tx.QueryRow(ctx, "WITH inserted AS (INSERT INTO banner_changes (feature, tag) SELECT * FROM unnest($1::INT[], $2::INT[]) RETURNING change_id) SELECT MIN(change_id), MAX(change_id) FROM inserted", featureIDs, tagIDs).Scan(&first, &last)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "SELECT pg_notify($1, $2)", changesChannel, fmt.Sprintf("%d-%d", first, last))
+	return err
+}
+
+// Subscribe receives the changes of the features for the tag until the returned function is called.
+// The channel is closed if the subscriber lags behind or the changes are not listened to anymore.
+func (r *changeStreamer) Subscribe(tagID int, featureIDs []int) (<-chan domain.BannerChange, func()) {
+	subscriber := &changeSubscriber{
+		tagID:      tagID,
+		featureIDs: make(map[int]struct{}, len(featureIDs)),
+		changes:    make(chan domain.BannerChange, subscriberBuffer),
+	}
+
+	for _, featureID := range featureIDs {
+		subscriber.featureIDs[featureID] = struct{}{}
+	}
+
+	r.mu.Lock()
+	id := r.nextID
+	r.nextID++
+	r.subscribers[id] = subscriber
+	if r.closed {
+		r.unsubscribe(id)
+	}
+	r.mu.Unlock()
+
+	return subscriber.changes, func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.unsubscribe(id)
+	}
+}
+
+// unsubscribe has to be called with mu held, the subscriber may already be unsubscribed.
+func (r *changeStreamer) unsubscribe(id int) {
+	if subscriber, ok := r.subscribers[id]; ok {
+		close(subscriber.changes)
+		delete(r.subscribers, id)
+	}
+}
+
+// publish skips the changes which are already published.
+func (r *changeStreamer) publish(changes []domain.BannerChange) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, change := range changes {
+		if _, ok := r.sent[change.ID]; ok {
+			continue
+		}
+
+		r.remember(change.ID)
+		r.lastID = max(r.lastID, change.ID)
+
+		for id, subscriber := range r.subscribers {
+			if _, ok := subscriber.featureIDs[change.FeatureID]; !ok {
+				continue
+			}
+
+			if change.TagID != 0 && change.TagID != subscriber.tagID {
+				continue
+			}
+
+			select {
+			case subscriber.changes <- change:
+			default:
+				r.unsubscribe(id)
+			}
+		}
+	}
+}
+
+// remember has to be called with mu held, only the last sentHistory ids are kept.
+func (r *changeStreamer) remember(id int64) {
+	if len(r.sentOrder) == sentHistory {
+		delete(r.sent, r.sentOrder[0])
+		r.sentOrder = r.sentOrder[1:]
+	}
+
+	r.sent[id] = struct{}{}
+	r.sentOrder = append(r.sentOrder, id)
+}
+
+// ListenChanges publishes the changes committed by any instance until ctx is done, then the subscriptions are closed.
+// The changes committed while the connection is lost are published after it is restored,
+// including the ones with the ids below the last published one.
+func (r *changeStreamer) ListenChanges(ctx context.Context) {
+	defer func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+
+		r.closed = true
+		for id := range r.subscribers {
+			r.unsubscribe(id)
+		}
+	}()
+
+	for {
+		err := r.listen(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.Logger().Errorln("repository.ListenChanges:", err.Error())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(listenRetryInterval):
+		}
+	}
+}
+
+func (r *changeStreamer) listen(ctx context.Context) error {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	// the connection keeps listening, so it is not returned to the pool
+	listener := conn.Hijack()
+	defer listener.Close(context.Background())
+
+	_, err = listener.Exec(ctx, "LISTEN "+changesChannel)
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	lastID := r.lastID
+	r.mu.Unlock()
+
+	if lastID == 0 {
+		err = listener.QueryRow(ctx, "SELECT COALESCE(MAX(change_id), 0) FROM banner_changes").Scan(&lastID)
+		if err != nil {
+			return err
+		}
+
+		r.mu.Lock()
+		r.lastID = max(r.lastID, lastID)
+		r.mu.Unlock()
+	} else {
+		changes, err := queryChanges(ctx, listener, "SELECT change_id, feature, tag FROM banner_changes WHERE "+missedChangesCondition+" ORDER BY change_id", lastID)
+		if err != nil {
+			return err
+		}
+
+		r.publish(changes)
+	}
+
+	for {
+		notification, err := listener.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var first, last int64
+		if _, err = fmt.Sscanf(notification.Payload, "%d-%d", &first, &last); err != nil {
+			logger.Logger().Errorln("repository.ListenChanges: unexpected notification", notification.Payload)
+			continue
+		}
+
+		changes, err := queryChanges(ctx, listener, "SELECT change_id, feature, tag FROM banner_changes WHERE change_id BETWEEN $1 AND $2 ORDER BY change_id", first, last)
+		if err != nil {
+			return err
+		}
+
+		r.publish(changes)
+	}
+}
+
+type changesQuerier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+func queryChanges(ctx context.Context, q changesQuerier, query string, args ...any) ([]domain.BannerChange, error) {
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	changes := make([]domain.BannerChange, 0)
+	for rows.Next() {
+		var change domain.BannerChange
+		if err = rows.Scan(&change.ID, &change.FeatureID, &change.TagID); err != nil {
+			return nil, err
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
+
+func (r *changeStreamer) LastChangeID(ctx context.Context) (int64, error) {
+	const logErrPrefix = "repository.LastChangeID: %w"
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf(logErrPrefix, err)
+	}
+	defer conn.Release()
+
+	var lastID int64
+	err = conn.QueryRow(ctx, "SELECT COALESCE(MAX(change_id), 0) FROM banner_changes").Scan(&lastID)
+	if err != nil {
+		return 0, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return lastID, nil
+}
+
+// ChangesSince returns the changes of the features for the tag committed after the change with changeID, unless it is already pruned.
+// They include the changes with lower ids committed later, and may include some of the changes committed before it.
+func (r *changeStreamer) ChangesSince(ctx context.Context, changeID int64, tagID int, featureIDs []int) ([]domain.BannerChange, error) {
+	const logErrPrefix = "repository.ChangesSince: %w"
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer conn.Release()
+
+	var kept bool
+	err = conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM banner_changes WHERE change_id = $1)", changeID).Scan(&kept)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	if !kept {
+		return nil, fmt.Errorf(logErrPrefix, appErrors.ErrChangesNotKept)
+	}
+
+	changes, err := queryChanges(ctx, conn, "SELECT change_id, feature, tag FROM banner_changes WHERE "+missedChangesCondition+" AND feature = ANY($2) AND tag IN ($3, 0) ORDER BY change_id", changeID, featureIDs, tagID)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return changes, nil
+}
+
+// PruneChanges deletes the changes older than the retention until ctx is done.
+func (r *changeStreamer) PruneChanges(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := r.prune(ctx); err != nil && ctx.Err() == nil {
+			logger.Logger().Errorln("repository.PruneChanges:", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// prune keeps the last change, so the subscribers which are up to date resume without reloading all of their banners.
+func (r *changeStreamer) prune(ctx context.Context) error {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, "DELETE FROM banner_changes WHERE created_at < $1 AND change_id < (SELECT MAX(change_id) FROM banner_changes)", time.Now().Add(-r.retention))
+	return err
+}
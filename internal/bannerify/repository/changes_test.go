@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+func TestPublishChanges(t *testing.T) {
+	r := NewChangeStreamer(nil, 0)
+
+	changes, unsubscribe := r.Subscribe(1, []int{2, 3})
+	defer unsubscribe()
+
+	r.publish([]domain.BannerChange{
+		{ID: 1, FeatureID: 2, TagID: 1},
+		{ID: 2, FeatureID: 2, TagID: 5},
+		{ID: 3, FeatureID: 4, TagID: 1},
+		{ID: 4, FeatureID: 3, TagID: 0},
+	})
+
+	require.Equal(t, domain.BannerChange{ID: 1, FeatureID: 2, TagID: 1}, <-changes)
+	require.Equal(t, domain.BannerChange{ID: 4, FeatureID: 3, TagID: 0}, <-changes)
+	require.Empty(t, changes)
+	require.Equal(t, int64(4), r.lastID)
+
+	lagging := make([]domain.BannerChange, subscriberBuffer+1)
+	for i := range lagging {
+		lagging[i] = domain.BannerChange{ID: int64(i + 5), FeatureID: 2, TagID: 1}
+	}
+
+	r.publish(lagging)
+
+	for range subscriberBuffer {
+		<-changes
+	}
+
+	_, ok := <-changes
+	require.False(t, ok)
+	require.Empty(t, r.subscribers)
+}
+
+func TestPublishChangesOnce(t *testing.T) {
+	r := NewChangeStreamer(nil, 0)
+
+	changes, unsubscribe := r.Subscribe(1, []int{2})
+	defer unsubscribe()
+
+	r.publish([]domain.BannerChange{{ID: 3, FeatureID: 2, TagID: 1}})
+
+	// the change 2 is committed after the change 3, both are read again after the connection is restored
+	r.publish([]domain.BannerChange{{ID: 2, FeatureID: 2, TagID: 1}, {ID: 3, FeatureID: 2, TagID: 1}})
+
+	require.Equal(t, domain.BannerChange{ID: 3, FeatureID: 2, TagID: 1}, <-changes)
+	require.Equal(t, domain.BannerChange{ID: 2, FeatureID: 2, TagID: 1}, <-changes)
+	require.Empty(t, changes)
+	require.Equal(t, int64(3), r.lastID)
+
+	for i := range sentHistory {
+		r.remember(int64(i + 4))
+	}
+
+	require.Len(t, r.sent, sentHistory)
+	require.NotContains(t, r.sent, int64(3))
+	require.Contains(t, r.sent, int64(sentHistory+3))
+}
@@ -0,0 +1,254 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+)
+
+const (
+	// eventBucket is the granularity of the statistics, the events are counted per hour they are received in.
+	eventBucket = time.Hour
+
+	// finalFlushTimeout limits the time the events left on shutdown are written in.
+	finalFlushTimeout = time.Second * 5
+)
+
+var (
+	_ domain.BannerRepositoryEventTracker = (*eventTracker)(nil)
+)
+
+type eventKey struct {
+	bannerID  int
+	versionID int
+	featureID int
+	tagID     int
+	bucket    time.Time
+}
+
+type eventCounts struct {
+	impressions int64
+	clicks      int64
+}
+
+// eventTracker counts the events in memory and writes the counters in batches, so the requests do not wait for the database.
+// The events are written every flushInterval or as soon as batchSize counters are pending,
+// while more than maxPending counters are pending the events are rejected.
+type eventTracker struct {
+	db            *postgres
+	mu            sync.Mutex
+	pending       map[eventKey]eventCounts
+	flushInterval time.Duration
+	batchSize     int
+	maxPending    int
+	full          chan struct{}
+}
+
+func NewEventTracker(pg *postgres, flushInterval time.Duration, batchSize int, maxPending int) *eventTracker {
+	return &eventTracker{
+		db:            pg,
+		pending:       make(map[eventKey]eventCounts),
+		flushInterval: flushInterval,
+		batchSize:     batchSize,
+		maxPending:    maxPending,
+		full:          make(chan struct{}, 1),
+	}
+}
+
+func (r *eventTracker) RecordEvents(ctx context.Context, events []domain.BannerEvent) error {
+	const logErrPrefix = "repository.RecordEvents: %w"
+
+	bucket := time.Now().UTC().Truncate(eventBucket)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.pending) >= r.maxPending {
+		return fmt.Errorf(logErrPrefix, appErrors.ErrEventBufferFull)
+	}
+
+	for _, event := range events {
+		key := eventKey{bannerID: event.BannerID, versionID: event.VersionID, featureID: event.FeatureID, tagID: event.TagID, bucket: bucket}
+		counts := r.pending[key]
+		switch event.Type {
+		case domain.ImpressionEvent:
+			counts.impressions++
+		case domain.ClickEvent:
+			counts.clicks++
+		}
+
+		r.pending[key] = counts
+	}
+
+	if len(r.pending) >= r.batchSize {
+		select {
+		case r.full <- struct{}{}:
+		default:
+		}
+	}
+
+	return nil
+}
+
+// FlushEvents writes the recorded events until ctx is done, then it writes the events left.
+func (r *eventTracker) FlushEvents(ctx context.Context) {
+	ticker := time.NewTicker(r.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			flushCtx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+			defer cancel()
+
+			if err := r.flush(flushCtx); err != nil {
+				logger.Logger().Errorln("repository.FlushEvents: the events are lost because of", err.Error())
+			}
+
+			return
+		case <-ticker.C:
+		case <-r.full:
+		}
+
+		if err := r.flush(ctx); err != nil {
+			logger.Logger().Errorln("repository.FlushEvents:", err.Error())
+		}
+	}
+}
+
+// flush returns the counters back to the pending ones if they are not written, so they are retried with the next batch.
+// The events of the banners, versions and features which do not match each other are dropped.
+func (r *eventTracker) flush(ctx context.Context) error {
+	r.mu.Lock()
+	pending := r.pending
+	r.pending = make(map[eventKey]eventCounts)
+	r.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	err := r.write(ctx, pending)
+	if err != nil {
+		r.mu.Lock()
+		for key, counts := range pending {
+			merged := r.pending[key]
+			merged.impressions += counts.impressions
+			merged.clicks += counts.clicks
+			r.pending[key] = merged
+		}
+		r.mu.Unlock()
+
+		return err
+	}
+
+	return nil
+}
+
+// write sorts the counters, so that the instances writing the same rows concurrently lock them in the same order.
+func (r *eventTracker) write(ctx context.Context, pending map[eventKey]eventCounts) error {
+	keys := make([]eventKey, 0, len(pending))
+	for key := range pending {
+		keys = append(keys, key)
+	}
+
+	slices.SortFunc(keys, func(a, b eventKey) int {
+		return cmp.Or(
+			cmp.Compare(a.bannerID, b.bannerID),
+			cmp.Compare(a.versionID, b.versionID),
+			cmp.Compare(a.featureID, b.featureID),
+			cmp.Compare(a.tagID, b.tagID),
+			a.bucket.Compare(b.bucket),
+		)
+	})
+
+	var (
+		bannerIDs   = make([]int, 0, len(keys))
+		versionIDs  = make([]int, 0, len(keys))
+		featureIDs  = make([]int, 0, len(keys))
+		tagIDs      = make([]int, 0, len(keys))
+		buckets     = make([]time.Time, 0, len(keys))
+		impressions = make([]int64, 0, len(keys))
+		clicks      = make([]int64, 0, len(keys))
+	)
+
+	for _, key := range keys {
+		bannerIDs = append(bannerIDs, key.bannerID)
+		versionIDs = append(versionIDs, key.versionID)
+		featureIDs = append(featureIDs, key.featureID)
+		tagIDs = append(tagIDs, key.tagID)
+		buckets = append(buckets, key.bucket)
+		impressions = append(impressions, pending[key].impressions)
+		clicks = append(clicks, pending[key].clicks)
+	}
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, "INSERT INTO banner_stats (banner_id, version_id, feature, tag, bucket, impressions, clicks) SELECT e.banner_id, e.version_id, e.feature, e.tag, e.bucket, e.impressions, e.clicks FROM unnest($1::INT[], $2::INT[], $3::INT[], $4::INT[], $5::TIMESTAMPTZ[], $6::BIGINT[], $7::BIGINT[]) WITH ORDINALITY AS e(banner_id, version_id, feature, tag, bucket, impressions, clicks, n) JOIN banner_versions bv ON e.version_id = bv.version_id AND e.banner_id = bv.banner_id AND e.feature = bv.feature ORDER BY e.n ON CONFLICT (banner_id, version_id, feature, tag, bucket) DO UPDATE SET impressions = banner_stats.impressions + EXCLUDED.impressions, clicks = banner_stats.clicks + EXCLUDED.clicks", bannerIDs, versionIDs, featureIDs, tagIDs, buckets, impressions, clicks)
+
+	return err
+}
+
+// GetBannerStats sums the events of the hours starting in [from, to), the banners are paginated, each with all of its versions.
+func (r *eventTracker) GetBannerStats(ctx context.Context, bannerID *int, featureID *int, tagID *int, from time.Time, to time.Time, limit int, offset int) ([]domain.BannerStats, error) {
+	const logErrPrefix = "repository.GetBannerStats: %w"
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, "WITH filtered AS (SELECT banner_id, version_id, SUM(impressions)::BIGINT AS impressions, SUM(clicks)::BIGINT AS clicks FROM banner_stats WHERE ($1::INT IS NULL OR banner_id = $1::INT) AND ($2::INT IS NULL OR feature = $2::INT) AND ($3::INT IS NULL OR tag = $3::INT) AND bucket >= $4 AND bucket < $5 GROUP BY banner_id, version_id), page AS (SELECT DISTINCT banner_id FROM filtered ORDER BY banner_id LIMIT $6 OFFSET $7) SELECT f.banner_id, f.version_id, f.impressions, f.clicks FROM filtered f JOIN page p ON f.banner_id = p.banner_id ORDER BY f.banner_id, f.version_id", bannerID, featureID, tagID, from, to, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer rows.Close()
+
+	stats := make([]domain.BannerStats, 0)
+	for rows.Next() {
+		var id int
+		var version domain.VersionStats
+		if err = rows.Scan(&id, &version.VersionID, &version.Impressions, &version.Clicks); err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
+		}
+
+		version.CTR = ctr(version.Clicks, version.Impressions)
+
+		if len(stats) == 0 || stats[len(stats)-1].BannerID != id {
+			stats = append(stats, domain.BannerStats{BannerID: id})
+		}
+
+		banner := &stats[len(stats)-1]
+		banner.Impressions += version.Impressions
+		banner.Clicks += version.Clicks
+		banner.CTR = ctr(banner.Clicks, banner.Impressions)
+		banner.Versions = append(banner.Versions, version)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return stats, nil
+}
+
+// ctr is the share of the impressions which were clicked, it is zero without impressions.
+func ctr(clicks int64, impressions int64) float64 {
+	if impressions == 0 {
+		return 0
+	}
+
+	return float64(clicks) / float64(impressions)
+}
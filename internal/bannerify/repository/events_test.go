@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+func TestRecordEvents(t *testing.T) {
+	ctx := context.Background()
+	r := NewEventTracker(nil, time.Minute, 2, 3)
+
+	err := r.RecordEvents(ctx, []domain.BannerEvent{
+		{Type: domain.ImpressionEvent, BannerID: 1, VersionID: 2, FeatureID: 3, TagID: 4},
+		{Type: domain.ImpressionEvent, BannerID: 1, VersionID: 2, FeatureID: 3, TagID: 4},
+		{Type: domain.ClickEvent, BannerID: 1, VersionID: 2, FeatureID: 3, TagID: 4},
+	})
+	require.NoError(t, err)
+	require.Len(t, r.pending, 1)
+
+	for _, counts := range r.pending {
+		require.Equal(t, eventCounts{impressions: 2, clicks: 1}, counts)
+	}
+
+	require.Empty(t, r.full)
+
+	err = r.RecordEvents(ctx, []domain.BannerEvent{
+		{Type: domain.ImpressionEvent, BannerID: 1, VersionID: 5, FeatureID: 3, TagID: 4},
+		{Type: domain.ImpressionEvent, BannerID: 1, VersionID: 5, FeatureID: 3, TagID: 0},
+	})
+	require.NoError(t, err)
+	require.Len(t, r.pending, 3)
+	require.Len(t, r.full, 1)
+
+	err = r.RecordEvents(ctx, []domain.BannerEvent{{Type: domain.ClickEvent, BannerID: 1, VersionID: 2, FeatureID: 3, TagID: 4}})
+	require.ErrorIs(t, err, appErrors.ErrEventBufferFull)
+}
+
+func TestCTR(t *testing.T) {
+	require.Zero(t, ctr(0, 0))
+	require.Zero(t, ctr(3, 0))
+	require.InDelta(t, 0.25, ctr(1, 4), 1e-9)
+}
@@ -0,0 +1,436 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/bucket"
+	"github.com/PoorMercymain/bannerify/pkg/jsonschema"
+)
+
+type resolvedVariant struct {
+	key              string
+	weight           int
+	bannerID         int
+	versionID        int
+	content          string
+	locale           string
+	locales          []string
+	template         bool
+	missingVariables string
+	frequencyCap     int
+}
+
+// attachVariants adds the visible variants of the running experiments of the winning pairs to the banners and their fallbacks.
+// The hidden variants still cap the cache ttl by their activation window boundaries, the same as the candidates do.
+func attachVariants(ctx context.Context, conn *pgxpool.Conn, banners map[int]resolvedBanner, winners []domain.FeatureTag, isAdmin bool, userLocale string) error {
+	featureIDs := make([]int, 0, len(winners))
+	tagIDs := make([]int, 0, len(winners))
+	for _, pair := range winners {
+		featureIDs = append(featureIDs, pair.FeatureID)
+		tagIDs = append(tagIDs, pair.TagID)
+	}
+
+	rows, err := conn.Query(ctx, "SELECT e.experiment_id, e.feature, e.tag, ev.variant_key, ev.weight, bv.banner_id, bv.version_id, COALESCE(bvl.data, bv.data), CASE WHEN bvl.data IS NULL THEN bv.default_locale ELSE bvl.locale END, ARRAY(SELECT l.locale FROM banner_version_locales l WHERE l.version_id = bv.version_id ORDER BY l.locale), bv.is_template, bv.missing_variables, COALESCE(bv.frequency_cap, 0), (($3 = TRUE) OR ((bv.active_from IS NULL OR bv.active_from <= now()) AND (bv.active_until IS NULL OR bv.active_until > now()))) AS visible, CASE WHEN $3 = TRUE THEN NULL ELSE LEAST(CASE WHEN bv.active_from > now() THEN bv.active_from END, CASE WHEN bv.active_until > now() THEN bv.active_until END) END AS boundary FROM unnest($1::INT[], $2::INT[]) AS p(feature, tag) JOIN experiments e ON e.feature = p.feature AND e.tag = p.tag JOIN experiment_variants ev ON e.experiment_id = ev.experiment_id JOIN banner_versions bv ON ev.version_id = bv.version_id LEFT JOIN banner_version_locales bvl ON bv.version_id = bvl.version_id AND bvl.locale = $4 WHERE e.is_active = TRUE AND ((bv.is_active = TRUE) OR ($3 = TRUE)) ORDER BY e.feature, e.tag, ev.variant_key", featureIDs, tagIDs, isAdmin, userLocale)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		experimentID int
+		featureID    int
+		tagID        int
+		variant      resolvedVariant
+		locale       *string
+		visible      bool
+		boundary     *time.Time
+	)
+
+	for rows.Next() {
+		if err = rows.Scan(&experimentID, &featureID, &tagID, &variant.key, &variant.weight, &variant.bannerID, &variant.versionID, &variant.content, &locale, &variant.locales, &variant.template, &variant.missingVariables, &variant.frequencyCap, &visible, &boundary); err != nil {
+			return err
+		}
+
+		variant.locale = optionalString(locale)
+
+		banner := banners[featureID]
+		banner.nextBoundary = earliest(banner.nextBoundary, boundary)
+		if visible {
+			candidate := &banner
+			for i := range banner.fallbacks {
+				if banner.fallbacks[i].tagID == tagID {
+					candidate = &banner.fallbacks[i]
+				}
+			}
+
+			candidate.experimentID = experimentID
+			candidate.variants = append(candidate.variants, variant)
+		}
+
+		banners[featureID] = banner
+	}
+
+	return rows.Err()
+}
+
+func earliest(a *time.Time, b *time.Time) *time.Time {
+	if a == nil || (b != nil && b.Before(*a)) {
+		return b
+	}
+
+	return a
+}
+
+// chooseVariant serves the variant of the experiment the user falls into, the same user keeps getting the same variant
+// while the variants do not change. The chosen banner of the pair is served to the requests without a user id.
+func chooseVariant(banner domain.UserBanner, userID string) domain.UserBanner {
+	variants := banner.Variants
+	banner.Variants = nil
+
+	if len(variants) == 0 || userID == "" {
+		banner.ExperimentID = 0
+		return banner
+	}
+
+	weights := make([]int, 0, len(variants))
+	for _, variant := range variants {
+		weights = append(weights, variant.Weight)
+	}
+
+	i := bucket.Choose(strconv.Itoa(banner.ExperimentID)+":"+userID, weights)
+	if i < 0 {
+		return banner
+	}
+
+	banner.BannerID = variants[i].BannerID
+	banner.VersionID = variants[i].VersionID
+	banner.Content = variants[i].Content
+	banner.Encoded = variants[i].Encoded
+	banner.ETag = variants[i].ETag
+	banner.Locale = variants[i].Locale
+	banner.Locales = variants[i].Locales
+	banner.Template = variants[i].Template
+	banner.MissingVariables = variants[i].MissingVariables
+	banner.FrequencyCap = variants[i].FrequencyCap
+	banner.Variant = variants[i].Key
+
+	return banner
+}
+
+var (
+	_ domain.BannerRepositoryExperimenter = (*experimenter)(nil)
+)
+
+type experimenter struct {
+	db    *postgres
+	cache domain.BannerCache
+}
+
+func NewExperimenter(pg *postgres, cache domain.BannerCache) *experimenter {
+	return &experimenter{db: pg, cache: cache}
+}
+
+func (r *experimenter) CreateExperiment(ctx context.Context, experiment domain.Experiment) (int, error) {
+	const logErrPrefix = "repository.CreateExperiment: %w"
+
+	var experimentID int
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		err := checkVariantVersions(ctx, tx, *experiment.FeatureID, experiment.Variants)
+		if err != nil {
+			return err
+		}
+
+		if experiment.IsActive == nil || *experiment.IsActive {
+			err = checkExperimentBanner(ctx, tx, domain.FeatureTag{FeatureID: *experiment.FeatureID, TagID: *experiment.TagID})
+			if err != nil {
+				return err
+			}
+		}
+
+		err = tx.QueryRow(ctx, "INSERT INTO experiments (feature, tag, is_active) VALUES ($1, $2, COALESCE($3, TRUE)) RETURNING experiment_id", *experiment.FeatureID, *experiment.TagID, experiment.IsActive).Scan(&experimentID)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				return appErrors.ErrExperimentExists
+			}
+
+			return err
+		}
+
+		err = insertVariants(ctx, tx, experimentID, experiment.Variants)
+		if err != nil {
+			return err
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxExperimentCreated, domain.ChangePayload{ExperimentID: experimentID, FeatureID: *experiment.FeatureID, TagID: *experiment.TagID, Affected: []domain.FeatureTag{{FeatureID: *experiment.FeatureID, TagID: *experiment.TagID}}})
+		if err != nil {
+			return err
+		}
+
+		return writeSnapshotAudit(ctx, tx, auditChange{action: domain.AuditExperimentCreate, targetID: &experimentID}, experimentSnapshotQuery, experimentID)
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf(logErrPrefix, err)
+	}
+
+	invalidate(ctx, r.cache, []domain.FeatureTag{{FeatureID: *experiment.FeatureID, TagID: *experiment.TagID}}, logErrPrefix)
+
+	return experimentID, nil
+}
+
+// checkVariantVersions requires the versions to belong to the feature and to conform to its content schema,
+// so a variant never shows a banner of another feature or the content the schema rejects.
+func checkVariantVersions(ctx context.Context, tx pgx.Tx, featureID int, variants []domain.ExperimentVariant) error {
+	versionIDs := make([]int, 0, len(variants))
+	seen := make(map[int]struct{}, len(variants))
+	for _, variant := range variants {
+		if _, ok := seen[variant.VersionID]; ok {
+			continue
+		}
+
+		seen[variant.VersionID] = struct{}{}
+		versionIDs = append(versionIDs, variant.VersionID)
+	}
+
+	var count int
+	err := tx.QueryRow(ctx, "SELECT COUNT(*) FROM banner_versions WHERE version_id = ANY($1) AND feature = $2", versionIDs, featureID).Scan(&count)
+	if err != nil {
+		return err
+	}
+
+	if count != len(versionIDs) {
+		return appErrors.ErrVariantVersionMismatch
+	}
+
+	return checkVariantSchemas(ctx, tx, variants)
+}
+
+// checkExperimentBanner requires the pair to have an active banner which has not ended its activation window,
+// because the variants are served only in place of the banner of the pair, and an experiment without one is never served.
+func checkExperimentBanner(ctx context.Context, tx pgx.Tx, pair domain.FeatureTag) error {
+	var exists bool
+	err := tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM chosen_versions cv JOIN banner_versions bv ON cv.version_id = bv.version_id WHERE cv.feature = $1 AND cv.tag = $2 AND bv.is_active = TRUE AND (bv.active_until IS NULL OR bv.active_until > now()))", pair.FeatureID, pair.TagID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return appErrors.ErrExperimentWithoutBanner
+	}
+
+	return nil
+}
+
+// checkVariantSchemas reports the violations under the paths of the variants in the request, such as /variants/1/content/title.
+func checkVariantSchemas(ctx context.Context, tx pgx.Tx, variants []domain.ExperimentVariant) error {
+	var violations []jsonschema.Violation
+	for i, variant := range variants {
+		err := checkContentSchema(ctx, tx, variant.VersionID)
+		if err == nil {
+			continue
+		}
+
+		var validationErr *jsonschema.ValidationError
+		if !errors.As(err, &validationErr) {
+			return err
+		}
+
+		for _, violation := range validationErr.Violations {
+			violations = append(violations, jsonschema.Violation{Path: "/variants/" + strconv.Itoa(i) + violation.Path, Message: violation.Message})
+		}
+	}
+
+	if len(violations) != 0 {
+		return fmt.Errorf("%w: %w", appErrors.ErrContentSchemaViolation, &jsonschema.ValidationError{Violations: violations})
+	}
+
+	return nil
+}
+
+func insertVariants(ctx context.Context, tx pgx.Tx, experimentID int, variants []domain.ExperimentVariant) error {
+	for _, variant := range variants {
+		_, err := tx.Exec(ctx, "INSERT INTO experiment_variants (experiment_id, variant_key, version_id, weight) VALUES ($1, $2, $3, $4)", experimentID, variant.Key, variant.VersionID, variant.Weight)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *experimenter) ListExperiments(ctx context.Context, featureID *int, tagID *int, limit int, offset int) ([]domain.ExperimentListElement, error) {
+	const logErrPrefix = "repository.ListExperiments: %w"
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, "SELECT e.experiment_id, e.feature, e.tag, e.is_active, e.created_at, e.updated_at, COALESCE(json_agg(json_build_object('key', ev.variant_key, 'version_id', ev.version_id, 'weight', ev.weight) ORDER BY ev.variant_key) FILTER (WHERE ev.experiment_id IS NOT NULL), '[]') FROM experiments e LEFT JOIN experiment_variants ev ON e.experiment_id = ev.experiment_id WHERE ($1::INT IS NULL OR e.feature = $1::INT) AND ($2::INT IS NULL OR e.tag = $2::INT) GROUP BY e.experiment_id ORDER BY e.updated_at DESC LIMIT $3 OFFSET $4", featureID, tagID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer rows.Close()
+
+	var (
+		experiments          []domain.ExperimentListElement
+		curElem              domain.ExperimentListElement
+		createdAt, updatedAt time.Time
+		variants             []byte
+	)
+
+	for rows.Next() {
+		if err = rows.Scan(&curElem.ExperimentID, &curElem.FeatureID, &curElem.TagID, &curElem.IsActive, &createdAt, &updatedAt, &variants); err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
+		}
+
+		curElem.Variants = nil
+		if err = json.Unmarshal(variants, &curElem.Variants); err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
+		}
+
+		curElem.CreatedAt = createdAt.Format(time.RFC3339)
+		curElem.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+		experiments = append(experiments, curElem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return experiments, nil
+}
+
+// UpdateExperiment replaces all of the variants if they are provided. The experiment which stays active is checked
+// to have a banner for its pair, the same as a new one.
+func (r *experimenter) UpdateExperiment(ctx context.Context, experimentID int, experiment domain.Experiment) error {
+	const logErrPrefix = "repository.UpdateExperiment: %w"
+
+	var oldPair, newPair domain.FeatureTag
+	var isActive bool
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, "SELECT feature, tag FROM experiments WHERE experiment_id = $1 FOR UPDATE", experimentID).Scan(&oldPair.FeatureID, &oldPair.TagID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return appErrors.ErrExperimentNotFound
+			}
+
+			return err
+		}
+
+		before, err := snapshot(ctx, tx, experimentSnapshotQuery, experimentID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, "UPDATE experiments SET feature = COALESCE($1, feature), tag = COALESCE($2, tag), is_active = COALESCE($3, is_active), updated_at = CURRENT_TIMESTAMP WHERE experiment_id = $4 RETURNING feature, tag, is_active", experiment.FeatureID, experiment.TagID, experiment.IsActive, experimentID).Scan(&newPair.FeatureID, &newPair.TagID, &isActive)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+				return appErrors.ErrExperimentExists
+			}
+
+			return err
+		}
+
+		if experiment.Variants == nil {
+			var mismatched int
+			err = tx.QueryRow(ctx, "SELECT COUNT(*) FROM experiment_variants ev JOIN banner_versions bv ON ev.version_id = bv.version_id WHERE ev.experiment_id = $1 AND bv.feature <> $2", experimentID, newPair.FeatureID).Scan(&mismatched)
+			if err != nil {
+				return err
+			}
+
+			if mismatched != 0 {
+				return appErrors.ErrVariantVersionMismatch
+			}
+		} else {
+			err = checkVariantVersions(ctx, tx, newPair.FeatureID, experiment.Variants)
+			if err != nil {
+				return err
+			}
+
+			_, err = tx.Exec(ctx, "DELETE FROM experiment_variants WHERE experiment_id = $1", experimentID)
+			if err != nil {
+				return err
+			}
+
+			err = insertVariants(ctx, tx, experimentID, experiment.Variants)
+			if err != nil {
+				return err
+			}
+		}
+
+		if isActive {
+			err = checkExperimentBanner(ctx, tx, newPair)
+			if err != nil {
+				return err
+			}
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxExperimentUpdated, domain.ChangePayload{ExperimentID: experimentID, FeatureID: newPair.FeatureID, TagID: newPair.TagID, Affected: []domain.FeatureTag{oldPair, newPair}})
+		if err != nil {
+			return err
+		}
+
+		return writeSnapshotAudit(ctx, tx, auditChange{action: domain.AuditExperimentUpdate, targetID: &experimentID, before: before}, experimentSnapshotQuery, experimentID)
+	})
+
+	if err != nil {
+		return fmt.Errorf(logErrPrefix, err)
+	}
+
+	invalidate(ctx, r.cache, []domain.FeatureTag{oldPair, newPair}, logErrPrefix)
+
+	return nil
+}
+
+func (r *experimenter) DeleteExperiment(ctx context.Context, experimentID int) error {
+	const logErrPrefix = "repository.DeleteExperiment: %w"
+
+	var pair domain.FeatureTag
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, experimentSnapshotQuery, experimentID)
+		if err != nil {
+			return err
+		}
+
+		err = tx.QueryRow(ctx, "DELETE FROM experiments WHERE experiment_id = $1 RETURNING feature, tag", experimentID).Scan(&pair.FeatureID, &pair.TagID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return appErrors.ErrExperimentNotFound
+			}
+
+			return err
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxExperimentDeleted, domain.ChangePayload{ExperimentID: experimentID, FeatureID: pair.FeatureID, TagID: pair.TagID, Affected: []domain.FeatureTag{pair}})
+		if err != nil {
+			return err
+		}
+
+		return writeAudit(ctx, tx, auditChange{action: domain.AuditExperimentDelete, targetID: &experimentID, before: before})
+	})
+
+	if err != nil {
+		return fmt.Errorf(logErrPrefix, err)
+	}
+
+	invalidate(ctx, r.cache, []domain.FeatureTag{pair}, logErrPrefix)
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/breaker"
+)
+
+func TestChooseVariant(t *testing.T) {
+	banner := resolvedBanner{
+		versionID:    1,
+		content:      "{\"title\": \"control\"}",
+		experimentID: 7,
+		variants: []resolvedVariant{
+			{key: "a", weight: 1, versionID: 2, content: "{\"title\": \"a\"}"},
+			{key: "b", weight: 1, versionID: 3, content: "{\"title\": \"b\"}"},
+		},
+	}.userBanner()
+
+	res := chooseVariant(banner, "")
+	require.Equal(t, 1, res.VersionID)
+	require.Empty(t, res.Variant)
+	require.Nil(t, res.Variants)
+
+	chosen := make(map[string]int)
+	for i := 0; i < 100; i++ {
+		userID := strconv.Itoa(i)
+		res = chooseVariant(banner, userID)
+		require.NotEmpty(t, res.Variant)
+		require.Nil(t, res.Variants)
+		require.Equal(t, res, chooseVariant(banner, userID))
+
+		chosen[res.Variant]++
+	}
+
+	require.Len(t, chosen, 2)
+	require.Len(t, banner.Variants, 2)
+}
+
+func TestGetBannerVariantFromCache(t *testing.T) {
+	ctx := context.Background()
+	r := newTestGetter(NewMemoryCache(100, 0), breaker.New(1, time.Minute))
+
+	banner := resolvedBanner{
+		versionID:    1,
+		content:      "{}",
+		experimentID: 7,
+		variants:     []resolvedVariant{{key: "only", weight: 1, versionID: 2, content: "{\"title\": \"only\"}"}},
+	}.userBanner()
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2})
+	require.NoError(t, err)
+
+	r.storeBanners(ctx, []int{1}, false, "", map[int]domain.UserBanner{2: banner}, generations, "%w")
+
+	res, err := r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{UserID: "user"})
+	require.NoError(t, err)
+	require.Equal(t, "only", res.Variant)
+	require.Equal(t, 2, res.VersionID)
+
+	res, err = r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{})
+	require.NoError(t, err)
+	require.Empty(t, res.Variant)
+	require.Equal(t, 1, res.VersionID)
+}
+
+func TestGetLocalizedBannerVariant(t *testing.T) {
+	ctx := context.Background()
+	r := newTestGetter(NewMemoryCache(100, 0), breaker.New(1, time.Minute))
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2})
+	require.NoError(t, err)
+
+	banner := resolvedBanner{
+		versionID:    1,
+		content:      "{\"title\": \"control\"}",
+		locale:       "en",
+		locales:      []string{"de"},
+		experimentID: 7,
+		variants:     []resolvedVariant{{key: "only", weight: 1, versionID: 2, content: "{\"title\": \"variant\"}", locale: "en", locales: []string{"fr"}}},
+	}.userBanner()
+	r.storeBanners(ctx, []int{1}, false, "", map[int]domain.UserBanner{2: banner}, generations, "%w")
+
+	localized := resolvedBanner{
+		versionID:    1,
+		content:      "{\"title\": \"control\"}",
+		locale:       "en",
+		locales:      []string{"de"},
+		experimentID: 7,
+		variants:     []resolvedVariant{{key: "only", weight: 1, versionID: 2, content: "{\"title\": \"variante\"}", locale: "fr", locales: []string{"fr"}}},
+	}.userBanner()
+	r.storeBanners(ctx, []int{1}, false, "fr", map[int]domain.UserBanner{2: localized}, generations, "%w")
+
+	res, err := r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{UserID: "user", Locales: []string{"fr"}})
+	require.NoError(t, err)
+	require.Equal(t, "only", res.Variant)
+	require.Equal(t, "fr", res.Locale)
+	require.Equal(t, []string{"fr"}, res.Locales)
+	require.JSONEq(t, "{\"title\": \"variante\"}", string(res.Content))
+
+	// the variant has no translation the control has
+	res, err = r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{UserID: "user", Locales: []string{"de"}})
+	require.NoError(t, err)
+	require.Equal(t, "only", res.Variant)
+	require.Equal(t, "en", res.Locale)
+	require.JSONEq(t, "{\"title\": \"variant\"}", string(res.Content))
+}
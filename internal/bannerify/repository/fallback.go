@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// attachFallbacks serves the fallback banners of the features instead of the missing banners.
+// The features whose last banner has a targeting rule or a frequency cap keep their fallback banners as the last of the fallbacks,
+// so it is served to the users none of the rules matches and to the ones who have reached the caps. The fallback banner is the chosen version of the banner
+// set for the feature, it is visible by the same rules as the other banners, while its targeting rule is not applied.
+// The fallback banner of a feature is not served if the banner is moved to another feature.
+func attachFallbacks(ctx context.Context, conn *pgxpool.Conn, banners map[int]resolvedBanner, isAdmin bool, userLocale string) error {
+	featureIDs := make([]int, 0, len(banners))
+	for featureID, banner := range banners {
+		last := banner
+		if len(banner.fallbacks) != 0 {
+			last = banner.fallbacks[len(banner.fallbacks)-1]
+		}
+
+		if banner.missing || last.targeting != "" || last.frequencyCap > 0 {
+			featureIDs = append(featureIDs, featureID)
+		}
+	}
+
+	if len(featureIDs) == 0 {
+		return nil
+	}
+
+	rows, err := conn.Query(ctx, "SELECT f.feature_id, b.banner_id, bv.version_id, COALESCE(bvl.data, bv.data), CASE WHEN bvl.data IS NULL THEN bv.default_locale ELSE bvl.locale END, ARRAY(SELECT l.locale FROM banner_version_locales l WHERE l.version_id = bv.version_id ORDER BY l.locale), bv.is_template, bv.missing_variables, COALESCE(bv.frequency_cap, 0), (($2 = TRUE) OR ((bv.active_from IS NULL OR bv.active_from <= now()) AND (bv.active_until IS NULL OR bv.active_until > now()))) AS visible, CASE WHEN $2 = TRUE THEN NULL ELSE LEAST(CASE WHEN bv.active_from > now() THEN bv.active_from END, CASE WHEN bv.active_until > now() THEN bv.active_until END) END AS boundary FROM features f JOIN banners b ON f.fallback_banner_id = b.banner_id JOIN banner_versions bv ON b.chosen_version_id = bv.version_id AND bv.feature = f.feature_id LEFT JOIN banner_version_locales bvl ON bv.version_id = bvl.version_id AND bvl.locale = $3 WHERE f.feature_id = ANY($1) AND ((bv.is_active = TRUE) OR ($2 = TRUE))", featureIDs, isAdmin, userLocale)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		featureID int
+		fallback  resolvedBanner
+		locale    *string
+		visible   bool
+		boundary  *time.Time
+	)
+
+	for rows.Next() {
+		if err = rows.Scan(&featureID, &fallback.bannerID, &fallback.versionID, &fallback.content, &locale, &fallback.locales, &fallback.template, &fallback.missingVariables, &fallback.frequencyCap, &visible, &boundary); err != nil {
+			return err
+		}
+
+		fallback.locale = optionalString(locale)
+		fallback.featureFallback = true
+
+		banner := banners[featureID]
+		banner.nextBoundary = earliest(banner.nextBoundary, boundary)
+		switch {
+		case !visible:
+		case banner.missing:
+			fallback.nextBoundary = banner.nextBoundary
+			banner = fallback
+		default:
+			banner.fallbacks = append(banner.fallbacks, fallback)
+		}
+
+		banners[featureID] = banner
+	}
+
+	return rows.Err()
+}
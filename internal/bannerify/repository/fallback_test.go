@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/breaker"
+)
+
+func TestGetFallbackBannerFromCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(100, 0)
+	r := newTestGetter(c, breaker.New(1, time.Minute))
+
+	banner := resolvedBanner{
+		tagID:     1,
+		versionID: 1,
+		content:   "{\"title\": \"ios\"}",
+		targeting: "platform == \"ios\"",
+		fallbacks: []resolvedBanner{{versionID: 2, content: "{\"title\": \"fallback\"}", featureFallback: true}},
+	}.userBanner()
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2, 3})
+	require.NoError(t, err)
+
+	fallback := resolvedBanner{versionID: 3, content: "{\"title\": \"fallback\"}", featureFallback: true}.userBanner()
+	r.storeBanners(ctx, []int{1}, false, "", map[int]domain.UserBanner{2: banner, 3: fallback}, generations, "%w")
+
+	res, err := r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{Attributes: map[string]string{"platform": "ios"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.VersionID)
+	require.False(t, res.FeatureFallback)
+
+	res, err = r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{Attributes: map[string]string{"platform": "android"}})
+	require.NoError(t, err)
+	require.Equal(t, 2, res.VersionID)
+	require.True(t, res.FeatureFallback)
+
+	banners, err := r.GetBanners(ctx, []int{1}, []int{3}, false, false, domain.UserContext{})
+	require.NoError(t, err)
+	require.True(t, banners[3].FeatureFallback)
+
+	require.NoError(t, c.Invalidate(ctx, []domain.FeatureTag{fallbackPair(3)}))
+
+	_, err = c.Get(ctx, bannerCacheKey([]int{1}, 3, false, ""))
+	require.ErrorIs(t, err, appErrors.ErrNotFoundInCache)
+
+	_, err = c.Get(ctx, bannerCacheKey([]int{1}, 2, false, ""))
+	require.NoError(t, err)
+}
@@ -0,0 +1,352 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/jsonschema"
+)
+
+var (
+	_ domain.BannerRepositoryFeatureRegistry = (*featureRegistry)(nil)
+)
+
+type featureRegistry struct {
+	db    *postgres
+	cache domain.BannerCache
+}
+
+func NewFeatureRegistry(pg *postgres, cache domain.BannerCache) *featureRegistry {
+	return &featureRegistry{db: pg, cache: cache}
+}
+
+// SetFeatureSchema registers the feature if it is not registered yet, the schema applies to the banners saved afterwards.
+func (r *featureRegistry) SetFeatureSchema(ctx context.Context, featureID int, schema []byte) error {
+	const logErrPrefix = "repository.SetFeatureSchema: %w"
+
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, featureSnapshotQuery, featureID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "INSERT INTO features (feature_id, content_schema) VALUES ($1, $2) ON CONFLICT (feature_id) DO UPDATE SET content_schema = EXCLUDED.content_schema, updated_at = CURRENT_TIMESTAMP", featureID, string(schema))
+		if err != nil {
+			return err
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxFeatureSchemaSet, domain.ChangePayload{FeatureID: featureID})
+		if err != nil {
+			return err
+		}
+
+		return writeSnapshotAudit(ctx, tx, auditChange{action: domain.AuditFeatureSchemaSet, targetID: &featureID, before: before}, featureSnapshotQuery, featureID)
+	})
+
+	if err != nil {
+		return fmt.Errorf(logErrPrefix, err)
+	}
+
+	return nil
+}
+
+func (r *featureRegistry) GetFeatureSchema(ctx context.Context, featureID int) ([]byte, error) {
+	const logErrPrefix = "repository.GetFeatureSchema: %w"
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer conn.Release()
+
+	var schema string
+	err = conn.QueryRow(ctx, "SELECT content_schema FROM features WHERE feature_id = $1 AND content_schema IS NOT NULL", featureID).Scan(&schema)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf(logErrPrefix, appErrors.ErrFeatureSchemaNotFound)
+		}
+
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return []byte(schema), nil
+}
+
+func (r *featureRegistry) DeleteFeatureSchema(ctx context.Context, featureID int) error {
+	const logErrPrefix = "repository.DeleteFeatureSchema: %w"
+
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, featureSnapshotQuery, featureID)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, "UPDATE features SET content_schema = NULL, updated_at = CURRENT_TIMESTAMP WHERE feature_id = $1 AND content_schema IS NOT NULL", featureID)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrFeatureSchemaNotFound
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxFeatureSchemaDeleted, domain.ChangePayload{FeatureID: featureID})
+		if err != nil {
+			return err
+		}
+
+		return writeSnapshotAudit(ctx, tx, auditChange{action: domain.AuditFeatureSchemaDelete, targetID: &featureID, before: before}, featureSnapshotQuery, featureID)
+	})
+
+	if err != nil {
+		return fmt.Errorf(logErrPrefix, err)
+	}
+
+	return nil
+}
+
+// CheckFeatureSchema validates the chosen versions of the banners of the feature against the schema without saving it,
+// it returns the banners which do not conform to it.
+func (r *featureRegistry) CheckFeatureSchema(ctx context.Context, featureID int, schema []byte) ([]domain.SchemaCheckElement, error) {
+	const logErrPrefix = "repository.CheckFeatureSchema: %w"
+
+	compiled, err := jsonschema.Compile(schema)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, "SELECT b.banner_id, bv.version_id, '' AS locale, bv.data FROM banners b JOIN banner_versions bv ON b.chosen_version_id = bv.version_id WHERE bv.feature = $1 UNION ALL SELECT b.banner_id, bv.version_id, l.locale, l.data FROM banners b JOIN banner_versions bv ON b.chosen_version_id = bv.version_id JOIN banner_version_locales l ON bv.version_id = l.version_id WHERE bv.feature = $1 ORDER BY 1, 3", featureID)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer rows.Close()
+
+	var (
+		res       []domain.SchemaCheckElement
+		bannerID  int
+		versionID int
+		locale    string
+		content   string
+	)
+
+	for rows.Next() {
+		if err = rows.Scan(&bannerID, &versionID, &locale, &content); err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
+		}
+
+		violations := contentViolations(compiled, locale, content)
+		if len(violations) == 0 {
+			continue
+		}
+
+		if len(res) == 0 || res[len(res)-1].BannerID != bannerID {
+			res = append(res, domain.SchemaCheckElement{BannerID: bannerID, VersionID: versionID})
+		}
+
+		for _, violation := range violations {
+			res[len(res)-1].Details = append(res[len(res)-1].Details, domain.ErrorDetail{Path: violation.Path, Message: violation.Message})
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return res, nil
+}
+
+// SetFeatureFallback registers the feature if it is not registered yet. The banner has to belong to the feature,
+// and its chosen version has to conform to the content schema of the feature.
+func (r *featureRegistry) SetFeatureFallback(ctx context.Context, featureID int, bannerID int) error {
+	const logErrPrefix = "repository.SetFeatureFallback: %w"
+
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		var bannerFeatureID, versionID int
+		err := tx.QueryRow(ctx, "SELECT bv.feature, bv.version_id FROM banners b JOIN banner_versions bv ON b.chosen_version_id = bv.version_id WHERE b.banner_id = $1 FOR SHARE OF b", bannerID).Scan(&bannerFeatureID, &versionID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return appErrors.ErrBannerNotFound
+			}
+
+			return err
+		}
+
+		if bannerFeatureID != featureID {
+			return appErrors.ErrFallbackOfOtherFeature
+		}
+
+		before, err := snapshot(ctx, tx, featureSnapshotQuery, featureID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "INSERT INTO features (feature_id, fallback_banner_id) VALUES ($1, $2) ON CONFLICT (feature_id) DO UPDATE SET fallback_banner_id = EXCLUDED.fallback_banner_id, updated_at = CURRENT_TIMESTAMP", featureID, bannerID)
+		if err != nil {
+			return err
+		}
+
+		// the schema is checked after the feature row is locked for the update, so a concurrent update can't deadlock on it
+		err = checkContentSchema(ctx, tx, versionID)
+		if err != nil {
+			return err
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxFeatureFallbackSet, domain.ChangePayload{BannerID: bannerID, FeatureID: featureID, Affected: []domain.FeatureTag{fallbackPair(featureID)}})
+		if err != nil {
+			return err
+		}
+
+		return writeSnapshotAudit(ctx, tx, auditChange{action: domain.AuditFeatureFallbackSet, bannerID: &bannerID, targetID: &featureID, before: before}, featureSnapshotQuery, featureID)
+	})
+
+	if err != nil {
+		return fmt.Errorf(logErrPrefix, err)
+	}
+
+	invalidate(ctx, r.cache, []domain.FeatureTag{fallbackPair(featureID)}, logErrPrefix)
+
+	return nil
+}
+
+func (r *featureRegistry) GetFeatureFallback(ctx context.Context, featureID int) (int, error) {
+	const logErrPrefix = "repository.GetFeatureFallback: %w"
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return 0, fmt.Errorf(logErrPrefix, err)
+	}
+	defer conn.Release()
+
+	var bannerID int
+	err = conn.QueryRow(ctx, "SELECT fallback_banner_id FROM features WHERE feature_id = $1 AND fallback_banner_id IS NOT NULL", featureID).Scan(&bannerID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, fmt.Errorf(logErrPrefix, appErrors.ErrFeatureFallbackNotFound)
+		}
+
+		return 0, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return bannerID, nil
+}
+
+func (r *featureRegistry) DeleteFeatureFallback(ctx context.Context, featureID int) error {
+	const logErrPrefix = "repository.DeleteFeatureFallback: %w"
+
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		var bannerID int
+		err := tx.QueryRow(ctx, "SELECT fallback_banner_id FROM features WHERE feature_id = $1 AND fallback_banner_id IS NOT NULL FOR UPDATE", featureID).Scan(&bannerID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return appErrors.ErrFeatureFallbackNotFound
+			}
+
+			return err
+		}
+
+		before, err := snapshot(ctx, tx, featureSnapshotQuery, featureID)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE features SET fallback_banner_id = NULL, updated_at = CURRENT_TIMESTAMP WHERE feature_id = $1", featureID)
+		if err != nil {
+			return err
+		}
+
+		err = writeOutbox(ctx, tx, domain.OutboxFeatureFallbackDeleted, domain.ChangePayload{BannerID: bannerID, FeatureID: featureID, Affected: []domain.FeatureTag{fallbackPair(featureID)}})
+		if err != nil {
+			return err
+		}
+
+		return writeSnapshotAudit(ctx, tx, auditChange{action: domain.AuditFeatureFallbackDelete, bannerID: &bannerID, targetID: &featureID, before: before}, featureSnapshotQuery, featureID)
+	})
+
+	if err != nil {
+		return fmt.Errorf(logErrPrefix, err)
+	}
+
+	invalidate(ctx, r.cache, []domain.FeatureTag{fallbackPair(featureID)}, logErrPrefix)
+
+	return nil
+}
+
+// checkContentSchema validates the content and the translations of the version against the content schema of its feature.
+// The schema row is locked, so it can't be replaced until the version is saved.
+func checkContentSchema(ctx context.Context, tx pgx.Tx, versionID int) error {
+	var schema string
+	err := tx.QueryRow(ctx, "SELECT f.content_schema FROM features f JOIN banner_versions bv ON f.feature_id = bv.feature WHERE bv.version_id = $1 AND f.content_schema IS NOT NULL FOR SHARE OF f", versionID).Scan(&schema)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+
+		return err
+	}
+
+	compiled, err := jsonschema.Compile([]byte(schema))
+	if err != nil {
+		return err
+	}
+
+	rows, err := tx.Query(ctx, "SELECT '' AS locale, data FROM banner_versions WHERE version_id = $1 UNION ALL SELECT locale, data FROM banner_version_locales WHERE version_id = $1 ORDER BY 1", versionID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		violations []jsonschema.Violation
+		locale     string
+		content    string
+	)
+
+	for rows.Next() {
+		if err = rows.Scan(&locale, &content); err != nil {
+			return err
+		}
+
+		violations = append(violations, contentViolations(compiled, locale, content)...)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(violations) != 0 {
+		return fmt.Errorf("%w: %w", appErrors.ErrContentSchemaViolation, &jsonschema.ValidationError{Violations: violations})
+	}
+
+	return nil
+}
+
+// contentViolations prefixes the paths with the field of the banner the content is in, /content or /locales/{locale}.
+func contentViolations(schema *jsonschema.Schema, locale string, content string) []jsonschema.Violation {
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(schema.Validate([]byte(content)), &validationErr) {
+		return nil
+	}
+
+	prefix := "/content"
+	if locale != "" {
+		prefix = "/locales/" + locale
+	}
+
+	violations := make([]jsonschema.Violation, 0, len(validationErr.Violations))
+	for _, violation := range validationErr.Violations {
+		violations = append(violations, jsonschema.Violation{Path: prefix + violation.Path, Message: violation.Message})
+	}
+
+	return violations
+}
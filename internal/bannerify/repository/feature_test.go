@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PoorMercymain/bannerify/pkg/jsonschema"
+)
+
+func TestContentViolations(t *testing.T) {
+	schema, err := jsonschema.Compile([]byte(`{"type": "object", "required": ["title"], "properties": {"title": {"type": "string"}}}`))
+	require.NoError(t, err)
+
+	require.Empty(t, contentViolations(schema, "", `{"title": "hello"}`))
+	require.Equal(t, []jsonschema.Violation{{Path: "/content/title", Message: "is required"}}, contentViolations(schema, "", `{}`))
+	require.Equal(t, []jsonschema.Violation{{Path: "/locales/de/title", Message: "should be string, got integer"}}, contentViolations(schema, "de", `{"title": 1}`))
+}
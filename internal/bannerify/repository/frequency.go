@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+)
+
+// showsDay is the UTC day the shows are counted in, the counters start from zero at midnight UTC.
+func showsDay(now time.Time) time.Time {
+	return now.UTC().Truncate(time.Hour * 24)
+}
+
+// showsKey contains the day, so the counter of the previous day is not incremented if it has not expired yet.
+func showsKey(day time.Time, userID string, bannerID int) string {
+	return fmt.Sprintf("shows_%s_%d_%s", day.Format(time.DateOnly), bannerID, userID)
+}
+
+var (
+	_ domain.ShowCounter = (*redisCache)(nil)
+)
+
+// Shows reads the counters with a pipeline, because the keys of different banners belong to different cluster slots.
+func (c *redisCache) Shows(ctx context.Context, userID string, bannerIDs []int) (map[int]int64, error) {
+	day := showsDay(time.Now())
+
+	pipe := c.UniversalClient.Pipeline()
+	cmds := make([]*redis.StringCmd, 0, len(bannerIDs))
+	for _, bannerID := range bannerIDs {
+		cmds = append(cmds, pipe.Get(ctx, showsKey(day, userID, bannerID)))
+	}
+
+	_, err := pipe.Exec(ctx)
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	shows := make(map[int]int64, len(bannerIDs))
+	for i, cmd := range cmds {
+		count, err := cmd.Int64()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+
+		shows[bannerIDs[i]] = count
+	}
+
+	return shows, nil
+}
+
+// AddShows expires the counters at the end of the day they are counted in.
+func (c *redisCache) AddShows(ctx context.Context, userID string, bannerIDs []int) error {
+	day := showsDay(time.Now())
+
+	pipe := c.UniversalClient.Pipeline()
+	for _, bannerID := range bannerIDs {
+		key := showsKey(day, userID, bannerID)
+		pipe.Incr(ctx, key)
+		pipe.ExpireAt(ctx, key, day.Add(time.Hour*24))
+	}
+
+	_, err := pipe.Exec(ctx)
+
+	return err
+}
+
+var (
+	_ domain.ShowCounter = (*memoryShowCounter)(nil)
+)
+
+// memoryShowCounter is used when the banners are not cached in Redis. The counters are not shared with other instances,
+// so it is suitable only for a single instance of the service and for tests.
+type memoryShowCounter struct {
+	mu     sync.Mutex
+	day    time.Time
+	counts map[string]int64
+}
+
+func NewMemoryShowCounter() *memoryShowCounter {
+	return &memoryShowCounter{counts: make(map[string]int64)}
+}
+
+func (c *memoryShowCounter) Shows(ctx context.Context, userID string, bannerIDs []int) (map[int]int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	day := c.rotate(time.Now())
+
+	shows := make(map[int]int64, len(bannerIDs))
+	for _, bannerID := range bannerIDs {
+		shows[bannerID] = c.counts[showsKey(day, userID, bannerID)]
+	}
+
+	return shows, nil
+}
+
+func (c *memoryShowCounter) AddShows(ctx context.Context, userID string, bannerIDs []int) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	day := c.rotate(time.Now())
+
+	for _, bannerID := range bannerIDs {
+		c.counts[showsKey(day, userID, bannerID)]++
+	}
+
+	return nil
+}
+
+// rotate drops the counters of the previous day, it should be called with the lock held.
+func (c *memoryShowCounter) rotate(now time.Time) time.Time {
+	day := showsDay(now)
+	if !day.Equal(c.day) {
+		c.day = day
+		c.counts = make(map[string]int64)
+	}
+
+	return day
+}
+
+// readShows reads how many times the user has been shown the banners with frequency caps today.
+// It returns nil if the caps are not applied: to the admins, to the requests without a user id,
+// and when the counters are unavailable, so the users keep getting the banners.
+func (r *bannerGetter) readShows(ctx context.Context, isAdmin bool, userID string, banners []domain.UserBanner) map[int]int64 {
+	if isAdmin || userID == "" {
+		return nil
+	}
+
+	var bannerIDs []int
+	for _, banner := range banners {
+		bannerIDs = appendCapped(bannerIDs, banner)
+	}
+
+	if len(bannerIDs) == 0 {
+		return map[int]int64{}
+	}
+
+	shows, err := r.counter.Shows(ctx, userID, bannerIDs)
+	if err != nil {
+		logger.Logger().Errorln("repository.readShows: not applying the frequency caps because of", err.Error())
+		return nil
+	}
+
+	return shows
+}
+
+// appendCapped appends the banners with frequency caps among the banner, its fallbacks and their variants.
+func appendCapped(bannerIDs []int, banner domain.UserBanner) []int {
+	if banner.FrequencyCap > 0 && !slices.Contains(bannerIDs, banner.BannerID) {
+		bannerIDs = append(bannerIDs, banner.BannerID)
+	}
+
+	for _, variant := range banner.Variants {
+		if variant.FrequencyCap > 0 && !slices.Contains(bannerIDs, variant.BannerID) {
+			bannerIDs = append(bannerIDs, variant.BannerID)
+		}
+	}
+
+	for _, fallback := range banner.Fallbacks {
+		bannerIDs = appendCapped(bannerIDs, fallback)
+	}
+
+	return bannerIDs
+}
+
+func reachedCap(banner domain.UserBanner, shows map[int]int64) bool {
+	return shows != nil && banner.FrequencyCap > 0 && shows[banner.BannerID] >= int64(banner.FrequencyCap)
+}
+
+// addShows counts the banners served to the user which have frequency caps. The counters are read and incremented separately,
+// so the concurrent requests of the same user may exceed the cap slightly.
+func (r *bannerGetter) addShows(ctx context.Context, shows map[int]int64, userID string, banners []domain.UserBanner) {
+	if shows == nil {
+		return
+	}
+
+	bannerIDs := make([]int, 0, len(banners))
+	for _, banner := range banners {
+		if banner.FrequencyCap > 0 {
+			bannerIDs = append(bannerIDs, banner.BannerID)
+		}
+	}
+
+	if len(bannerIDs) == 0 {
+		return
+	}
+
+	if err := r.counter.AddShows(ctx, userID, bannerIDs); err != nil {
+		logger.Logger().Errorln("repository.addShows:", err.Error())
+	}
+}
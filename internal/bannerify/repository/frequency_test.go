@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/breaker"
+)
+
+func TestGetCappedBannerFromCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(100, 0)
+	r := newTestGetter(c, breaker.New(1, time.Minute))
+
+	banner := resolvedBanner{
+		tagID:        1,
+		bannerID:     1,
+		versionID:    1,
+		content:      "{\"title\": \"capped\"}",
+		frequencyCap: 2,
+		fallbacks:    []resolvedBanner{{bannerID: 2, versionID: 2, content: "{\"title\": \"fallback\"}", featureFallback: true}},
+	}.userBanner()
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2})
+	require.NoError(t, err)
+
+	r.storeBanners(ctx, []int{1}, false, "", map[int]domain.UserBanner{2: banner}, generations, "%w")
+	r.storeBanners(ctx, []int{1}, true, "", map[int]domain.UserBanner{2: banner}, generations, "%w")
+
+	user := domain.UserContext{UserID: "user"}
+	for range 2 {
+		res, err := r.GetBanner(ctx, []int{1}, 2, false, false, user)
+		require.NoError(t, err)
+		require.Equal(t, 1, res.BannerID)
+		require.True(t, res.Capped)
+	}
+
+	res, err := r.GetBanner(ctx, []int{1}, 2, false, false, user)
+	require.NoError(t, err)
+	require.Equal(t, 2, res.BannerID)
+	require.True(t, res.FeatureFallback)
+	require.True(t, res.Capped)
+
+	res, err = r.GetBanner(ctx, []int{1}, 2, true, false, user)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.BannerID)
+	require.False(t, res.Capped)
+
+	res, err = r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{})
+	require.NoError(t, err)
+	require.Equal(t, 1, res.BannerID)
+
+	banners, err := r.GetBanners(ctx, []int{1}, []int{2}, false, false, domain.UserContext{UserID: "other"})
+	require.NoError(t, err)
+	require.Equal(t, 1, banners[2].BannerID)
+
+	shows, err := r.counter.Shows(ctx, "user", []int{1, 2})
+	require.NoError(t, err)
+	require.Equal(t, map[int]int64{1: 2, 2: 0}, shows)
+}
+
+func TestGetCappedBannerWithoutFallback(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(100, 0)
+	r := newTestGetter(c, breaker.New(1, time.Minute))
+
+	banner := resolvedBanner{tagID: 1, bannerID: 1, versionID: 1, content: "{\"title\": \"capped\"}", frequencyCap: 1}.userBanner()
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2})
+	require.NoError(t, err)
+
+	r.storeBanners(ctx, []int{1}, false, "", map[int]domain.UserBanner{2: banner}, generations, "%w")
+
+	user := domain.UserContext{UserID: "user"}
+	_, err = r.GetBanner(ctx, []int{1}, 2, false, false, user)
+	require.NoError(t, err)
+
+	_, err = r.GetBanner(ctx, []int{1}, 2, false, false, user)
+	require.ErrorIs(t, err, appErrors.ErrBannerNotFound)
+
+	banners, err := r.GetBanners(ctx, []int{1}, []int{2}, false, false, user)
+	require.NoError(t, err)
+	require.Empty(t, banners)
+}
+
+func TestPeekCappedBanner(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(100, 0)
+	r := newTestGetter(c, breaker.New(1, time.Minute))
+
+	banner := resolvedBanner{tagID: 1, bannerID: 1, versionID: 1, content: "{\"title\": \"capped\"}", frequencyCap: 1}.userBanner()
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2})
+	require.NoError(t, err)
+
+	r.storeBanners(ctx, []int{1}, false, "", map[int]domain.UserBanner{2: banner}, generations, "%w")
+
+	user := domain.UserContext{UserID: "user"}
+	for range 3 {
+		banners, err := r.PeekBanners(ctx, []int{1}, []int{2}, false, false, user)
+		require.NoError(t, err)
+		require.Equal(t, 1, banners[2].BannerID)
+	}
+
+	shows, err := r.counter.Shows(ctx, "user", []int{1})
+	require.NoError(t, err)
+	require.Equal(t, map[int]int64{1: 0}, shows)
+
+	res, err := r.GetBanner(ctx, []int{1}, 2, false, false, user)
+	require.NoError(t, err)
+	require.Equal(t, 1, res.BannerID)
+
+	banners, err := r.PeekBanners(ctx, []int{1}, []int{2}, false, false, user)
+	require.NoError(t, err)
+	require.Empty(t, banners)
+}
+
+func TestMemoryShowCounterRotate(t *testing.T) {
+	c := NewMemoryShowCounter()
+	require.NoError(t, c.AddShows(context.Background(), "user", []int{1, 1}))
+
+	shows, err := c.Shows(context.Background(), "user", []int{1})
+	require.NoError(t, err)
+	require.Equal(t, int64(2), shows[1])
+
+	c.mu.Lock()
+	c.rotate(time.Now().Add(time.Hour * 24))
+	c.mu.Unlock()
+
+	require.Empty(t, c.counts)
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/locale"
+)
+
+// negotiateLocale returns the locale the banner has to be loaded in for the user,
+// empty string means the banner is already in the best of its locales.
+func negotiateLocale(banner domain.UserBanner, preferences []string) string {
+	if len(banner.Locales) == 0 || len(preferences) == 0 {
+		return ""
+	}
+
+	available := banner.Locales
+	if banner.Locale != "" {
+		available = append([]string{banner.Locale}, banner.Locales...)
+	}
+
+	userLocale := locale.Negotiate(preferences, available)
+	if userLocale == banner.Locale {
+		return ""
+	}
+
+	return userLocale
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/breaker"
+)
+
+func TestGetLocalizedBannerFromCache(t *testing.T) {
+	ctx := context.Background()
+	r := newTestGetter(NewMemoryCache(100, 0), breaker.New(1, time.Minute))
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2})
+	require.NoError(t, err)
+
+	banner := resolvedBanner{versionID: 3, content: "{\"title\": \"hello\"}", locale: "en", locales: []string{"de"}}.userBanner()
+	r.storeBanners(ctx, []int{1}, false, "", map[int]domain.UserBanner{2: banner}, generations, "%w")
+
+	localized := resolvedBanner{versionID: 3, content: "{\"title\": \"hallo\"}", locale: "de", locales: []string{"de"}}.userBanner()
+	r.storeBanners(ctx, []int{1}, false, "de", map[int]domain.UserBanner{2: localized}, generations, "%w")
+
+	testTable := []struct {
+		locales  []string
+		expected string
+	}{
+		{nil, "en"},
+		{[]string{"fr"}, "en"},
+		{[]string{"en-GB", "de"}, "en"},
+		{[]string{"de-AT"}, "de"},
+		{[]string{"fr", "de"}, "de"},
+	}
+
+	for _, testCase := range testTable {
+		res, err := r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{Locales: testCase.locales})
+		require.NoError(t, err)
+		require.Equal(t, testCase.expected, res.Locale)
+	}
+
+	banners, err := r.GetBanners(ctx, []int{1}, []int{2}, false, false, domain.UserContext{Locales: []string{"de"}})
+	require.NoError(t, err)
+	require.Equal(t, "de", banners[2].Locale)
+	require.JSONEq(t, "{\"title\": \"hallo\"}", string(banners[2].Content))
+}
+
+func TestGetLocalizedBannerFallsBackToDefault(t *testing.T) {
+	ctx := context.Background()
+	r := newTestGetter(NewMemoryCache(100, 0), breaker.New(1, time.Minute))
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2})
+	require.NoError(t, err)
+
+	banner := resolvedBanner{versionID: 3, content: "{}", locale: "en", locales: []string{"de"}}.userBanner()
+	r.storeBanners(ctx, []int{1}, false, "", map[int]domain.UserBanner{2: banner}, generations, "%w")
+
+	r.breaker.Failure()
+
+	res, err := r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{Locales: []string{"de"}})
+	require.NoError(t, err)
+	require.Equal(t, "en", res.Locale)
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/lru"
+)
+
+var (
+	_ domain.BannerCache = (*memoryCache)(nil)
+)
+
+// memoryCache keeps the banners in the memory of the process. The invalidations are not shared with other instances,
+// so it is suitable only for a single instance of the service and for tests.
+type memoryCache struct {
+	mu          sync.Mutex
+	values      *lru.Cache[string, string]
+	maxEntries  int
+	generations map[domain.FeatureTag]uint64
+	deps        map[domain.FeatureTag]map[string]struct{}
+	depsSize    int
+	subscribers map[int]func(keys []string)
+	nextID      int
+}
+
+func NewMemoryCache(maxEntries int, maxBytes int64) *memoryCache {
+	return &memoryCache{
+		values:      lru.New[string, string](maxEntries, maxBytes, time.Duration(math.MaxInt64)),
+		maxEntries:  maxEntries,
+		generations: make(map[domain.FeatureTag]uint64),
+		deps:        make(map[domain.FeatureTag]map[string]struct{}),
+		subscribers: make(map[int]func(keys []string)),
+	}
+}
+
+func (c *memoryCache) Get(ctx context.Context, key string) (string, error) {
+	value, ok := c.values.Get(key)
+	if !ok {
+		return "", appErrors.ErrNotFoundInCache
+	}
+
+	return value, nil
+}
+
+func (c *memoryCache) GetMany(ctx context.Context, keys []string) (map[string]string, error) {
+	res := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if value, ok := c.values.Get(key); ok {
+			res[key] = value
+		}
+	}
+
+	return res, nil
+}
+
+func (c *memoryCache) GetStale(ctx context.Context, keys []string) (map[string]string, error) {
+	res := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if value, ok := c.values.Get(staleKey(key)); ok {
+			res[key] = value
+		}
+	}
+
+	return res, nil
+}
+
+func (c *memoryCache) Generations(ctx context.Context, pairs []domain.FeatureTag) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	generations := make([]string, 0, len(pairs))
+	for _, pair := range pairs {
+		generations = append(generations, c.generation(pair))
+	}
+
+	return generations, nil
+}
+
+// generation is empty for the pairs which were never invalidated, the same as in Redis.
+func (c *memoryCache) generation(pair domain.FeatureTag) string {
+	generation, ok := c.generations[pair]
+	if !ok {
+		return ""
+	}
+
+	return strconv.FormatUint(generation, 10)
+}
+
+// SetTracked stores the items under the same lock which Invalidate takes, so an item is either rejected
+// because of a changed generation or removed by the invalidation.
+func (c *memoryCache) SetTracked(ctx context.Context, items map[string]domain.CacheItem) (map[string]bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stored := make(map[string]bool, len(items))
+	for key, item := range items {
+		stored[key] = c.setTracked(key, item)
+	}
+
+	if c.maxEntries > 0 && c.depsSize > c.maxEntries*2 {
+		c.pruneDeps()
+	}
+
+	return stored, nil
+}
+
+func (c *memoryCache) setTracked(key string, item domain.CacheItem) bool {
+	for i, pair := range item.Pairs {
+		if i >= len(item.Generations) || c.generation(pair) != item.Generations[i] {
+			return false
+		}
+	}
+
+	c.values.Set(key, item.Value, int64(len(key)+len(item.Value)), item.TTL)
+	if item.StaleTTL > 0 {
+		c.values.Set(staleKey(key), item.Value, int64(len(key)+len(item.Value)), item.StaleTTL)
+	}
+
+	for _, pair := range item.Pairs {
+		keys, ok := c.deps[pair]
+		if !ok {
+			keys = make(map[string]struct{})
+			c.deps[pair] = keys
+		}
+
+		if _, ok = keys[key]; !ok {
+			keys[key] = struct{}{}
+			c.depsSize++
+		}
+	}
+
+	return true
+}
+
+// pruneDeps forgets the keys which were evicted or have expired, so the dependencies do not grow without bound.
+func (c *memoryCache) pruneDeps() {
+	for pair, keys := range c.deps {
+		for key := range keys {
+			if !c.values.Contains(key) && !c.values.Contains(staleKey(key)) {
+				delete(keys, key)
+				c.depsSize--
+			}
+		}
+
+		if len(keys) == 0 {
+			delete(c.deps, pair)
+		}
+	}
+}
+
+func (c *memoryCache) Invalidate(ctx context.Context, pairs []domain.FeatureTag) error {
+	c.mu.Lock()
+
+	var keys []string
+	for _, pair := range pairs {
+		c.generations[pair]++
+		for key := range c.deps[pair] {
+			keys = append(keys, key)
+			c.values.Delete(key)
+			c.values.Delete(staleKey(key))
+		}
+
+		c.depsSize -= len(c.deps[pair])
+		delete(c.deps, pair)
+	}
+
+	handlers := make([]func(keys []string), 0, len(c.subscribers))
+	for _, handle := range c.subscribers {
+		handlers = append(handlers, handle)
+	}
+
+	c.mu.Unlock()
+
+	if len(keys) == 0 {
+		return nil
+	}
+
+	for _, handle := range handlers {
+		handle(keys)
+	}
+
+	return nil
+}
+
+// SubscribeInvalidations calls handle with the invalidated keys until ctx is done.
+func (c *memoryCache) SubscribeInvalidations(ctx context.Context, handle func(keys []string)) error {
+	c.mu.Lock()
+	id := c.nextID
+	c.nextID++
+	c.subscribers[id] = handle
+	c.mu.Unlock()
+
+	<-ctx.Done()
+
+	c.mu.Lock()
+	delete(c.subscribers, id)
+	c.mu.Unlock()
+
+	return nil
+}
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+func TestMemoryCache(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(100, 0)
+	pair := domain.FeatureTag{FeatureID: 1, TagID: 1}
+
+	_, err := c.Get(ctx, "a")
+	require.ErrorIs(t, err, appErrors.ErrNotFoundInCache)
+
+	generations, err := c.Generations(ctx, []domain.FeatureTag{pair})
+	require.NoError(t, err)
+
+	stored, err := c.SetTracked(ctx, map[string]domain.CacheItem{
+		"a": {Value: "1", TTL: time.Minute, StaleTTL: time.Hour, Pairs: []domain.FeatureTag{pair}, Generations: generations},
+	})
+	require.NoError(t, err)
+	require.True(t, stored["a"])
+
+	value, err := c.Get(ctx, "a")
+	require.NoError(t, err)
+	require.Equal(t, "1", value)
+
+	values, err := c.GetMany(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"a": "1"}, values)
+
+	values, err = c.GetStale(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"a": "1"}, values)
+
+	require.NoError(t, c.Invalidate(ctx, []domain.FeatureTag{pair}))
+
+	_, err = c.Get(ctx, "a")
+	require.ErrorIs(t, err, appErrors.ErrNotFoundInCache)
+
+	values, err = c.GetStale(ctx, []string{"a"})
+	require.NoError(t, err)
+	require.Empty(t, values)
+
+	stored, err = c.SetTracked(ctx, map[string]domain.CacheItem{
+		"a": {Value: "2", TTL: time.Minute, Pairs: []domain.FeatureTag{pair}, Generations: generations},
+	})
+	require.NoError(t, err)
+	require.False(t, stored["a"])
+}
+
+func TestMemoryCacheSubscribeInvalidations(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := NewMemoryCache(100, 0)
+	pair := domain.FeatureTag{FeatureID: 1, TagID: 1}
+
+	invalidated := make(chan []string, 1)
+	done := make(chan error)
+	go func() {
+		done <- c.SubscribeInvalidations(ctx, func(keys []string) {
+			invalidated <- keys
+		})
+	}()
+
+	require.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return len(c.subscribers) == 1
+	}, time.Second, time.Millisecond)
+
+	_, err := c.SetTracked(ctx, map[string]domain.CacheItem{
+		"a": {Value: "1", TTL: time.Minute, Pairs: []domain.FeatureTag{pair}, Generations: []string{""}},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, c.Invalidate(ctx, []domain.FeatureTag{pair}))
+	require.Equal(t, []string{"a"}, <-invalidated)
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestMemoryCachePruneDeps(t *testing.T) {
+	ctx := context.Background()
+	c := NewMemoryCache(1, 0)
+
+	for _, key := range []string{"a", "b", "c", "d"} {
+		_, err := c.SetTracked(ctx, map[string]domain.CacheItem{
+			key: {Value: key, TTL: time.Minute, Pairs: []domain.FeatureTag{{FeatureID: 1, TagID: 1}}, Generations: []string{""}},
+		})
+		require.NoError(t, err)
+	}
+
+	require.LessOrEqual(t, c.depsSize, 2)
+}
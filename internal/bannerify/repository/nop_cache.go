@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+var (
+	_ domain.BannerCache = (*nopCache)(nil)
+)
+
+// nopCache disables caching, every banner is loaded from the database.
+type nopCache struct{}
+
+func NewNopCache() *nopCache {
+	return &nopCache{}
+}
+
+func (c *nopCache) Get(ctx context.Context, key string) (string, error) {
+	return "", appErrors.ErrNotFoundInCache
+}
+
+func (c *nopCache) GetMany(ctx context.Context, keys []string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (c *nopCache) GetStale(ctx context.Context, keys []string) (map[string]string, error) {
+	return map[string]string{}, nil
+}
+
+func (c *nopCache) Generations(ctx context.Context, pairs []domain.FeatureTag) ([]string, error) {
+	return make([]string, len(pairs)), nil
+}
+
+// SetTracked reports that nothing was stored, so the banners are not kept in the memory of the getter either.
+func (c *nopCache) SetTracked(ctx context.Context, items map[string]domain.CacheItem) (map[string]bool, error) {
+	stored := make(map[string]bool, len(items))
+	for key := range items {
+		stored[key] = false
+	}
+
+	return stored, nil
+}
+
+func (c *nopCache) Invalidate(ctx context.Context, pairs []domain.FeatureTag) error {
+	return nil
+}
+
+func (c *nopCache) SubscribeInvalidations(ctx context.Context, handle func(keys []string)) error {
+	<-ctx.Done()
+
+	return nil
+}
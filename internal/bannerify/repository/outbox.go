@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+)
+
+const (
+	// outboxBatchSize is the amount of the events published at once.
+	outboxBatchSize = 100
+
+	// outboxLockKey is the advisory lock held by the instance relaying the events, so they are published in order.
+	outboxLockKey = 0x6f7574626f78
+)
+
+// writeOutbox adds the events to the outbox in tx, so they are published if and only if the changes are committed.
+func writeOutbox(ctx context.Context, tx pgx.Tx, eventType string, payloads ...domain.ChangePayload) error {
+	if len(payloads) == 0 {
+		return nil
+	}
+
+	encoded := make([]string, 0, len(payloads))
+	for _, payload := range payloads {
+		payloadBytes, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+
+		encoded = append(encoded, string(payloadBytes))
+	}
+
+	_, err := tx.Exec(ctx, "INSERT INTO outbox (event_type, payload) SELECT $1, p FROM unnest($2::JSONB[]) WITH ORDINALITY AS e(p, n) ORDER BY e.n", eventType, encoded)
+	return err
+}
+
+// outboxRelay publishes the events written to the outbox by any instance to the sink.
+type outboxRelay struct {
+	db           *postgres
+	sink         domain.OutboxSink
+	pollInterval time.Duration
+	retention    time.Duration
+}
+
+func NewOutboxRelay(pg *postgres, sink domain.OutboxSink, pollInterval time.Duration, retention time.Duration) *outboxRelay {
+	return &outboxRelay{db: pg, sink: sink, pollInterval: pollInterval, retention: retention}
+}
+
+// RelayOutbox publishes the events until ctx is done, the sent ones are deleted after the retention.
+// An event is marked as sent in the transaction it was read in, so it is published again if the commit fails.
+func (r *outboxRelay) RelayOutbox(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	var lastPrune time.Time
+	for {
+		published, err := r.relay(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Logger().Errorln("repository.RelayOutbox:", err.Error())
+		}
+
+		if time.Since(lastPrune) >= pruneInterval && ctx.Err() == nil {
+			if err = r.prune(ctx); err != nil && ctx.Err() == nil {
+				logger.Logger().Errorln("repository.RelayOutbox:", err.Error())
+			}
+
+			lastPrune = time.Now()
+		}
+
+		// a full batch means more events are waiting already
+		if published == outboxBatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *outboxRelay) relay(ctx context.Context) (int, error) {
+	var events []domain.OutboxEvent
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		var locked bool
+		err := tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock($1)", outboxLockKey).Scan(&locked)
+		if err != nil || !locked {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, "SELECT event_id, event_type, payload, created_at FROM outbox WHERE sent_at IS NULL ORDER BY event_id LIMIT $1", outboxBatchSize)
+		if err != nil {
+			return err
+		}
+
+		events, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.OutboxEvent, error) {
+			var event domain.OutboxEvent
+			var payload []byte
+			var createdAt time.Time
+			err := row.Scan(&event.ID, &event.Type, &payload, &createdAt)
+
+			event.Payload = json.RawMessage(payload)
+			event.CreatedAt = createdAt.Format(time.RFC3339Nano)
+			return event, err
+		})
+		if err != nil || len(events) == 0 {
+			return err
+		}
+
+		if err = r.sink.Publish(ctx, events); err != nil {
+			return err
+		}
+
+		// the ids are listed, since an event with a lower id may be committed after the batch is read
+		eventIDs := make([]int64, 0, len(events))
+		for _, event := range events {
+			eventIDs = append(eventIDs, event.ID)
+		}
+
+		_, err = tx.Exec(ctx, "UPDATE outbox SET sent_at = now() WHERE event_id = ANY($1)", eventIDs)
+		return err
+	})
+
+	if err != nil {
+		return 0, err
+	}
+
+	return len(events), nil
+}
+
+func (r *outboxRelay) prune(ctx context.Context) error {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	_, err = conn.Exec(ctx, "DELETE FROM outbox WHERE sent_at < $1", time.Now().Add(-r.retention))
+	return err
+}
+
+var (
+	_ domain.OutboxSink = (*logSink)(nil)
+	_ domain.OutboxSink = (*httpSink)(nil)
+)
+
+// logSink writes the events to the log, it is enough to see the changes without a consumer.
+type logSink struct{}
+
+func NewLogSink() *logSink {
+	return &logSink{}
+}
+
+func (s *logSink) Publish(ctx context.Context, events []domain.OutboxEvent) error {
+	for _, event := range events {
+		logger.Logger().Infoln("Outbox event", event.ID, event.Type, string(event.Payload))
+	}
+
+	return nil
+}
+
+// httpSink posts the events as a JSON array, the batch is published again unless the response is 2xx.
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+func NewHTTPSink(url string, timeout time.Duration) *httpSink {
+	return &httpSink{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (s *httpSink) Publish(ctx context.Context, events []domain.OutboxEvent) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDeliveryErrorLength))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("outbox sink responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+func TestHTTPSinkPublish(t *testing.T) {
+	bodies := make(chan []byte, 1)
+	status := http.StatusOK
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		bodies <- body
+
+		w.WriteHeader(status)
+	}))
+	defer stub.Close()
+
+	payload, err := json.Marshal(domain.ChangePayload{BannerID: 1, VersionID: 2, Affected: []domain.FeatureTag{{FeatureID: 3, TagID: 4}}})
+	require.NoError(t, err)
+
+	events := []domain.OutboxEvent{
+		{ID: 1, Type: domain.OutboxBannerCreated, Payload: payload, CreatedAt: "2024-04-14T00:00:00Z"},
+		{ID: 2, Type: domain.OutboxFeatureSchemaDeleted, Payload: json.RawMessage(`{"feature_id":3}`), CreatedAt: "2024-04-14T00:00:01Z"},
+	}
+
+	sink := NewHTTPSink(stub.URL, time.Second)
+	require.NoError(t, sink.Publish(context.Background(), events))
+
+	var published []domain.OutboxEvent
+	require.NoError(t, json.Unmarshal(<-bodies, &published))
+	require.Len(t, published, 2)
+	require.Equal(t, int64(1), published[0].ID)
+	require.Equal(t, domain.OutboxBannerCreated, published[0].Type)
+	require.JSONEq(t, `{"banner_id":1,"version_id":2,"affected":[{"feature_id":3,"tag_id":4}]}`, string(published[0].Payload))
+	require.Equal(t, domain.OutboxFeatureSchemaDeleted, published[1].Type)
+
+	status = http.StatusServiceUnavailable
+	require.Error(t, sink.Publish(context.Background(), events))
+	<-bodies
+}
+
+func TestLogSinkPublish(t *testing.T) {
+	require.NoError(t, NewLogSink().Publish(context.Background(), []domain.OutboxEvent{{ID: 1, Type: domain.OutboxTagPrioritySet, Payload: json.RawMessage(`{"tag_id":1,"priority":2}`)}}))
+}
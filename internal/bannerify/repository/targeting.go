@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+	"github.com/PoorMercymain/bannerify/pkg/targeting"
+)
+
+// personalize chooses the banner served to the user, it reports false if none of the banners matches the user.
+// The banners the user has already been shown as many times today as their frequency caps allow are skipped,
+// shows is nil if the caps are not applied to the request, see readShows.
+func (r *bannerGetter) personalize(banner domain.UserBanner, user domain.UserContext, shows map[int]int64) (domain.UserBanner, bool) {
+	var capped bool
+	for _, candidate := range r.chooseTargeted(banner, user.Attributes) {
+		candidate = chooseVariant(candidate, user.UserID)
+		if reachedCap(candidate, shows) {
+			capped = true
+			continue
+		}
+
+		candidate.Capped = capped || (shows != nil && candidate.FrequencyCap > 0)
+		return candidate, true
+	}
+
+	return domain.UserBanner{}, false
+}
+
+// chooseTargeted returns the banner and its fallbacks whose targeting rules match the user attributes, in order.
+// The rules are kept in the cached banner and matched per request, so the cache key does not depend on the attributes.
+// The served banner is Targeted if any rule or cap was involved in choosing it.
+func (r *bannerGetter) chooseTargeted(banner domain.UserBanner, attributes map[string]string) []domain.UserBanner {
+	fallbacks := banner.Fallbacks
+	banner.Fallbacks = nil
+	banner.Targeted = banner.Targeting != "" || banner.FrequencyCap > 0 || len(fallbacks) > 0
+
+	matched := make([]domain.UserBanner, 0, 1+len(fallbacks))
+	if r.matches(banner.Targeting, attributes) {
+		matched = append(matched, banner)
+	}
+
+	for _, fallback := range fallbacks {
+		if r.matches(fallback.Targeting, attributes) {
+			fallback.Fallbacks = nil
+			fallback.ExpiresAt = banner.ExpiresAt
+			fallback.Stale = banner.Stale
+			fallback.Targeted = true
+			matched = append(matched, fallback)
+		}
+	}
+
+	return matched
+}
+
+// matches parses every rule once, the rules are validated when the banners are saved,
+// so a rule which is unable to be parsed matches nobody.
+func (r *bannerGetter) matches(expr string, attributes map[string]string) bool {
+	if expr == "" {
+		return true
+	}
+
+	if rule, ok := r.rules.Load(expr); ok {
+		return rule.(*targeting.Rule).Match(attributes)
+	}
+
+	rule, err := targeting.Parse(expr)
+	if err != nil {
+		logger.Logger().Errorln("repository.matches:", err.Error())
+		return false
+	}
+
+	r.rules.Store(expr, rule)
+
+	return rule.Match(attributes)
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/breaker"
+)
+
+func TestGetTargetedBannerFromCache(t *testing.T) {
+	ctx := context.Background()
+	r := newTestGetter(NewMemoryCache(100, 0), breaker.New(1, time.Minute))
+
+	banner := resolvedBanner{
+		tagID:     1,
+		versionID: 1,
+		content:   "{\"title\": \"ios\"}",
+		targeting: "platform == \"ios\" && app_version >= 2.0",
+		fallbacks: []resolvedBanner{
+			{tagID: 2, versionID: 2, content: "{\"title\": \"de\"}", targeting: "country == \"DE\""},
+			{tagID: 3, versionID: 3, content: "{\"title\": \"default\"}"},
+		},
+	}.userBanner()
+
+	generations, err := r.readGenerations(ctx, []int{1, 2, 3}, []int{4})
+	require.NoError(t, err)
+
+	r.storeBanners(ctx, []int{1, 2, 3}, false, "", map[int]domain.UserBanner{4: banner}, generations, "%w")
+
+	testTable := []struct {
+		attributes map[string]string
+		versionID  int
+	}{
+		{map[string]string{"platform": "ios", "app_version": "2.1"}, 1},
+		{map[string]string{"platform": "ios", "app_version": "1.9", "country": "DE"}, 2},
+		{map[string]string{"platform": "android"}, 3},
+		{nil, 3},
+	}
+
+	for _, testCase := range testTable {
+		res, err := r.GetBanner(ctx, []int{3, 2, 1}, 4, false, false, domain.UserContext{Attributes: testCase.attributes})
+		require.NoError(t, err)
+		require.Equal(t, testCase.versionID, res.VersionID)
+		require.True(t, res.Targeted)
+		require.Nil(t, res.Fallbacks)
+	}
+
+	banners, err := r.GetBanners(ctx, []int{1, 2, 3}, []int{4}, false, false, domain.UserContext{Attributes: map[string]string{"country": "de"}})
+	require.NoError(t, err)
+	require.Equal(t, 2, banners[4].VersionID)
+}
+
+func TestGetTargetedBannerNotMatched(t *testing.T) {
+	ctx := context.Background()
+	r := newTestGetter(NewMemoryCache(100, 0), breaker.New(1, time.Minute))
+
+	banner := resolvedBanner{tagID: 1, versionID: 1, content: "{}", targeting: "platform == \"ios\""}.userBanner()
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2})
+	require.NoError(t, err)
+
+	r.storeBanners(ctx, []int{1}, false, "", map[int]domain.UserBanner{2: banner}, generations, "%w")
+
+	_, err = r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{Attributes: map[string]string{"platform": "android"}})
+	require.ErrorIs(t, err, appErrors.ErrBannerNotFound)
+
+	banners, err := r.GetBanners(ctx, []int{1}, []int{2}, false, false, domain.UserContext{})
+	require.NoError(t, err)
+	require.Empty(t, banners)
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/breaker"
+)
+
+func TestGetTemplateBannerFromCache(t *testing.T) {
+	ctx := context.Background()
+	r := newTestGetter(NewMemoryCache(100, 0), breaker.New(1, time.Minute))
+
+	content := "{\"title\": \"Hi {{user_name}}\", \"text\": \"" + strings.Repeat("a", 2048) + "\"}"
+	banner := resolvedBanner{
+		versionID:        1,
+		content:          content,
+		template:         true,
+		missingVariables: "keep",
+		experimentID:     7,
+		variants:         []resolvedVariant{{key: "only", weight: 1, versionID: 2, content: "{\"title\": \"{{user_name}}\"}", template: true, missingVariables: "error"}},
+	}.userBanner()
+	require.Nil(t, banner.Encoded)
+
+	generations, err := r.readGenerations(ctx, []int{1}, []int{2})
+	require.NoError(t, err)
+
+	r.storeBanners(ctx, []int{1}, false, "", map[int]domain.UserBanner{2: banner}, generations, "%w")
+
+	res, err := r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{Attributes: map[string]string{"user_name": "Bob"}})
+	require.NoError(t, err)
+	require.True(t, res.Template)
+	require.Equal(t, "keep", res.MissingVariables)
+	require.JSONEq(t, content, string(res.Content))
+
+	res, err = r.GetBanner(ctx, []int{1}, 2, false, false, domain.UserContext{UserID: "user"})
+	require.NoError(t, err)
+	require.Equal(t, "only", res.Variant)
+	require.True(t, res.Template)
+	require.Equal(t, "error", res.MissingVariables)
+}
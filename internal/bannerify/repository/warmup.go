@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+const warmUpBatchSize = 500
+
+// WarmUp caches the banners of all the pairs currently visible to users, as they are requested with a single tag.
+// The pairs with running experiments are skipped, they are cached with their variants on the first request.
+// So are the targeted and capped banners of the features with a fallback banner, which is cached with them on the first request.
+// It returns the amount of cached banners.
+func (r *bannerGetter) WarmUp(ctx context.Context) (int, error) {
+	const logErrPrefix = "repository.WarmUp: %w"
+
+	if !r.warmingUp.CompareAndSwap(false, true) {
+		return 0, fmt.Errorf(logErrPrefix, appErrors.ErrWarmUpInProgress)
+	}
+	defer r.warmingUp.Store(false)
+
+	pairs, err := r.visiblePairs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf(logErrPrefix, err)
+	}
+
+	var warmed int
+	for start := 0; start < len(pairs); start += warmUpBatchSize {
+		stored, err := r.warmUpBatch(ctx, pairs[start:min(start+warmUpBatchSize, len(pairs))], logErrPrefix)
+		if err != nil {
+			return warmed, fmt.Errorf(logErrPrefix, err)
+		}
+
+		warmed += stored
+	}
+
+	return warmed, nil
+}
+
+func (r *bannerGetter) visiblePairs(ctx context.Context) ([]domain.FeatureTag, error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, "SELECT cv.feature, cv.tag FROM chosen_versions cv JOIN banner_versions bv ON cv.version_id = bv.version_id WHERE bv.is_active = TRUE AND (bv.active_from IS NULL OR bv.active_from <= now()) AND (bv.active_until IS NULL OR bv.active_until > now()) AND NOT EXISTS (SELECT 1 FROM experiments e WHERE e.feature = cv.feature AND e.tag = cv.tag AND e.is_active = TRUE) AND ((bv.targeting IS NULL AND bv.frequency_cap IS NULL) OR NOT EXISTS (SELECT 1 FROM features f WHERE f.feature_id = cv.feature AND f.fallback_banner_id IS NOT NULL)) ORDER BY cv.feature, cv.tag")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pairs []domain.FeatureTag
+	for rows.Next() {
+		var pair domain.FeatureTag
+		if err = rows.Scan(&pair.FeatureID, &pair.TagID); err != nil {
+			return nil, err
+		}
+
+		pairs = append(pairs, pair)
+	}
+
+	return pairs, rows.Err()
+}
+
+// warmUpBatch reads the generations before the banners, so a banner changed during the warm-up is not cached, see cache.SetTracked.
+func (r *bannerGetter) warmUpBatch(ctx context.Context, pairs []domain.FeatureTag, logErrPrefix string) (int, error) {
+	trackedPairs := slices.Clone(pairs)
+	for i, pair := range pairs {
+		if i == 0 || pairs[i-1].FeatureID != pair.FeatureID {
+			trackedPairs = append(trackedPairs, fallbackPair(pair.FeatureID))
+		}
+	}
+
+	generations, err := r.cache.Generations(ctx, trackedPairs)
+	if err != nil {
+		return 0, err
+	}
+
+	pairGenerations := make(map[domain.FeatureTag]string, len(trackedPairs))
+	for i, pair := range trackedPairs {
+		pairGenerations[pair] = generations[i]
+	}
+
+	featureIDs := make([]int, 0, len(pairs))
+	tagIDs := make([]int, 0, len(pairs))
+	for _, pair := range pairs {
+		featureIDs = append(featureIDs, pair.FeatureID)
+		tagIDs = append(tagIDs, pair.TagID)
+	}
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, "SELECT cv.feature, cv.tag, cv.banner_id, bv.version_id, bv.data, COALESCE(bv.default_locale, ''), ARRAY(SELECT l.locale FROM banner_version_locales l WHERE l.version_id = bv.version_id ORDER BY l.locale), COALESCE(bv.targeting, ''), bv.is_template, bv.missing_variables, COALESCE(bv.frequency_cap, 0), CASE WHEN bv.active_until > now() THEN bv.active_until END FROM unnest($1::INT[], $2::INT[]) AS p(feature, tag) JOIN chosen_versions cv ON cv.feature = p.feature AND cv.tag = p.tag JOIN banner_versions bv ON cv.version_id = bv.version_id WHERE bv.is_active = TRUE AND (bv.active_from IS NULL OR bv.active_from <= now()) AND (bv.active_until IS NULL OR bv.active_until > now()) AND ((bv.targeting IS NULL AND bv.frequency_cap IS NULL) OR NOT EXISTS (SELECT 1 FROM features f WHERE f.feature_id = cv.feature AND f.fallback_banner_id IS NOT NULL))", featureIDs, tagIDs)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	items := make(map[string]domain.CacheItem, len(pairs))
+	for rows.Next() {
+		var pair domain.FeatureTag
+		var banner resolvedBanner
+		if err = rows.Scan(&pair.FeatureID, &pair.TagID, &banner.bannerID, &banner.versionID, &banner.content, &banner.locale, &banner.locales, &banner.targeting, &banner.template, &banner.missingVariables, &banner.frequencyCap, &banner.nextBoundary); err != nil {
+			return 0, err
+		}
+
+		banner.tagID = pair.TagID
+		item, ok := r.newCacheItem(banner.userBanner(), []domain.FeatureTag{pair, fallbackPair(pair.FeatureID)}, []string{pairGenerations[pair], pairGenerations[fallbackPair(pair.FeatureID)]}, logErrPrefix)
+		if !ok {
+			continue
+		}
+
+		items[bannerCacheKey([]int{pair.TagID}, pair.FeatureID, false, "")] = item
+	}
+
+	if err = rows.Err(); err != nil {
+		return 0, err
+	}
+
+	if len(items) == 0 {
+		return 0, nil
+	}
+
+	stored, err := r.cache.SetTracked(ctx, items)
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	for _, ok := range stored {
+		if ok {
+			count++
+		}
+	}
+
+	return count, nil
+}
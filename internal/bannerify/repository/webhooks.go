@@ -0,0 +1,431 @@
+package repository
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/logger"
+	"github.com/PoorMercymain/bannerify/pkg/signature"
+)
+
+const (
+	// deliveryBatchSize is the amount of the deliveries an instance sends at once.
+	deliveryBatchSize = 20
+
+	// maxDeliveryErrorLength is enough to tell why the delivery failed without keeping the whole response.
+	maxDeliveryErrorLength = 512
+)
+
+var (
+	_ domain.BannerRepositoryWebhookManager = (*webhookManager)(nil)
+)
+
+type webhookManager struct {
+	db *postgres
+}
+
+func NewWebhookManager(pg *postgres) *webhookManager {
+	return &webhookManager{db: pg}
+}
+
+func (r *webhookManager) CreateWebhook(ctx context.Context, webhook domain.Webhook) (int, error) {
+	const logErrPrefix = "repository.CreateWebhook: %w"
+
+	var webhookID int
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		err := tx.QueryRow(ctx, "INSERT INTO webhooks (url, secret, events, is_active) VALUES ($1, $2, $3, COALESCE($4, TRUE)) RETURNING webhook_id", *webhook.URL, *webhook.Secret, webhook.Events, webhook.IsActive).Scan(&webhookID)
+		if err != nil {
+			return err
+		}
+
+		return writeSnapshotAudit(ctx, tx, auditChange{action: domain.AuditWebhookCreate, targetID: &webhookID}, webhookSnapshotQuery, webhookID)
+	})
+
+	if err != nil {
+		return 0, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return webhookID, nil
+}
+
+func (r *webhookManager) ListWebhooks(ctx context.Context, limit int, offset int) ([]domain.WebhookListElement, error) {
+	const logErrPrefix = "repository.ListWebhooks: %w"
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer conn.Release()
+
+	rows, err := conn.Query(ctx, "SELECT webhook_id, url, events, is_active, created_at, updated_at FROM webhooks ORDER BY webhook_id LIMIT $1 OFFSET $2", limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer rows.Close()
+
+	var (
+		webhooks             []domain.WebhookListElement
+		curElem              domain.WebhookListElement
+		createdAt, updatedAt time.Time
+	)
+
+	for rows.Next() {
+		if err = rows.Scan(&curElem.WebhookID, &curElem.URL, &curElem.Events, &curElem.IsActive, &createdAt, &updatedAt); err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
+		}
+
+		curElem.CreatedAt = createdAt.Format(time.RFC3339)
+		curElem.UpdatedAt = updatedAt.Format(time.RFC3339)
+
+		webhooks = append(webhooks, curElem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return webhooks, nil
+}
+
+// UpdateWebhook does not change the deliveries already queued, they are sent to the new url with the new secret.
+func (r *webhookManager) UpdateWebhook(ctx context.Context, webhookID int, webhook domain.Webhook) error {
+	const logErrPrefix = "repository.UpdateWebhook: %w"
+
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, webhookSnapshotQuery, webhookID)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, "UPDATE webhooks SET url = COALESCE($1, url), secret = COALESCE($2, secret), events = COALESCE($3, events), is_active = COALESCE($4, is_active), updated_at = CURRENT_TIMESTAMP WHERE webhook_id = $5", webhook.URL, webhook.Secret, webhook.Events, webhook.IsActive, webhookID)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrWebhookNotFound
+		}
+
+		return writeSnapshotAudit(ctx, tx, auditChange{action: domain.AuditWebhookUpdate, targetID: &webhookID, before: before}, webhookSnapshotQuery, webhookID)
+	})
+
+	if err != nil {
+		return fmt.Errorf(logErrPrefix, err)
+	}
+
+	return nil
+}
+
+// DeleteWebhook deletes its deliveries too, including the ones which are not sent yet.
+func (r *webhookManager) DeleteWebhook(ctx context.Context, webhookID int) error {
+	const logErrPrefix = "repository.DeleteWebhook: %w"
+
+	err := r.db.WithTransaction(ctx, func(tx pgx.Tx) error {
+		before, err := snapshot(ctx, tx, webhookSnapshotQuery, webhookID)
+		if err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, "DELETE FROM webhooks WHERE webhook_id = $1", webhookID)
+		if err != nil {
+			return err
+		}
+
+		if tag.RowsAffected() == 0 {
+			return appErrors.ErrWebhookNotFound
+		}
+
+		return writeAudit(ctx, tx, auditChange{action: domain.AuditWebhookDelete, targetID: &webhookID, before: before})
+	})
+
+	if err != nil {
+		return fmt.Errorf(logErrPrefix, err)
+	}
+
+	return nil
+}
+
+func (r *webhookManager) ListDeliveries(ctx context.Context, webhookID int, status *string, limit int, offset int) ([]domain.WebhookDelivery, error) {
+	const logErrPrefix = "repository.ListDeliveries: %w"
+
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer conn.Release()
+
+	var webhookExists bool
+	err = conn.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM webhooks WHERE webhook_id = $1)", webhookID).Scan(&webhookExists)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	if !webhookExists {
+		return nil, fmt.Errorf(logErrPrefix, appErrors.ErrWebhookNotFound)
+	}
+
+	rows, err := conn.Query(ctx, "SELECT delivery_id, webhook_id, event, payload, status, attempts, next_attempt_at, last_status_code, last_error, created_at, delivered_at FROM webhook_deliveries WHERE webhook_id = $1 AND ($2::TEXT IS NULL OR status = $2::TEXT) ORDER BY delivery_id DESC LIMIT $3 OFFSET $4", webhookID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+	defer rows.Close()
+
+	var (
+		deliveries    []domain.WebhookDelivery
+		curElem       domain.WebhookDelivery
+		payload       []byte
+		nextAttemptAt time.Time
+		createdAt     time.Time
+		deliveredAt   *time.Time
+	)
+
+	for rows.Next() {
+		if err = rows.Scan(&curElem.DeliveryID, &curElem.WebhookID, &curElem.Event, &payload, &curElem.Status, &curElem.Attempts, &nextAttemptAt, &curElem.LastStatusCode, &curElem.LastError, &createdAt, &deliveredAt); err != nil {
+			return nil, fmt.Errorf(logErrPrefix, err)
+		}
+
+		curElem.Payload = json.RawMessage(payload)
+		curElem.NextAttemptAt = nil
+		if curElem.Status == domain.DeliveryPending {
+			curElem.NextAttemptAt = formatOptionalTime(&nextAttemptAt)
+		}
+
+		curElem.CreatedAt = createdAt.Format(time.RFC3339)
+		curElem.DeliveredAt = formatOptionalTime(deliveredAt)
+
+		deliveries = append(deliveries, curElem)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(logErrPrefix, err)
+	}
+
+	return deliveries, nil
+}
+
+// enqueueWebhooks queues the events for the active webhooks subscribed to them in tx,
+// so an event is delivered if and only if the change it describes is committed.
+func enqueueWebhooks(ctx context.Context, tx pgx.Tx, events []domain.WebhookEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(events))
+	payloads := make([]string, 0, len(events))
+	occurredAt := time.Now().UTC().Format(time.RFC3339)
+	for _, event := range events {
+		event.OccurredAt = occurredAt
+
+		payload, err := json.Marshal(event)
+		if err != nil {
+			return err
+		}
+
+		names = append(names, event.Event)
+		payloads = append(payloads, string(payload))
+	}
+
+	_, err := tx.Exec(ctx, "INSERT INTO webhook_deliveries (webhook_id, event, payload) SELECT w.webhook_id, e.event, e.payload FROM unnest($1::TEXT[], $2::JSONB[]) WITH ORDINALITY AS e(event, payload, n) JOIN webhooks w ON w.is_active = TRUE AND e.event = ANY(w.events) ORDER BY e.n, w.webhook_id", names, payloads)
+	return err
+}
+
+type pendingDelivery struct {
+	deliveryID int64
+	webhookID  int
+	event      string
+	payload    []byte
+	attempts   int
+	url        string
+	secret     string
+}
+
+// webhookDispatcher sends the queued deliveries, several instances may send them at once,
+// since a delivery is leased by the instance which claimed it.
+type webhookDispatcher struct {
+	db           *postgres
+	client       *http.Client
+	maxAttempts  int
+	backoffBase  time.Duration
+	backoffMax   time.Duration
+	pollInterval time.Duration
+}
+
+func NewWebhookDispatcher(pg *postgres, timeout time.Duration, maxAttempts int, backoffBase time.Duration, backoffMax time.Duration, pollInterval time.Duration) *webhookDispatcher {
+	return &webhookDispatcher{
+		db:           pg,
+		client:       &http.Client{Timeout: timeout},
+		maxAttempts:  maxAttempts,
+		backoffBase:  backoffBase,
+		backoffMax:   backoffMax,
+		pollInterval: pollInterval,
+	}
+}
+
+// DeliverWebhooks sends the deliveries which are due until ctx is done. A delivery interrupted by the shutdown
+// is sent again after its lease expires, so the receivers get every event at least once.
+func (r *webhookDispatcher) DeliverWebhooks(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		claimed, err := r.deliverBatch(ctx)
+		if err != nil && ctx.Err() == nil {
+			logger.Logger().Errorln("repository.DeliverWebhooks:", err.Error())
+		}
+
+		// a full batch means more deliveries may be due already
+		if claimed == deliveryBatchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *webhookDispatcher) deliverBatch(ctx context.Context) (int, error) {
+	deliveries, err := r.claim(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			statusCode, err := r.send(ctx, delivery)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err = r.record(ctx, delivery, statusCode, err); err != nil {
+				logger.Logger().Errorln("repository.DeliverWebhooks:", err.Error())
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return len(deliveries), nil
+}
+
+// claim leases the due deliveries for longer than a request may take, so no other instance sends them meanwhile.
+func (r *webhookDispatcher) claim(ctx context.Context) ([]pendingDelivery, error) {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	lease := 2*r.client.Timeout + r.pollInterval
+	rows, err := conn.Query(ctx, "WITH claimed AS (UPDATE webhook_deliveries SET next_attempt_at = now() + $1 * INTERVAL '1 millisecond' WHERE delivery_id IN (SELECT delivery_id FROM webhook_deliveries WHERE status = 'pending' AND next_attempt_at <= now() ORDER BY next_attempt_at LIMIT $2 FOR UPDATE SKIP LOCKED) RETURNING delivery_id, webhook_id, event, payload, attempts) SELECT c.delivery_id, c.webhook_id, c.event, c.payload, c.attempts, w.url, w.secret FROM claimed c JOIN webhooks w ON c.webhook_id = w.webhook_id ORDER BY c.delivery_id", lease.Milliseconds(), deliveryBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	deliveries := make([]pendingDelivery, 0, deliveryBatchSize)
+	for rows.Next() {
+		var delivery pendingDelivery
+		if err = rows.Scan(&delivery.deliveryID, &delivery.webhookID, &delivery.event, &delivery.payload, &delivery.attempts, &delivery.url, &delivery.secret); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, rows.Err()
+}
+
+// send posts the payload signed with the secret of the webhook, any status but 2xx is a failure.
+func (r *webhookDispatcher) send(ctx context.Context, delivery pendingDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.url, bytes.NewReader(delivery.payload))
+	if err != nil {
+		return 0, err
+	}
+
+	timestamp := time.Now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "bannerify-webhooks")
+	req.Header.Set("X-Bannerify-Event", delivery.event)
+	req.Header.Set("X-Bannerify-Delivery", strconv.FormatInt(delivery.deliveryID, 10))
+	req.Header.Set("X-Bannerify-Timestamp", strconv.FormatInt(timestamp, 10))
+	req.Header.Set("X-Bannerify-Signature", signature.Sign(delivery.secret, timestamp, delivery.payload))
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxDeliveryErrorLength))
+		return resp.StatusCode, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, body)
+	}
+
+	// the body is drained for the connection to be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxDeliveryErrorLength))
+
+	return resp.StatusCode, nil
+}
+
+// record marks the delivery as delivered, schedules the next attempt or gives up after the last one.
+func (r *webhookDispatcher) record(ctx context.Context, delivery pendingDelivery, statusCode int, sendErr error) error {
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	var lastStatusCode *int
+	if statusCode != 0 {
+		lastStatusCode = &statusCode
+	}
+
+	if sendErr == nil {
+		_, err = conn.Exec(ctx, "UPDATE webhook_deliveries SET status = 'delivered', attempts = attempts + 1, last_status_code = $1, last_error = NULL, delivered_at = now() WHERE delivery_id = $2", lastStatusCode, delivery.deliveryID)
+		return err
+	}
+
+	lastError := sendErr.Error()
+	if len(lastError) > maxDeliveryErrorLength {
+		lastError = lastError[:maxDeliveryErrorLength]
+	}
+
+	attempts := delivery.attempts + 1
+	status := domain.DeliveryPending
+	if attempts >= r.maxAttempts {
+		status = domain.DeliveryFailed
+	}
+
+	_, err = conn.Exec(ctx, "UPDATE webhook_deliveries SET status = $1, attempts = $2, next_attempt_at = now() + $3 * INTERVAL '1 millisecond', last_status_code = $4, last_error = $5 WHERE delivery_id = $6", status, attempts, deliveryBackoff(attempts, r.backoffBase, r.backoffMax).Milliseconds(), lastStatusCode, lastError, delivery.deliveryID)
+	return err
+}
+
+// deliveryBackoff doubles the pause after every failed attempt, starting with base, up to limit.
+func deliveryBackoff(attempts int, base time.Duration, limit time.Duration) time.Duration {
+	backoff := base
+	for i := 1; i < attempts && backoff < limit; i++ {
+		backoff *= 2
+	}
+
+	return min(backoff, limit)
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+	"github.com/PoorMercymain/bannerify/pkg/signature"
+)
+
+func TestSendWebhook(t *testing.T) {
+	type received struct {
+		header http.Header
+		body   []byte
+	}
+
+	requests := make(chan received, 1)
+	status := http.StatusNoContent
+	stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		requests <- received{header: r.Header, body: body}
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("try again later"))
+	}))
+	defer stub.Close()
+
+	r := NewWebhookDispatcher(nil, time.Second, 3, time.Second, time.Minute, time.Second)
+	delivery := pendingDelivery{
+		deliveryID: 7,
+		webhookID:  1,
+		event:      domain.WebhookBannerCreated,
+		payload:    []byte(`{"event":"banner.created","banner_id":1,"version_id":2,"occurred_at":"2024-04-14T00:00:00Z"}`),
+		url:        stub.URL,
+		secret:     "0123456789abcdef",
+	}
+
+	statusCode, err := r.send(context.Background(), delivery)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, statusCode)
+
+	req := <-requests
+	require.Equal(t, delivery.payload, req.body)
+	require.Equal(t, "application/json", req.header.Get("Content-Type"))
+	require.Equal(t, domain.WebhookBannerCreated, req.header.Get("X-Bannerify-Event"))
+	require.Equal(t, "7", req.header.Get("X-Bannerify-Delivery"))
+
+	timestamp, err := strconv.ParseInt(req.header.Get("X-Bannerify-Timestamp"), 10, 64)
+	require.NoError(t, err)
+	require.True(t, signature.Verify(delivery.secret, timestamp, req.body, req.header.Get("X-Bannerify-Signature")))
+
+	status = http.StatusServiceUnavailable
+
+	statusCode, err = r.send(context.Background(), delivery)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "try again later")
+	require.Equal(t, http.StatusServiceUnavailable, statusCode)
+	<-requests
+
+	stub.Close()
+
+	statusCode, err = r.send(context.Background(), delivery)
+	require.Error(t, err)
+	require.Zero(t, statusCode)
+}
+
+func TestDeliveryBackoff(t *testing.T) {
+	require.Equal(t, 10*time.Second, deliveryBackoff(1, 10*time.Second, time.Hour))
+	require.Equal(t, 20*time.Second, deliveryBackoff(2, 10*time.Second, time.Hour))
+	require.Equal(t, 80*time.Second, deliveryBackoff(4, 10*time.Second, time.Hour))
+	require.Equal(t, time.Hour, deliveryBackoff(12, 10*time.Second, time.Hour))
+	require.Equal(t, time.Hour, deliveryBackoff(1000, 10*time.Second, time.Hour))
+}
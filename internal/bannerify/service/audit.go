@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+var (
+	_ domain.BannerServiceAuditor = (*auditor)(nil)
+)
+
+type auditor struct {
+	repo domain.BannerRepositoryAuditor
+}
+
+func NewAuditor(repo domain.BannerRepositoryAuditor) *auditor {
+	return &auditor{repo: repo}
+}
+
+func (s *auditor) SearchAudit(ctx context.Context, filter domain.AuditFilter, limit int, offset int) ([]domain.AuditEntry, error) {
+	entries, err := s.repo.SearchAudit(ctx, filter, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("service.SearchAudit: %w", err)
+	}
+
+	return entries, nil
+}
@@ -40,15 +40,33 @@ func NewGetter(repo domain.BannerRepositoryGetter) *bannerGetter {
 	return &bannerGetter{repo: repo}
 }
 
-func (s *bannerGetter) GetBanner(ctx context.Context, tagID int, featureID int, isAdmin bool, dbRequired bool) (string, error) {
-	banner, err := s.repo.GetBanner(ctx, tagID, featureID, isAdmin, dbRequired)
+func (s *bannerGetter) GetBanner(ctx context.Context, tagIDs []int, featureID int, isAdmin bool, dbRequired bool, user domain.UserContext) (domain.UserBanner, error) {
+	banner, err := s.repo.GetBanner(ctx, tagIDs, featureID, isAdmin, dbRequired, user)
 	if err != nil {
-		return "", fmt.Errorf("service.GetBanner: %w", err)
+		return domain.UserBanner{}, fmt.Errorf("service.GetBanner: %w", err)
 	}
 
 	return banner, nil
 }
 
+func (s *bannerGetter) GetBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, dbRequired bool, user domain.UserContext) (map[int]domain.UserBanner, error) {
+	banners, err := s.repo.GetBanners(ctx, tagIDs, featureIDs, isAdmin, dbRequired, user)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetBanners: %w", err)
+	}
+
+	return banners, nil
+}
+
+func (s *bannerGetter) PeekBanners(ctx context.Context, tagIDs []int, featureIDs []int, isAdmin bool, dbRequired bool, user domain.UserContext) (map[int]domain.UserBanner, error) {
+	banners, err := s.repo.PeekBanners(ctx, tagIDs, featureIDs, isAdmin, dbRequired, user)
+	if err != nil {
+		return nil, fmt.Errorf("service.PeekBanners: %w", err)
+	}
+
+	return banners, nil
+}
+
 func (s *bannerGetter) ListBanners(ctx context.Context, tagID *int, featureID *int, limit int, offset int) ([]domain.BannerListElement, error) {
 	banners, err := s.repo.ListBanners(ctx, tagID, featureID, limit, offset)
 	if err != nil {
@@ -58,6 +76,19 @@ func (s *bannerGetter) ListBanners(ctx context.Context, tagID *int, featureID *i
 	return banners, nil
 }
 
+func (s *bannerGetter) CacheStats() domain.CacheStats {
+	return s.repo.CacheStats()
+}
+
+func (s *bannerGetter) WarmUp(ctx context.Context) (int, error) {
+	warmed, err := s.repo.WarmUp(ctx)
+	if err != nil {
+		return warmed, fmt.Errorf("service.WarmUp: %w", err)
+	}
+
+	return warmed, nil
+}
+
 var (
 	_ domain.BannerServiceVersioner = (*bannerVersioner)(nil)
 )
@@ -130,6 +161,27 @@ func (s *bannerUpdater) UpdateBanner(ctx context.Context, bannerID int, banner d
 	return nil
 }
 
+var (
+	_ domain.BannerServiceTagPrioritizer = (*tagPrioritizer)(nil)
+)
+
+type tagPrioritizer struct {
+	repo domain.BannerRepositoryTagPrioritizer
+}
+
+func NewTagPrioritizer(repo domain.BannerRepositoryTagPrioritizer) *tagPrioritizer {
+	return &tagPrioritizer{repo: repo}
+}
+
+func (s *tagPrioritizer) SetTagPriority(ctx context.Context, tagID int, priority int) error {
+	err := s.repo.SetTagPriority(ctx, tagID, priority)
+	if err != nil {
+		return fmt.Errorf("service.SetTagPriority: %w", err)
+	}
+
+	return nil
+}
+
 var (
 	_ domain.BannerServiceDeleter = (*bannerDeleter)(nil)
 )
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+var (
+	_ domain.BannerServiceChangeStreamer = (*changeStreamer)(nil)
+)
+
+type changeStreamer struct {
+	repo domain.BannerRepositoryChangeStreamer
+}
+
+func NewChangeStreamer(repo domain.BannerRepositoryChangeStreamer) *changeStreamer {
+	return &changeStreamer{repo: repo}
+}
+
+func (s *changeStreamer) Subscribe(tagID int, featureIDs []int) (<-chan domain.BannerChange, func()) {
+	return s.repo.Subscribe(tagID, featureIDs)
+}
+
+func (s *changeStreamer) LastChangeID(ctx context.Context) (int64, error) {
+	lastID, err := s.repo.LastChangeID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("service.LastChangeID: %w", err)
+	}
+
+	return lastID, nil
+}
+
+func (s *changeStreamer) ChangesSince(ctx context.Context, changeID int64, tagID int, featureIDs []int) ([]domain.BannerChange, error) {
+	changes, err := s.repo.ChangesSince(ctx, changeID, tagID, featureIDs)
+	if err != nil {
+		return nil, fmt.Errorf("service.ChangesSince: %w", err)
+	}
+
+	return changes, nil
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+var (
+	_ domain.BannerServiceEventTracker = (*eventTracker)(nil)
+)
+
+type eventTracker struct {
+	repo domain.BannerRepositoryEventTracker
+}
+
+func NewEventTracker(repo domain.BannerRepositoryEventTracker) *eventTracker {
+	return &eventTracker{repo: repo}
+}
+
+func (s *eventTracker) RecordEvents(ctx context.Context, events []domain.BannerEvent) error {
+	err := s.repo.RecordEvents(ctx, events)
+	if err != nil {
+		return fmt.Errorf("service.RecordEvents: %w", err)
+	}
+
+	return nil
+}
+
+func (s *eventTracker) GetBannerStats(ctx context.Context, bannerID *int, featureID *int, tagID *int, from time.Time, to time.Time, limit int, offset int) ([]domain.BannerStats, error) {
+	stats, err := s.repo.GetBannerStats(ctx, bannerID, featureID, tagID, from, to, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetBannerStats: %w", err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+var (
+	_ domain.BannerServiceExperimenter = (*experimenter)(nil)
+)
+
+type experimenter struct {
+	repo domain.BannerRepositoryExperimenter
+}
+
+func NewExperimenter(repo domain.BannerRepositoryExperimenter) *experimenter {
+	return &experimenter{repo: repo}
+}
+
+func (s *experimenter) CreateExperiment(ctx context.Context, experiment domain.Experiment) (int, error) {
+	experimentID, err := s.repo.CreateExperiment(ctx, experiment)
+	if err != nil {
+		return 0, fmt.Errorf("service.CreateExperiment: %w", err)
+	}
+
+	return experimentID, nil
+}
+
+func (s *experimenter) ListExperiments(ctx context.Context, featureID *int, tagID *int, limit int, offset int) ([]domain.ExperimentListElement, error) {
+	experiments, err := s.repo.ListExperiments(ctx, featureID, tagID, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListExperiments: %w", err)
+	}
+
+	return experiments, nil
+}
+
+func (s *experimenter) UpdateExperiment(ctx context.Context, experimentID int, experiment domain.Experiment) error {
+	err := s.repo.UpdateExperiment(ctx, experimentID, experiment)
+	if err != nil {
+		return fmt.Errorf("service.UpdateExperiment: %w", err)
+	}
+
+	return nil
+}
+
+func (s *experimenter) DeleteExperiment(ctx context.Context, experimentID int) error {
+	err := s.repo.DeleteExperiment(ctx, experimentID)
+	if err != nil {
+		return fmt.Errorf("service.DeleteExperiment: %w", err)
+	}
+
+	return nil
+}
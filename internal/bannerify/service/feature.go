@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+var (
+	_ domain.BannerServiceFeatureRegistry = (*featureRegistry)(nil)
+)
+
+type featureRegistry struct {
+	repo domain.BannerRepositoryFeatureRegistry
+}
+
+func NewFeatureRegistry(repo domain.BannerRepositoryFeatureRegistry) *featureRegistry {
+	return &featureRegistry{repo: repo}
+}
+
+func (s *featureRegistry) SetFeatureSchema(ctx context.Context, featureID int, schema []byte) error {
+	err := s.repo.SetFeatureSchema(ctx, featureID, schema)
+	if err != nil {
+		return fmt.Errorf("service.SetFeatureSchema: %w", err)
+	}
+
+	return nil
+}
+
+func (s *featureRegistry) GetFeatureSchema(ctx context.Context, featureID int) ([]byte, error) {
+	schema, err := s.repo.GetFeatureSchema(ctx, featureID)
+	if err != nil {
+		return nil, fmt.Errorf("service.GetFeatureSchema: %w", err)
+	}
+
+	return schema, nil
+}
+
+func (s *featureRegistry) DeleteFeatureSchema(ctx context.Context, featureID int) error {
+	err := s.repo.DeleteFeatureSchema(ctx, featureID)
+	if err != nil {
+		return fmt.Errorf("service.DeleteFeatureSchema: %w", err)
+	}
+
+	return nil
+}
+
+func (s *featureRegistry) CheckFeatureSchema(ctx context.Context, featureID int, schema []byte) ([]domain.SchemaCheckElement, error) {
+	banners, err := s.repo.CheckFeatureSchema(ctx, featureID, schema)
+	if err != nil {
+		return nil, fmt.Errorf("service.CheckFeatureSchema: %w", err)
+	}
+
+	return banners, nil
+}
+
+func (s *featureRegistry) SetFeatureFallback(ctx context.Context, featureID int, bannerID int) error {
+	err := s.repo.SetFeatureFallback(ctx, featureID, bannerID)
+	if err != nil {
+		return fmt.Errorf("service.SetFeatureFallback: %w", err)
+	}
+
+	return nil
+}
+
+func (s *featureRegistry) GetFeatureFallback(ctx context.Context, featureID int) (int, error) {
+	bannerID, err := s.repo.GetFeatureFallback(ctx, featureID)
+	if err != nil {
+		return 0, fmt.Errorf("service.GetFeatureFallback: %w", err)
+	}
+
+	return bannerID, nil
+}
+
+func (s *featureRegistry) DeleteFeatureFallback(ctx context.Context, featureID int) error {
+	err := s.repo.DeleteFeatureFallback(ctx, featureID)
+	if err != nil {
+		return fmt.Errorf("service.DeleteFeatureFallback: %w", err)
+	}
+
+	return nil
+}
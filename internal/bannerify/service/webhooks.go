@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/PoorMercymain/bannerify/internal/bannerify/domain"
+)
+
+var (
+	_ domain.BannerServiceWebhookManager = (*webhookManager)(nil)
+)
+
+type webhookManager struct {
+	repo domain.BannerRepositoryWebhookManager
+}
+
+func NewWebhookManager(repo domain.BannerRepositoryWebhookManager) *webhookManager {
+	return &webhookManager{repo: repo}
+}
+
+func (s *webhookManager) CreateWebhook(ctx context.Context, webhook domain.Webhook) (int, error) {
+	webhookID, err := s.repo.CreateWebhook(ctx, webhook)
+	if err != nil {
+		return 0, fmt.Errorf("service.CreateWebhook: %w", err)
+	}
+
+	return webhookID, nil
+}
+
+func (s *webhookManager) ListWebhooks(ctx context.Context, limit int, offset int) ([]domain.WebhookListElement, error) {
+	webhooks, err := s.repo.ListWebhooks(ctx, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListWebhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (s *webhookManager) UpdateWebhook(ctx context.Context, webhookID int, webhook domain.Webhook) error {
+	err := s.repo.UpdateWebhook(ctx, webhookID, webhook)
+	if err != nil {
+		return fmt.Errorf("service.UpdateWebhook: %w", err)
+	}
+
+	return nil
+}
+
+func (s *webhookManager) DeleteWebhook(ctx context.Context, webhookID int) error {
+	err := s.repo.DeleteWebhook(ctx, webhookID)
+	if err != nil {
+		return fmt.Errorf("service.DeleteWebhook: %w", err)
+	}
+
+	return nil
+}
+
+func (s *webhookManager) ListDeliveries(ctx context.Context, webhookID int, status *string, limit int, offset int) ([]domain.WebhookDelivery, error) {
+	deliveries, err := s.repo.ListDeliveries(ctx, webhookID, status, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("service.ListDeliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
@@ -16,3 +16,13 @@ func WriteHTTPError(w http.ResponseWriter, err error, statusCode int, prefix str
 		logger.Logger().Errorln(prefix, err.Error())
 	}
 }
+
+// WriteHTTPErrorDetails writes the error along with the places of the request body it is about.
+func WriteHTTPErrorDetails(w http.ResponseWriter, err error, details []domain.ErrorDetail, statusCode int, prefix string) {
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	err = json.NewEncoder(w).Encode(domain.JSONError{Err: err.Error(), Details: details})
+	if err != nil {
+		logger.Logger().Errorln(prefix, err.Error())
+	}
+}
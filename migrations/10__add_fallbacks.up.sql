@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE features ADD COLUMN IF NOT EXISTS fallback_banner_id INT NULL REFERENCES banners(banner_id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_features_fallback_banner_id ON features(fallback_banner_id);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_features_fallback_banner_id;
+
+ALTER TABLE features DROP COLUMN IF EXISTS fallback_banner_id;
+
+COMMIT;
\ No newline at end of file
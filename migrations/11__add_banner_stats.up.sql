@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS banner_stats (
+    banner_id INT NOT NULL,
+    version_id INT NOT NULL,
+    feature INT NOT NULL,
+    tag INT NOT NULL,
+    bucket TIMESTAMPTZ NOT NULL,
+    impressions BIGINT NOT NULL DEFAULT 0,
+    clicks BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (banner_id, version_id, feature, tag, bucket)
+);
+
+CREATE INDEX IF NOT EXISTS idx_banner_stats_bucket ON banner_stats(bucket);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS banner_stats;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE banner_versions ADD COLUMN IF NOT EXISTS frequency_cap INT NULL CHECK (frequency_cap > 0);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE banner_versions DROP COLUMN IF EXISTS frequency_cap;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,15 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS banner_changes (
+    change_id BIGSERIAL PRIMARY KEY,
+    feature INT NOT NULL,
+    tag INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    tx_id XID8 NOT NULL DEFAULT pg_current_xact_id(),
+    tx_xmin XID8 NOT NULL DEFAULT pg_snapshot_xmin(pg_current_snapshot())
+);
+
+CREATE INDEX IF NOT EXISTS idx_banner_changes_created_at ON banner_changes(created_at);
+CREATE INDEX IF NOT EXISTS idx_banner_changes_tx_id ON banner_changes(tx_id);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS banner_changes;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS audit_log (
+    entry_id BIGSERIAL PRIMARY KEY,
+    actor TEXT NOT NULL,
+    action TEXT NOT NULL,
+    banner_id INT NULL,
+    version_id INT NULL,
+    target_id INT NULL,
+    diff JSONB NOT NULL,
+    request_id TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor ON audit_log(actor, created_at);
+CREATE INDEX IF NOT EXISTS idx_audit_log_banner_id ON audit_log(banner_id, created_at) WHERE banner_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_audit_log_created_at ON audit_log(created_at);
+
+COMMIT;
\ No newline at end of file
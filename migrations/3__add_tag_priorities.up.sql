@@ -0,0 +1,10 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS tag_priorities (
+    tag INT PRIMARY KEY,
+    priority INT NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_chosen_versions_version_id ON chosen_versions(version_id);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_chosen_versions_version_id;
+
+DROP TABLE IF EXISTS tag_priorities;
+
+COMMIT;
\ No newline at end of file
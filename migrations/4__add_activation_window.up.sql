@@ -0,0 +1,21 @@
+BEGIN;
+
+ALTER TABLE banner_versions ADD COLUMN IF NOT EXISTS active_from TIMESTAMPTZ NULL;
+
+ALTER TABLE banner_versions ADD COLUMN IF NOT EXISTS active_until TIMESTAMPTZ NULL;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (
+        SELECT 1
+        FROM pg_constraint
+        WHERE conname = 'chk_active_window'
+    ) THEN
+        ALTER TABLE banner_versions
+            ADD CONSTRAINT chk_active_window
+            CHECK (active_from IS NULL OR active_until IS NULL OR active_from < active_until);
+    END IF;
+END
+$$;
+
+COMMIT;
\ No newline at end of file
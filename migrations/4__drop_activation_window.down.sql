@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE banner_versions DROP CONSTRAINT IF EXISTS chk_active_window;
+
+ALTER TABLE banner_versions DROP COLUMN IF EXISTS active_until;
+
+ALTER TABLE banner_versions DROP COLUMN IF EXISTS active_from;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS experiments (
+    experiment_id SERIAL PRIMARY KEY,
+    feature INT NOT NULL,
+    tag INT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (feature, tag)
+);
+
+CREATE TABLE IF NOT EXISTS experiment_variants (
+    experiment_id INT NOT NULL,
+    variant_key TEXT NOT NULL,
+    version_id INT NOT NULL,
+    weight INT NOT NULL CHECK (weight > 0),
+    PRIMARY KEY (experiment_id, variant_key),
+    FOREIGN KEY (experiment_id) REFERENCES experiments(experiment_id) ON DELETE CASCADE,
+    FOREIGN KEY (version_id) REFERENCES banner_versions(version_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_experiment_variants_version_id ON experiment_variants(version_id);
+
+COMMIT;
\ No newline at end of file
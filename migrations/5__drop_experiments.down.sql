@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS idx_experiment_variants_version_id;
+
+DROP TABLE IF EXISTS experiment_variants;
+
+DROP TABLE IF EXISTS experiments;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE banner_versions ADD COLUMN IF NOT EXISTS targeting TEXT NULL;
+
+COMMIT;
\ No newline at end of file
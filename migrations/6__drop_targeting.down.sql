@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE banner_versions DROP COLUMN IF EXISTS targeting;
+
+COMMIT;
\ No newline at end of file
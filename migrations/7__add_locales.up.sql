@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE banner_versions ADD COLUMN IF NOT EXISTS default_locale TEXT NULL;
+
+CREATE TABLE IF NOT EXISTS banner_version_locales (
+    version_id INT NOT NULL,
+    locale TEXT NOT NULL,
+    data TEXT NOT NULL,
+    PRIMARY KEY (version_id, locale),
+    FOREIGN KEY (version_id) REFERENCES banner_versions(version_id) ON DELETE CASCADE
+);
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS banner_version_locales;
+
+ALTER TABLE banner_versions DROP COLUMN IF EXISTS default_locale;
+
+COMMIT;
\ No newline at end of file
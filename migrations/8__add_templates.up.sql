@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE banner_versions ADD COLUMN IF NOT EXISTS is_template BOOLEAN NOT NULL DEFAULT FALSE;
+
+ALTER TABLE banner_versions ADD COLUMN IF NOT EXISTS missing_variables TEXT NOT NULL DEFAULT 'empty';
+
+COMMIT;
\ No newline at end of file
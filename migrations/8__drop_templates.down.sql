@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE banner_versions DROP COLUMN IF EXISTS missing_variables;
+
+ALTER TABLE banner_versions DROP COLUMN IF EXISTS is_template;
+
+COMMIT;
\ No newline at end of file
@@ -0,0 +1,4 @@
+// Package api contains the gRPC API of the service generated from api/bannerify.proto.
+package api
+
+//go:generate protoc -I ../../api --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative bannerify.proto
@@ -0,0 +1,395 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: bannerify.proto
+
+package api
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BannerService_GetBanner_FullMethodName     = "/bannerify.v1.BannerService/GetBanner"
+	BannerService_ListBanners_FullMethodName   = "/bannerify.v1.BannerService/ListBanners"
+	BannerService_ListVersions_FullMethodName  = "/bannerify.v1.BannerService/ListVersions"
+	BannerService_ChooseVersion_FullMethodName = "/bannerify.v1.BannerService/ChooseVersion"
+	BannerService_CreateBanner_FullMethodName  = "/bannerify.v1.BannerService/CreateBanner"
+	BannerService_UpdateBanner_FullMethodName  = "/bannerify.v1.BannerService/UpdateBanner"
+	BannerService_DeleteBanner_FullMethodName  = "/bannerify.v1.BannerService/DeleteBanner"
+	BannerService_DeleteBanners_FullMethodName = "/bannerify.v1.BannerService/DeleteBanners"
+)
+
+// BannerServiceClient is the client API for BannerService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// BannerService mirrors the banner endpoints of the REST API. The token is passed in the token metadata,
+// GetBanner accepts the tokens of users and admins, the other methods require an admin token.
+type BannerServiceClient interface {
+	GetBanner(ctx context.Context, in *GetBannerRequest, opts ...grpc.CallOption) (*UserBanner, error)
+	ListBanners(ctx context.Context, in *ListBannersRequest, opts ...grpc.CallOption) (*ListBannersResponse, error)
+	ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error)
+	ChooseVersion(ctx context.Context, in *ChooseVersionRequest, opts ...grpc.CallOption) (*ChooseVersionResponse, error)
+	CreateBanner(ctx context.Context, in *CreateBannerRequest, opts ...grpc.CallOption) (*CreateBannerResponse, error)
+	UpdateBanner(ctx context.Context, in *UpdateBannerRequest, opts ...grpc.CallOption) (*UpdateBannerResponse, error)
+	DeleteBanner(ctx context.Context, in *DeleteBannerRequest, opts ...grpc.CallOption) (*DeleteBannerResponse, error)
+	// DeleteBanners deletes the banners of the tag and/or the feature in the background, like DELETE /banner.
+	DeleteBanners(ctx context.Context, in *DeleteBannersRequest, opts ...grpc.CallOption) (*DeleteBannersResponse, error)
+}
+
+type bannerServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBannerServiceClient(cc grpc.ClientConnInterface) BannerServiceClient {
+	return &bannerServiceClient{cc}
+}
+
+func (c *bannerServiceClient) GetBanner(ctx context.Context, in *GetBannerRequest, opts ...grpc.CallOption) (*UserBanner, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserBanner)
+	err := c.cc.Invoke(ctx, BannerService_GetBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) ListBanners(ctx context.Context, in *ListBannersRequest, opts ...grpc.CallOption) (*ListBannersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListBannersResponse)
+	err := c.cc.Invoke(ctx, BannerService_ListBanners_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) ListVersions(ctx context.Context, in *ListVersionsRequest, opts ...grpc.CallOption) (*ListVersionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListVersionsResponse)
+	err := c.cc.Invoke(ctx, BannerService_ListVersions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) ChooseVersion(ctx context.Context, in *ChooseVersionRequest, opts ...grpc.CallOption) (*ChooseVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChooseVersionResponse)
+	err := c.cc.Invoke(ctx, BannerService_ChooseVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) CreateBanner(ctx context.Context, in *CreateBannerRequest, opts ...grpc.CallOption) (*CreateBannerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateBannerResponse)
+	err := c.cc.Invoke(ctx, BannerService_CreateBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) UpdateBanner(ctx context.Context, in *UpdateBannerRequest, opts ...grpc.CallOption) (*UpdateBannerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateBannerResponse)
+	err := c.cc.Invoke(ctx, BannerService_UpdateBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) DeleteBanner(ctx context.Context, in *DeleteBannerRequest, opts ...grpc.CallOption) (*DeleteBannerResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBannerResponse)
+	err := c.cc.Invoke(ctx, BannerService_DeleteBanner_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *bannerServiceClient) DeleteBanners(ctx context.Context, in *DeleteBannersRequest, opts ...grpc.CallOption) (*DeleteBannersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBannersResponse)
+	err := c.cc.Invoke(ctx, BannerService_DeleteBanners_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BannerServiceServer is the server API for BannerService service.
+// All implementations must embed UnimplementedBannerServiceServer
+// for forward compatibility.
+//
+// BannerService mirrors the banner endpoints of the REST API. The token is passed in the token metadata,
+// GetBanner accepts the tokens of users and admins, the other methods require an admin token.
+type BannerServiceServer interface {
+	GetBanner(context.Context, *GetBannerRequest) (*UserBanner, error)
+	ListBanners(context.Context, *ListBannersRequest) (*ListBannersResponse, error)
+	ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error)
+	ChooseVersion(context.Context, *ChooseVersionRequest) (*ChooseVersionResponse, error)
+	CreateBanner(context.Context, *CreateBannerRequest) (*CreateBannerResponse, error)
+	UpdateBanner(context.Context, *UpdateBannerRequest) (*UpdateBannerResponse, error)
+	DeleteBanner(context.Context, *DeleteBannerRequest) (*DeleteBannerResponse, error)
+	// DeleteBanners deletes the banners of the tag and/or the feature in the background, like DELETE /banner.
+	DeleteBanners(context.Context, *DeleteBannersRequest) (*DeleteBannersResponse, error)
+	mustEmbedUnimplementedBannerServiceServer()
+}
+
+// UnimplementedBannerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBannerServiceServer struct{}
+
+func (UnimplementedBannerServiceServer) GetBanner(context.Context, *GetBannerRequest) (*UserBanner, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBanner not implemented")
+}
+func (UnimplementedBannerServiceServer) ListBanners(context.Context, *ListBannersRequest) (*ListBannersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListBanners not implemented")
+}
+func (UnimplementedBannerServiceServer) ListVersions(context.Context, *ListVersionsRequest) (*ListVersionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVersions not implemented")
+}
+func (UnimplementedBannerServiceServer) ChooseVersion(context.Context, *ChooseVersionRequest) (*ChooseVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChooseVersion not implemented")
+}
+func (UnimplementedBannerServiceServer) CreateBanner(context.Context, *CreateBannerRequest) (*CreateBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBanner not implemented")
+}
+func (UnimplementedBannerServiceServer) UpdateBanner(context.Context, *UpdateBannerRequest) (*UpdateBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBanner not implemented")
+}
+func (UnimplementedBannerServiceServer) DeleteBanner(context.Context, *DeleteBannerRequest) (*DeleteBannerResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBanner not implemented")
+}
+func (UnimplementedBannerServiceServer) DeleteBanners(context.Context, *DeleteBannersRequest) (*DeleteBannersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBanners not implemented")
+}
+func (UnimplementedBannerServiceServer) mustEmbedUnimplementedBannerServiceServer() {}
+func (UnimplementedBannerServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafeBannerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BannerServiceServer will
+// result in compilation errors.
+type UnsafeBannerServiceServer interface {
+	mustEmbedUnimplementedBannerServiceServer()
+}
+
+func RegisterBannerServiceServer(s grpc.ServiceRegistrar, srv BannerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBannerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BannerService_ServiceDesc, srv)
+}
+
+func _BannerService_GetBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).GetBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_GetBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).GetBanner(ctx, req.(*GetBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_ListBanners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListBannersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).ListBanners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_ListBanners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).ListBanners(ctx, req.(*ListBannersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_ListVersions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVersionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).ListVersions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_ListVersions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).ListVersions(ctx, req.(*ListVersionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_ChooseVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChooseVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).ChooseVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_ChooseVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).ChooseVersion(ctx, req.(*ChooseVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_CreateBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).CreateBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_CreateBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).CreateBanner(ctx, req.(*CreateBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_UpdateBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).UpdateBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_UpdateBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).UpdateBanner(ctx, req.(*UpdateBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_DeleteBanner_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBannerRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).DeleteBanner(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_DeleteBanner_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).DeleteBanner(ctx, req.(*DeleteBannerRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BannerService_DeleteBanners_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBannersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BannerServiceServer).DeleteBanners(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BannerService_DeleteBanners_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BannerServiceServer).DeleteBanners(ctx, req.(*DeleteBannersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BannerService_ServiceDesc is the grpc.ServiceDesc for BannerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BannerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "bannerify.v1.BannerService",
+	HandlerType: (*BannerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBanner",
+			Handler:    _BannerService_GetBanner_Handler,
+		},
+		{
+			MethodName: "ListBanners",
+			Handler:    _BannerService_ListBanners_Handler,
+		},
+		{
+			MethodName: "ListVersions",
+			Handler:    _BannerService_ListVersions_Handler,
+		},
+		{
+			MethodName: "ChooseVersion",
+			Handler:    _BannerService_ChooseVersion_Handler,
+		},
+		{
+			MethodName: "CreateBanner",
+			Handler:    _BannerService_CreateBanner_Handler,
+		},
+		{
+			MethodName: "UpdateBanner",
+			Handler:    _BannerService_UpdateBanner_Handler,
+		},
+		{
+			MethodName: "DeleteBanner",
+			Handler:    _BannerService_DeleteBanner_Handler,
+		},
+		{
+			MethodName: "DeleteBanners",
+			Handler:    _BannerService_DeleteBanners_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "bannerify.proto",
+}
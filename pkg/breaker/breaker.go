@@ -0,0 +1,73 @@
+package breaker
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	StateClosed   = "closed"
+	StateOpen     = "open"
+	StateHalfOpen = "half-open"
+)
+
+// Breaker opens after threshold consecutive failures and rejects the calls for openTimeout.
+// After that a single probe call is allowed: its success closes the breaker, its failure opens it again.
+// If the result of the probe is not reported within openTimeout, another probe is allowed.
+type Breaker struct {
+	mu          sync.Mutex
+	state       string
+	failures    int
+	threshold   int
+	openTimeout time.Duration
+	openedAt    time.Time
+}
+
+func New(threshold int, openTimeout time.Duration) *Breaker {
+	return &Breaker{state: StateClosed, threshold: threshold, openTimeout: openTimeout}
+}
+
+// Allow reports whether the call may be made. Every allowed call must be followed by Success or Failure.
+func (b *Breaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateOpen, StateHalfOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return false
+		}
+
+		b.state = StateHalfOpen
+		b.openedAt = time.Now()
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *Breaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = StateClosed
+	b.failures = 0
+}
+
+func (b *Breaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == StateHalfOpen || b.failures >= b.threshold {
+		b.state = StateOpen
+		b.openedAt = time.Now()
+	}
+}
+
+func (b *Breaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
@@ -0,0 +1,48 @@
+package breaker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBreaker(t *testing.T) {
+	b := New(2, 20*time.Millisecond)
+
+	require.True(t, b.Allow())
+	b.Failure()
+	require.Equal(t, StateClosed, b.State())
+
+	require.True(t, b.Allow())
+	b.Success()
+	require.True(t, b.Allow())
+	b.Failure()
+	require.Equal(t, StateClosed, b.State())
+
+	require.True(t, b.Allow())
+	b.Failure()
+	require.Equal(t, StateOpen, b.State())
+	require.False(t, b.Allow())
+
+	time.Sleep(25 * time.Millisecond)
+
+	require.True(t, b.Allow())
+	require.Equal(t, StateHalfOpen, b.State())
+	require.False(t, b.Allow())
+	b.Failure()
+	require.Equal(t, StateOpen, b.State())
+	require.False(t, b.Allow())
+
+	time.Sleep(25 * time.Millisecond)
+
+	require.True(t, b.Allow())
+	require.False(t, b.Allow())
+
+	time.Sleep(25 * time.Millisecond)
+
+	require.True(t, b.Allow())
+	b.Success()
+	require.Equal(t, StateClosed, b.State())
+	require.True(t, b.Allow())
+}
@@ -0,0 +1,39 @@
+package bucket
+
+import (
+	"hash/fnv"
+)
+
+// Choose picks an index of weights proportionally to them. The same seed always gets the same index
+// while the weights do not change, the indexes of non-positive weights are never picked.
+// It returns -1 if there is nothing to pick.
+func Choose(seed string, weights []int) int {
+	var total uint64
+	for _, weight := range weights {
+		if weight > 0 {
+			total += uint64(weight)
+		}
+	}
+
+	if total == 0 {
+		return -1
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(seed))
+	point := h.Sum64() % total
+
+	for i, weight := range weights {
+		if weight <= 0 {
+			continue
+		}
+
+		if point < uint64(weight) {
+			return i
+		}
+
+		point -= uint64(weight)
+	}
+
+	return -1
+}
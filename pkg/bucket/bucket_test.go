@@ -0,0 +1,32 @@
+package bucket
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChoose(t *testing.T) {
+	require.Equal(t, -1, Choose("user", nil))
+	require.Equal(t, -1, Choose("user", []int{0, -1}))
+	require.Equal(t, 1, Choose("user", []int{0, 5}))
+
+	for i := 0; i < 100; i++ {
+		seed := strconv.Itoa(i)
+		require.Equal(t, Choose(seed, []int{1, 2, 3}), Choose(seed, []int{1, 2, 3}))
+	}
+}
+
+func TestChooseDistribution(t *testing.T) {
+	weights := []int{1, 3}
+	counts := make([]int, len(weights))
+
+	const users = 10000
+	for i := 0; i < users; i++ {
+		counts[Choose("experiment:"+strconv.Itoa(i), weights)]++
+	}
+
+	require.InDelta(t, users/4, counts[0], users/20)
+	require.InDelta(t, users*3/4, counts[1], users/20)
+}
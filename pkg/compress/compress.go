@@ -0,0 +1,95 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+
+	appErrors "github.com/PoorMercymain/bannerify/errors"
+)
+
+const (
+	Brotli = "br"
+	Gzip   = "gzip"
+
+	// MinSize is the length of content below which compression is not worth the CPU and headers.
+	MinSize = 1024
+)
+
+// Encodings are listed in the order of preference used when the client accepts several of them equally.
+var Encodings = []string{Brotli, Gzip}
+
+// Negotiate picks the encoding for the response from the Accept-Encoding header value.
+// Empty string means the response should be sent as is.
+func Negotiate(acceptEncoding string) string {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(part, ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+
+			quality = parsed
+		}
+
+		qualities[name] = quality
+	}
+
+	var chosen string
+	var chosenQuality float64
+	for _, encoding := range Encodings {
+		quality, ok := qualities[encoding]
+		if !ok {
+			quality, ok = qualities["*"]
+		}
+
+		if ok && quality > chosenQuality {
+			chosen, chosenQuality = encoding, quality
+		}
+	}
+
+	return chosen
+}
+
+func NewWriter(encoding string, w io.Writer) io.WriteCloser {
+	switch encoding {
+	case Brotli:
+		return brotli.NewWriterLevel(w, brotli.DefaultCompression)
+	case Gzip:
+		return gzip.NewWriter(w)
+	default:
+		return nil
+	}
+}
+
+func Encode(encoding string, content []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := NewWriter(encoding, &buf)
+	if w == nil {
+		return nil, appErrors.ErrUnknownEncoding
+	}
+
+	_, err := w.Write(content)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
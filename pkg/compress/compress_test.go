@@ -0,0 +1,48 @@
+package compress
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegotiate(t *testing.T) {
+	require.Equal(t, "", Negotiate(""))
+	require.Equal(t, "", Negotiate("identity"))
+	require.Equal(t, Gzip, Negotiate("gzip"))
+	require.Equal(t, Brotli, Negotiate("gzip, deflate, br"))
+	require.Equal(t, Gzip, Negotiate("br;q=0.5, gzip"))
+	require.Equal(t, Gzip, Negotiate("br;q=0, gzip;q=0.1"))
+	require.Equal(t, Brotli, Negotiate("*"))
+	require.Equal(t, "", Negotiate("*;q=0"))
+	require.Equal(t, Gzip, Negotiate("GZIP;q=0.8, br;q=abc"))
+}
+
+func TestEncode(t *testing.T) {
+	content := bytes.Repeat([]byte(`{"title": "some_title"}`), 100)
+
+	encoded, err := Encode(Gzip, content)
+	require.NoError(t, err)
+	require.Less(t, len(encoded), len(content))
+
+	r, err := gzip.NewReader(bytes.NewReader(encoded))
+	require.NoError(t, err)
+	decoded, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.Equal(t, content, decoded)
+
+	encoded, err = Encode(Brotli, content)
+	require.NoError(t, err)
+	require.Less(t, len(encoded), len(content))
+
+	decoded, err = io.ReadAll(brotli.NewReader(bytes.NewReader(encoded)))
+	require.NoError(t, err)
+	require.Equal(t, content, decoded)
+
+	_, err = Encode("deflate", content)
+	require.Error(t, err)
+}
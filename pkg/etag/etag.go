@@ -0,0 +1,48 @@
+package etag
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+func Make(versionID int, content []byte) string {
+	h := sha256.New()
+	h.Write([]byte(strconv.Itoa(versionID)))
+	h.Write([]byte{':'})
+	h.Write(content)
+
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// WithEncoding makes a separate tag for the encoded representation, since a strong ETag
+// must differ for the bytes sent with a different Content-Encoding.
+func WithEncoding(etag string, encoding string) string {
+	if encoding == "" {
+		return etag
+	}
+
+	return strings.TrimSuffix(etag, `"`) + "-" + encoding + `"`
+}
+
+// Match uses the weak comparison, as If-None-Match requires (RFC 9110, section 13.1.2).
+func Match(ifNoneMatch string, etag string) bool {
+	ifNoneMatch = strings.TrimSpace(ifNoneMatch)
+	if ifNoneMatch == "" || etag == "" {
+		return false
+	}
+
+	if ifNoneMatch == "*" {
+		return true
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,37 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMake(t *testing.T) {
+	first := Make(1, []byte(`{"title":"a"}`))
+	require.Equal(t, first, Make(1, []byte(`{"title":"a"}`)))
+	require.NotEqual(t, first, Make(2, []byte(`{"title":"a"}`)))
+	require.NotEqual(t, first, Make(1, []byte(`{"title":"b"}`)))
+	require.Equal(t, byte('"'), first[0])
+	require.Equal(t, byte('"'), first[len(first)-1])
+}
+
+func TestMatch(t *testing.T) {
+	etag := Make(1, []byte(`{}`))
+
+	require.False(t, Match("", etag))
+	require.False(t, Match(etag, ""))
+	require.True(t, Match("*", etag))
+	require.True(t, Match(etag, etag))
+	require.True(t, Match("W/"+etag, etag))
+	require.True(t, Match(`"abc", `+etag, etag))
+	require.False(t, Match(`"abc"`, etag))
+	require.False(t, Match(Make(2, []byte(`{}`)), etag))
+}
+
+func TestWithEncoding(t *testing.T) {
+	etag := Make(1, []byte(`{}`))
+
+	require.Equal(t, etag, WithEncoding(etag, ""))
+	require.Equal(t, etag[:len(etag)-1]+`-gzip"`, WithEncoding(etag, "gzip"))
+	require.NotEqual(t, WithEncoding(etag, "gzip"), WithEncoding(etag, "br"))
+}
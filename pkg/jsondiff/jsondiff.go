@@ -0,0 +1,78 @@
+package jsondiff
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// Change is a field of an object before and after it was changed, null if the field or the object did not exist.
+type Change struct {
+	Before json.RawMessage `json:"before"`
+	After  json.RawMessage `json:"after"`
+}
+
+// Diff compares the top level fields of two JSON objects and returns the changed ones, a nil object has no fields.
+// The values are compared after decoding, so the formatting and the order of the keys do not matter.
+func Diff(before []byte, after []byte) (map[string]Change, error) {
+	beforeFields, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+
+	afterFields, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(map[string]Change)
+	for key, beforeValue := range beforeFields {
+		afterValue, ok := afterFields[key]
+		if !ok {
+			changes[key] = Change{Before: beforeValue, After: json.RawMessage("null")}
+			continue
+		}
+
+		equal, err := equalValues(beforeValue, afterValue)
+		if err != nil {
+			return nil, err
+		}
+
+		if !equal {
+			changes[key] = Change{Before: beforeValue, After: afterValue}
+		}
+	}
+
+	for key, afterValue := range afterFields {
+		if _, ok := beforeFields[key]; !ok {
+			changes[key] = Change{Before: json.RawMessage("null"), After: afterValue}
+		}
+	}
+
+	return changes, nil
+}
+
+func fields(object []byte) (map[string]json.RawMessage, error) {
+	if object == nil {
+		return nil, nil
+	}
+
+	var res map[string]json.RawMessage
+	if err := json.Unmarshal(object, &res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func equalValues(a json.RawMessage, b json.RawMessage) (bool, error) {
+	var decodedA, decodedB any
+	if err := json.Unmarshal(a, &decodedA); err != nil {
+		return false, err
+	}
+
+	if err := json.Unmarshal(b, &decodedB); err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(decodedA, decodedB), nil
+}
@@ -0,0 +1,32 @@
+package jsondiff
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiff(t *testing.T) {
+	changes, err := Diff([]byte(`{"feature_id": 1, "tag_ids": [1, 2], "is_active": true, "targeting": null}`), []byte(`{"tag_ids":[1,2],"feature_id":2,"is_active":true,"frequency_cap":3}`))
+	require.NoError(t, err)
+
+	encoded, err := json.Marshal(changes)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"feature_id":{"before":1,"after":2},"frequency_cap":{"before":null,"after":3},"targeting":{"before":null,"after":null}}`, string(encoded))
+
+	changes, err = Diff(nil, []byte(`{"priority": 5}`))
+	require.NoError(t, err)
+	require.Equal(t, map[string]Change{"priority": {Before: json.RawMessage("null"), After: json.RawMessage("5")}}, changes)
+
+	changes, err = Diff([]byte(`{"priority": 5}`), nil)
+	require.NoError(t, err)
+	require.Equal(t, map[string]Change{"priority": {Before: json.RawMessage("5"), After: json.RawMessage("null")}}, changes)
+
+	changes, err = Diff([]byte(`{"priority": 5}`), []byte(`{"priority": 5.0}`))
+	require.NoError(t, err)
+	require.Empty(t, changes)
+
+	_, err = Diff([]byte(`[1]`), nil)
+	require.Error(t, err)
+}
@@ -0,0 +1,722 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+var ErrInvalidSchema = errors.New("invalid JSON schema")
+
+// MaxViolations limits the number of violations reported for a document.
+const MaxViolations = 100
+
+// Violation is a place where the document does not conform to the schema, Path is a JSON Pointer such as /items/0/title.
+type Violation struct {
+	Path    string
+	Message string
+}
+
+// ValidationError lists the violations of a document sorted by their paths.
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Path+": "+v.Message)
+	}
+
+	return strings.Join(messages, "; ")
+}
+
+// Schema is a compiled JSON Schema. The supported keywords are type, enum, const,
+// properties, required, additionalProperties, minProperties, maxProperties,
+// items, minItems, maxItems, uniqueItems, minLength, maxLength, pattern,
+// minimum, maximum, exclusiveMinimum, exclusiveMaximum, allOf, anyOf, oneOf, not,
+// and $ref to the $defs of the same schema. The annotations such as title and description are ignored,
+// a schema with any other keyword is invalid, so that the content it should reject is not accepted silently.
+type Schema struct {
+	always *bool
+
+	types    []string
+	enum     []interface{}
+	constant *interface{}
+
+	properties    map[string]*Schema
+	required      []string
+	additional    *Schema
+	minProperties *int
+	maxProperties *int
+
+	items       *Schema
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+
+	minimum          *float64
+	maximum          *float64
+	exclusiveMinimum *float64
+	exclusiveMaximum *float64
+
+	allOf []*Schema
+	anyOf []*Schema
+	oneOf []*Schema
+	not   *Schema
+
+	ref  string
+	defs map[string]*Schema
+}
+
+// annotations are the keywords which do not affect validation.
+var annotations = map[string]struct{}{
+	"$schema":     {},
+	"$id":         {},
+	"$comment":    {},
+	"title":       {},
+	"description": {},
+	"default":     {},
+	"examples":    {},
+	"deprecated":  {},
+	"readOnly":    {},
+	"writeOnly":   {},
+}
+
+// keywords are the keywords the validator implements.
+var keywords = map[string]struct{}{
+	"type":                 {},
+	"enum":                 {},
+	"const":                {},
+	"properties":           {},
+	"required":             {},
+	"additionalProperties": {},
+	"minProperties":        {},
+	"maxProperties":        {},
+	"items":                {},
+	"minItems":             {},
+	"maxItems":             {},
+	"uniqueItems":          {},
+	"minLength":            {},
+	"maxLength":            {},
+	"pattern":              {},
+	"minimum":              {},
+	"maximum":              {},
+	"exclusiveMinimum":     {},
+	"exclusiveMaximum":     {},
+	"allOf":                {},
+	"anyOf":                {},
+	"oneOf":                {},
+	"not":                  {},
+	"$defs":                {},
+	"$ref":                 {},
+}
+
+// Compile parses the schema, which can be validated against concurrently.
+func Compile(data []byte) (*Schema, error) {
+	value, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSchema, err)
+	}
+
+	defs := make(map[string]*Schema)
+	root, err := compile(value, "", defs)
+	if err != nil {
+		return nil, err
+	}
+	defs[""] = root
+
+	if err = root.resolve(defs, make(map[*Schema]struct{})); err != nil {
+		return nil, err
+	}
+
+	return root, nil
+}
+
+// Validate returns nil if the document conforms to the schema and a *ValidationError otherwise.
+func (s *Schema) Validate(data []byte) error {
+	value, err := decode(data)
+	if err != nil {
+		return &ValidationError{Violations: []Violation{{Path: "", Message: "document is not a valid JSON"}}}
+	}
+
+	var violations []Violation
+	s.validate(value, "", &violations)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		return violations[i].Path < violations[j].Path
+	})
+
+	if len(violations) > MaxViolations {
+		violations = violations[:MaxViolations]
+	}
+
+	return &ValidationError{Violations: violations}
+}
+
+func decode(data []byte) (interface{}, error) {
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var value interface{}
+	if err := d.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	if d.More() {
+		return nil, errors.New("unexpected data after the top-level value")
+	}
+
+	return value, nil
+}
+
+func compile(value interface{}, path string, defs map[string]*Schema) (*Schema, error) {
+	if b, ok := value.(bool); ok {
+		return &Schema{always: &b}, nil
+	}
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, invalid(path, "a schema should be an object or a boolean")
+	}
+
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		_, isKeyword := keywords[name]
+		_, isAnnotation := annotations[name]
+		if !isKeyword && !isAnnotation {
+			return nil, invalid(path+"/"+escape(name), "the keyword is not supported")
+		}
+	}
+
+	s := &Schema{}
+	var err error
+
+	if t, ok := object["type"]; ok {
+		if s.types, err = compileTypes(t, path+"/type"); err != nil {
+			return nil, err
+		}
+	}
+
+	if e, ok := object["enum"]; ok {
+		values, ok := e.([]interface{})
+		if !ok || len(values) == 0 {
+			return nil, invalid(path+"/enum", "should be a non-empty array")
+		}
+
+		s.enum = values
+	}
+
+	if c, ok := object["const"]; ok {
+		s.constant = &c
+	}
+
+	if p, ok := object["properties"]; ok {
+		properties, ok := p.(map[string]interface{})
+		if !ok {
+			return nil, invalid(path+"/properties", "should be an object")
+		}
+
+		s.properties = make(map[string]*Schema, len(properties))
+		for name, property := range properties {
+			if s.properties[name], err = compile(property, path+"/properties/"+escape(name), defs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if r, ok := object["required"]; ok {
+		names, ok := r.([]interface{})
+		if !ok {
+			return nil, invalid(path+"/required", "should be an array of strings")
+		}
+
+		for _, name := range names {
+			str, ok := name.(string)
+			if !ok {
+				return nil, invalid(path+"/required", "should be an array of strings")
+			}
+
+			s.required = append(s.required, str)
+		}
+	}
+
+	if a, ok := object["additionalProperties"]; ok {
+		if s.additional, err = compile(a, path+"/additionalProperties", defs); err != nil {
+			return nil, err
+		}
+	}
+
+	if i, ok := object["items"]; ok {
+		if s.items, err = compile(i, path+"/items", defs); err != nil {
+			return nil, err
+		}
+	}
+
+	if u, ok := object["uniqueItems"]; ok {
+		if s.uniqueItems, ok = u.(bool); !ok {
+			return nil, invalid(path+"/uniqueItems", "should be a boolean")
+		}
+	}
+
+	counts := map[string]**int{
+		"minProperties": &s.minProperties,
+		"maxProperties": &s.maxProperties,
+		"minItems":      &s.minItems,
+		"maxItems":      &s.maxItems,
+		"minLength":     &s.minLength,
+		"maxLength":     &s.maxLength,
+	}
+	for keyword, dst := range counts {
+		if c, ok := object[keyword]; ok {
+			if *dst, err = compileCount(c, path+"/"+keyword); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	bounds := map[string]**float64{
+		"minimum":          &s.minimum,
+		"maximum":          &s.maximum,
+		"exclusiveMinimum": &s.exclusiveMinimum,
+		"exclusiveMaximum": &s.exclusiveMaximum,
+	}
+	for keyword, dst := range bounds {
+		if b, ok := object[keyword]; ok {
+			n, ok := number(b)
+			if !ok {
+				return nil, invalid(path+"/"+keyword, "should be a number")
+			}
+
+			*dst = &n
+		}
+	}
+
+	if p, ok := object["pattern"]; ok {
+		str, ok := p.(string)
+		if !ok {
+			return nil, invalid(path+"/pattern", "should be a string")
+		}
+
+		if s.pattern, err = regexp.Compile(str); err != nil {
+			return nil, invalid(path+"/pattern", err.Error())
+		}
+	}
+
+	combinators := map[string]*[]*Schema{
+		"allOf": &s.allOf,
+		"anyOf": &s.anyOf,
+		"oneOf": &s.oneOf,
+	}
+	for keyword, dst := range combinators {
+		if c, ok := object[keyword]; ok {
+			schemas, ok := c.([]interface{})
+			if !ok || len(schemas) == 0 {
+				return nil, invalid(path+"/"+keyword, "should be a non-empty array")
+			}
+
+			for i, schema := range schemas {
+				compiled, err := compile(schema, path+"/"+keyword+"/"+strconv.Itoa(i), defs)
+				if err != nil {
+					return nil, err
+				}
+
+				*dst = append(*dst, compiled)
+			}
+		}
+	}
+
+	if n, ok := object["not"]; ok {
+		if s.not, err = compile(n, path+"/not", defs); err != nil {
+			return nil, err
+		}
+	}
+
+	if d, ok := object["$defs"]; ok {
+		definitions, ok := d.(map[string]interface{})
+		if !ok {
+			return nil, invalid(path+"/$defs", "should be an object")
+		}
+
+		for name, definition := range definitions {
+			defPath := path + "/$defs/" + escape(name)
+			if defs[defPath], err = compile(definition, defPath, defs); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if r, ok := object["$ref"]; ok {
+		ref, ok := r.(string)
+		if !ok || !strings.HasPrefix(ref, "#") {
+			return nil, invalid(path+"/$ref", "only the references to the same schema such as #/$defs/name are supported")
+		}
+
+		s.ref = ref
+	}
+
+	return s, nil
+}
+
+// resolve links the references to the definitions, every reference should point to one of them.
+func (s *Schema) resolve(defs map[string]*Schema, seen map[*Schema]struct{}) error {
+	if _, ok := seen[s]; ok {
+		return nil
+	}
+	seen[s] = struct{}{}
+
+	if s.ref != "" {
+		if _, ok := defs[strings.TrimPrefix(s.ref, "#")]; !ok {
+			return invalid(s.ref, "the reference does not point to a definition")
+		}
+
+		s.defs = defs
+	}
+
+	children := make([]*Schema, 0, len(s.properties)+len(s.allOf)+len(s.anyOf)+len(s.oneOf)+3)
+	for _, property := range s.properties {
+		children = append(children, property)
+	}
+	children = append(children, s.allOf...)
+	children = append(children, s.anyOf...)
+	children = append(children, s.oneOf...)
+	for _, child := range []*Schema{s.additional, s.items, s.not} {
+		if child != nil {
+			children = append(children, child)
+		}
+	}
+	for _, def := range defs {
+		children = append(children, def)
+	}
+
+	for _, child := range children {
+		if err := child.resolve(defs, seen); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func compileTypes(value interface{}, path string) ([]string, error) {
+	var names []interface{}
+	switch t := value.(type) {
+	case string:
+		names = []interface{}{t}
+	case []interface{}:
+		names = t
+	default:
+		return nil, invalid(path, "should be a string or an array of strings")
+	}
+
+	types := make([]string, 0, len(names))
+	for _, name := range names {
+		switch name {
+		case "null", "boolean", "object", "array", "number", "integer", "string":
+			types = append(types, name.(string))
+		default:
+			return nil, invalid(path, fmt.Sprintf("unknown type %v", name))
+		}
+	}
+
+	return types, nil
+}
+
+func compileCount(value interface{}, path string) (*int, error) {
+	n, ok := number(value)
+	if !ok || n < 0 || n != math.Trunc(n) {
+		return nil, invalid(path, "should be a non-negative integer")
+	}
+
+	count := int(n)
+	return &count, nil
+}
+
+func invalid(path string, message string) error {
+	if path == "" {
+		path = "/"
+	}
+
+	return fmt.Errorf("%w: %s: %s", ErrInvalidSchema, path, message)
+}
+
+func (s *Schema) validate(value interface{}, path string, violations *[]Violation) {
+	if len(*violations) > MaxViolations {
+		return
+	}
+
+	report := func(format string, args ...interface{}) {
+		*violations = append(*violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if s.always != nil {
+		if !*s.always {
+			report("no value is allowed here")
+		}
+
+		return
+	}
+
+	if s.ref != "" {
+		s.defs[strings.TrimPrefix(s.ref, "#")].validate(value, path, violations)
+	}
+
+	if len(s.types) != 0 && !hasType(value, s.types) {
+		report("should be %s, got %s", strings.Join(s.types, " or "), typeOf(value))
+		return
+	}
+
+	if s.enum != nil {
+		found := false
+		for _, candidate := range s.enum {
+			if equal(value, candidate) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			report("should be one of the enum values")
+		}
+	}
+
+	if s.constant != nil && !equal(value, *s.constant) {
+		report("should be equal to the const value")
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		s.validateObject(v, path, violations, report)
+	case []interface{}:
+		s.validateArray(v, path, violations, report)
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.minLength != nil && length < *s.minLength {
+			report("should be at least %d characters long", *s.minLength)
+		}
+
+		if s.maxLength != nil && length > *s.maxLength {
+			report("should be at most %d characters long", *s.maxLength)
+		}
+
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			report("should match the pattern %s", s.pattern.String())
+		}
+	case json.Number:
+		n, _ := number(v)
+		if s.minimum != nil && n < *s.minimum {
+			report("should be at least %v", *s.minimum)
+		}
+
+		if s.maximum != nil && n > *s.maximum {
+			report("should be at most %v", *s.maximum)
+		}
+
+		if s.exclusiveMinimum != nil && n <= *s.exclusiveMinimum {
+			report("should be greater than %v", *s.exclusiveMinimum)
+		}
+
+		if s.exclusiveMaximum != nil && n >= *s.exclusiveMaximum {
+			report("should be less than %v", *s.exclusiveMaximum)
+		}
+	}
+
+	for _, schema := range s.allOf {
+		schema.validate(value, path, violations)
+	}
+
+	if s.anyOf != nil && matching(s.anyOf, value) == 0 {
+		report("should match at least one of the anyOf schemas")
+	}
+
+	if s.oneOf != nil && matching(s.oneOf, value) != 1 {
+		report("should match exactly one of the oneOf schemas")
+	}
+
+	if s.not != nil && s.not.matches(value) {
+		report("should not match the not schema")
+	}
+}
+
+func (s *Schema) validateObject(object map[string]interface{}, path string, violations *[]Violation, report func(format string, args ...interface{})) {
+	if s.minProperties != nil && len(object) < *s.minProperties {
+		report("should have at least %d properties", *s.minProperties)
+	}
+
+	if s.maxProperties != nil && len(object) > *s.maxProperties {
+		report("should have at most %d properties", *s.maxProperties)
+	}
+
+	for _, name := range s.required {
+		if _, ok := object[name]; !ok {
+			*violations = append(*violations, Violation{Path: path + "/" + escape(name), Message: "is required"})
+		}
+	}
+
+	for name, property := range object {
+		if schema, ok := s.properties[name]; ok {
+			schema.validate(property, path+"/"+escape(name), violations)
+		} else if s.additional != nil {
+			if s.additional.always != nil && !*s.additional.always {
+				*violations = append(*violations, Violation{Path: path + "/" + escape(name), Message: "is not allowed"})
+				continue
+			}
+
+			s.additional.validate(property, path+"/"+escape(name), violations)
+		}
+	}
+}
+
+func (s *Schema) validateArray(array []interface{}, path string, violations *[]Violation, report func(format string, args ...interface{})) {
+	if s.minItems != nil && len(array) < *s.minItems {
+		report("should have at least %d items", *s.minItems)
+	}
+
+	if s.maxItems != nil && len(array) > *s.maxItems {
+		report("should have at most %d items", *s.maxItems)
+	}
+
+	if s.uniqueItems {
+		for i := range array {
+			for j := i + 1; j < len(array); j++ {
+				if equal(array[i], array[j]) {
+					report("should have unique items, %d and %d are equal", i, j)
+				}
+			}
+		}
+	}
+
+	if s.items != nil {
+		for i, item := range array {
+			s.items.validate(item, path+"/"+strconv.Itoa(i), violations)
+		}
+	}
+}
+
+func (s *Schema) matches(value interface{}) bool {
+	var violations []Violation
+	s.validate(value, "", &violations)
+	return len(violations) == 0
+}
+
+func matching(schemas []*Schema, value interface{}) int {
+	count := 0
+	for _, schema := range schemas {
+		if schema.matches(value) {
+			count++
+		}
+	}
+
+	return count
+}
+
+func hasType(value interface{}, types []string) bool {
+	actual := typeOf(value)
+	for _, t := range types {
+		if t == actual || (t == "number" && actual == "integer") {
+			return true
+		}
+	}
+
+	return false
+}
+
+func typeOf(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		if n, ok := number(v); ok && n == math.Trunc(n) {
+			return "integer"
+		}
+
+		return "number"
+	default:
+		return "unknown"
+	}
+}
+
+func number(value interface{}) (float64, bool) {
+	n, ok := value.(json.Number)
+	if !ok {
+		return 0, false
+	}
+
+	f, err := n.Float64()
+	if err != nil {
+		return 0, false
+	}
+
+	return f, true
+}
+
+// equal compares the decoded JSON values, the numbers are equal if their values are, so 1 equals 1.0.
+func equal(a interface{}, b interface{}) bool {
+	switch av := a.(type) {
+	case json.Number:
+		an, aok := number(av)
+		bn, bok := number(b)
+		return aok && bok && an == bn
+	case map[string]interface{}:
+		bv, ok := b.(map[string]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for key, value := range av {
+			other, ok := bv[key]
+			if !ok || !equal(value, other) {
+				return false
+			}
+		}
+
+		return true
+	case []interface{}:
+		bv, ok := b.([]interface{})
+		if !ok || len(av) != len(bv) {
+			return false
+		}
+
+		for i := range av {
+			if !equal(av[i], bv[i]) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return a == b
+	}
+}
+
+// escape makes a JSON Pointer reference token of the name.
+func escape(name string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+}
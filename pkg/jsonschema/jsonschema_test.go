@@ -0,0 +1,99 @@
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const bannerSchema = `{
+	"$schema": "https://json-schema.org/draft/2020-12/schema",
+	"type": "object",
+	"required": ["title", "url"],
+	"properties": {
+		"title": {"type": "string", "minLength": 1, "maxLength": 10},
+		"url": {"type": "string", "pattern": "^https://"},
+		"priority": {"type": "integer", "minimum": 1, "exclusiveMaximum": 10},
+		"kind": {"enum": ["promo", "info"]},
+		"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2, "uniqueItems": true},
+		"button": {"$ref": "#/$defs/button"}
+	},
+	"additionalProperties": false,
+	"$defs": {
+		"button": {"type": "object", "required": ["text"], "properties": {"text": {"type": "string"}}}
+	}
+}`
+
+func TestCompile(t *testing.T) {
+	_, err := Compile([]byte(bannerSchema))
+	require.NoError(t, err)
+
+	for _, schema := range []string{
+		``,
+		`[]`,
+		`{"type": "text"}`,
+		`{"required": "title"}`,
+		`{"minLength": -1}`,
+		`{"maxItems": 1.5}`,
+		`{"minimum": "1"}`,
+		`{"pattern": "("}`,
+		`{"anyOf": []}`,
+		`{"properties": {"title": 1}}`,
+		`{"$ref": "#/$defs/missing"}`,
+		`{"$ref": "https://example.com/schema.json"}`,
+		`{"if": {"type": "string"}, "then": {"minLength": 1}, "else": {"minimum": 1}}`,
+		`{"patternProperties": {"^x-": {"type": "string"}}}`,
+		`{"dependentRequired": {"url": ["title"]}}`,
+		`{"prefixItems": [{"type": "string"}]}`,
+		`{"contains": {"type": "string"}}`,
+		`{"type": "string", "format": "email"}`,
+		`{"properties": {"title": {"type": "string", "maxLenght": 10}}}`,
+		`{"definitions": {"button": {"type": "object"}}}`,
+	} {
+		_, err := Compile([]byte(schema))
+		require.ErrorIs(t, err, ErrInvalidSchema, schema)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema, err := Compile([]byte(bannerSchema))
+	require.NoError(t, err)
+
+	require.NoError(t, schema.Validate([]byte(`{"title": "hello", "url": "https://example.com", "priority": 2.0, "kind": "promo", "tags": ["a", "b"], "button": {"text": "go"}}`)))
+
+	err = schema.Validate([]byte(`{"title": "", "priority": 10, "kind": "ad", "tags": ["a", "a", "b"], "button": {}, "text": "extra"}`))
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, []Violation{
+		{Path: "/button/text", Message: "is required"},
+		{Path: "/kind", Message: "should be one of the enum values"},
+		{Path: "/priority", Message: "should be less than 10"},
+		{Path: "/tags", Message: "should have at most 2 items"},
+		{Path: "/tags", Message: "should have unique items, 0 and 1 are equal"},
+		{Path: "/text", Message: "is not allowed"},
+		{Path: "/title", Message: "should be at least 1 characters long"},
+		{Path: "/url", Message: "is required"},
+	}, validationErr.Violations)
+
+	err = schema.Validate([]byte(`[]`))
+	require.ErrorAs(t, err, &validationErr)
+	require.Equal(t, []Violation{{Path: "", Message: "should be object, got array"}}, validationErr.Violations)
+}
+
+func TestCombinators(t *testing.T) {
+	schema, err := Compile([]byte(`{"oneOf": [{"type": "integer"}, {"type": "number", "minimum": 5}], "not": {"const": 7}}`))
+	require.NoError(t, err)
+
+	require.NoError(t, schema.Validate([]byte(`1`)))
+	require.NoError(t, schema.Validate([]byte(`5.5`)))
+	require.Error(t, schema.Validate([]byte(`6`)))
+	require.Error(t, schema.Validate([]byte(`7.0`)))
+	require.Error(t, schema.Validate([]byte(`"1"`)))
+
+	schema, err = Compile([]byte(`{"type": "object", "properties": {"children": {"type": "array", "items": {"$ref": "#"}}}, "required": ["name"]}`))
+	require.NoError(t, err)
+
+	require.NoError(t, schema.Validate([]byte(`{"name": "a", "children": [{"name": "b", "children": []}]}`)))
+	require.Error(t, schema.Validate([]byte(`{"name": "a", "children": [{"children": []}]}`)))
+}
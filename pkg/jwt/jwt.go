@@ -11,15 +11,17 @@ import (
 
 type Claims struct {
 	*jwt.RegisteredClaims
-	IsAdmin bool `json:"isAdmin"`
+	IsAdmin bool   `json:"isAdmin"`
+	Login   string `json:"login,omitempty"`
 }
 
-func CreateJWT(isAdmin bool, signingKey []byte, expiresAt time.Time) (string, error) {
+func CreateJWT(login string, isAdmin bool, signingKey []byte, expiresAt time.Time) (string, error) {
 	claims := &Claims{
 		RegisteredClaims: &jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 		IsAdmin: isAdmin,
+		Login:   login,
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -32,6 +34,16 @@ func CreateJWT(isAdmin bool, signingKey []byte, expiresAt time.Time) (string, er
 }
 
 func CheckIsAdminInJWT(tokenString string, signingKey string) (bool, error) {
+	claims, err := ParseJWT(tokenString, signingKey)
+	if err != nil {
+		return false, fmt.Errorf("jwt.CheckIsAdminInJWT: %w", err)
+	}
+
+	return claims.IsAdmin, nil
+}
+
+// ParseJWT returns the claims of a valid token. The tokens issued before the login was added to them have an empty one.
+func ParseJWT(tokenString string, signingKey string) (*Claims, error) {
 	claims := &Claims{}
 
 	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
@@ -39,8 +51,8 @@ func CheckIsAdminInJWT(tokenString string, signingKey string) (bool, error) {
 	})
 
 	if err != nil || !token.Valid {
-		return false, fmt.Errorf("jwt.CheckIsAdminInJWT: %w", appErrors.ErrTokenIsInvalid)
+		return nil, fmt.Errorf("jwt.ParseJWT: %w", appErrors.ErrTokenIsInvalid)
 	}
 
-	return claims.IsAdmin, nil
+	return claims, nil
 }
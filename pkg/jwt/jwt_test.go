@@ -8,7 +8,7 @@ import (
 )
 
 func TestJWT(t *testing.T) {
-	token, err := CreateJWT(false, []byte(""), time.Now().Add(24*time.Hour))
+	token, err := CreateJWT("", false, []byte(""), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
 	_, err = CheckIsAdminInJWT(token, "abc")
@@ -18,16 +18,29 @@ func TestJWT(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, false, isAdmin)
 
-	token, err = CreateJWT(true, []byte(""), time.Now().Add(24*time.Hour))
+	token, err = CreateJWT("", true, []byte(""), time.Now().Add(24*time.Hour))
 	require.NoError(t, err)
 
 	isAdmin, err = CheckIsAdminInJWT(token, "")
 	require.NoError(t, err)
 	require.Equal(t, true, isAdmin)
 
-	expiredStr, err := CreateJWT(false, []byte(""), time.Now().Add(-1*time.Hour))
+	expiredStr, err := CreateJWT("", false, []byte(""), time.Now().Add(-1*time.Hour))
 	require.NoError(t, err)
 
 	_, err = CheckIsAdminInJWT(expiredStr, "")
 	require.Error(t, err)
 }
+
+func TestParseJWT(t *testing.T) {
+	token, err := CreateJWT("admin", true, []byte(""), time.Now().Add(24*time.Hour))
+	require.NoError(t, err)
+
+	claims, err := ParseJWT(token, "")
+	require.NoError(t, err)
+	require.Equal(t, "admin", claims.Login)
+	require.Equal(t, true, claims.IsAdmin)
+
+	_, err = ParseJWT(token, "abc")
+	require.Error(t, err)
+}
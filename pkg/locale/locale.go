@@ -0,0 +1,135 @@
+package locale
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Canonical validates a language tag such as en, de-AT or zh-Hant-TW and returns its canonical form:
+// the language is lower case, the two letter regions are upper case and the four letter scripts are title case.
+func Canonical(tag string) (string, bool) {
+	subtags := strings.Split(strings.ReplaceAll(tag, "_", "-"), "-")
+	if len(subtags[0]) < 2 || len(subtags[0]) > 8 || !isAlpha(subtags[0]) {
+		return "", false
+	}
+
+	subtags[0] = strings.ToLower(subtags[0])
+	for i := 1; i < len(subtags); i++ {
+		subtag := subtags[i]
+		if len(subtag) < 1 || len(subtag) > 8 || !isAlphanumeric(subtag) {
+			return "", false
+		}
+
+		switch {
+		case len(subtag) == 2 && isAlpha(subtag):
+			subtags[i] = strings.ToUpper(subtag)
+		case len(subtag) == 4 && isAlpha(subtag):
+			subtags[i] = strings.ToUpper(subtag[:1]) + strings.ToLower(subtag[1:])
+		default:
+			subtags[i] = strings.ToLower(subtag)
+		}
+	}
+
+	return strings.Join(subtags, "-"), true
+}
+
+// ParseAcceptLanguage returns the canonical tags of the Accept-Language header value ordered by their quality,
+// the tags of the same quality keep their order. The wildcard and the invalid tags are skipped.
+func ParseAcceptLanguage(acceptLanguage string) []string {
+	type preference struct {
+		tag     string
+		quality float64
+	}
+
+	var preferences []preference
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		name, params, _ := strings.Cut(part, ";")
+
+		tag, ok := Canonical(strings.TrimSpace(name))
+		if !ok {
+			continue
+		}
+
+		quality := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+
+			quality = parsed
+		}
+
+		if quality <= 0 {
+			continue
+		}
+
+		preferences = append(preferences, preference{tag: tag, quality: quality})
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+
+	tags := make([]string, 0, len(preferences))
+	for _, p := range preferences {
+		tags = append(tags, p.tag)
+	}
+
+	return tags
+}
+
+// Negotiate picks the best of the available canonical tags for the canonical preferences.
+// For every preference in order it looks for the same tag, then for the tag with the less specific subtags
+// (de for de-AT), and then for any tag of the same language (de-DE for de).
+// Empty string means nothing matches.
+func Negotiate(preferences []string, available []string) string {
+	for _, preference := range preferences {
+		for tag := preference; tag != ""; tag = parent(tag) {
+			for _, candidate := range available {
+				if candidate == tag {
+					return candidate
+				}
+			}
+		}
+
+		language, _, _ := strings.Cut(preference, "-")
+		for _, candidate := range available {
+			if candidateLanguage, _, _ := strings.Cut(candidate, "-"); candidateLanguage == language {
+				return candidate
+			}
+		}
+	}
+
+	return ""
+}
+
+func parent(tag string) string {
+	i := strings.LastIndexByte(tag, '-')
+	if i < 0 {
+		return ""
+	}
+
+	return tag[:i]
+}
+
+func isAlpha(s string) bool {
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isAlphanumeric(s string) bool {
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') {
+			return false
+		}
+	}
+
+	return true
+}
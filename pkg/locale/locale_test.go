@@ -0,0 +1,51 @@
+package locale
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonical(t *testing.T) {
+	testTable := []struct {
+		tag      string
+		expected string
+		ok       bool
+	}{
+		{"en", "en", true},
+		{"DE-at", "de-AT", true},
+		{"zh_hant_tw", "zh-Hant-TW", true},
+		{"es-419", "es-419", true},
+		{"", "", false},
+		{"e", "", false},
+		{"en-", "", false},
+		{"en US", "", false},
+		{"12", "", false},
+		{"*", "", false},
+	}
+
+	for _, testCase := range testTable {
+		tag, ok := Canonical(testCase.tag)
+		require.Equal(t, testCase.ok, ok, testCase.tag)
+		require.Equal(t, testCase.expected, tag, testCase.tag)
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	require.Equal(t, []string{"fr-CH", "fr", "en", "de"}, ParseAcceptLanguage("fr-CH, fr;q=0.9, en;q=0.8, de;q=0.7, *;q=0.5"))
+	require.Equal(t, []string{"en", "de"}, ParseAcceptLanguage("de;q=0.5, en, ru;q=0, it;q=abc"))
+	require.Empty(t, ParseAcceptLanguage(""))
+}
+
+func TestNegotiate(t *testing.T) {
+	available := []string{"en", "de-DE", "pt-BR", "pt"}
+
+	require.Equal(t, "en", Negotiate([]string{"en-GB"}, available))
+	require.Equal(t, "de-DE", Negotiate([]string{"de"}, available))
+	require.Equal(t, "de-DE", Negotiate([]string{"de-AT"}, available))
+	require.Equal(t, "pt", Negotiate([]string{"pt-PT"}, available))
+	require.Equal(t, "pt-BR", Negotiate([]string{"pt-BR"}, available))
+	require.Equal(t, "de-DE", Negotiate([]string{"fr", "de"}, available))
+	require.Empty(t, Negotiate([]string{"fr"}, available))
+	require.Empty(t, Negotiate(nil, available))
+}
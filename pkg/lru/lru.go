@@ -0,0 +1,150 @@
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	size      int64
+	expiresAt time.Time
+}
+
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Entries   int    `json:"entries"`
+	Bytes     int64  `json:"bytes"`
+}
+
+// Cache is an LRU cache bounded by the amount of entries and by their total size.
+// Every entry lives no longer than the ttl of the cache. Zero maxEntries disables the cache.
+type Cache[K comparable, V any] struct {
+	mu         sync.Mutex
+	items      map[K]*list.Element
+	order      *list.List
+	maxEntries int
+	maxBytes   int64
+	ttl        time.Duration
+	bytes      int64
+
+	hits      atomic.Uint64
+	misses    atomic.Uint64
+	evictions atomic.Uint64
+}
+
+func New[K comparable, V any](maxEntries int, maxBytes int64, ttl time.Duration) *Cache[K, V] {
+	return &Cache[K, V]{
+		items:      make(map[K]*list.Element),
+		order:      list.New(),
+		maxEntries: maxEntries,
+		maxBytes:   maxBytes,
+		ttl:        ttl,
+	}
+}
+
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+
+	e := elem.Value.(*entry[K, V])
+	if !time.Now().Before(e.expiresAt) {
+		c.remove(elem)
+		c.misses.Add(1)
+		var zero V
+		return zero, false
+	}
+
+	c.order.MoveToFront(elem)
+	c.hits.Add(1)
+
+	return e.value, true
+}
+
+// Contains reports whether the key is stored and not expired, it affects neither the order of the entries nor the counters.
+func (c *Cache[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+
+	return ok && time.Now().Before(elem.Value.(*entry[K, V]).expiresAt)
+}
+
+// Set stores the value for the ttl of the cache, or for the given ttl if it is shorter.
+func (c *Cache[K, V]) Set(key K, value V, size int64, ttl time.Duration) {
+	if c.maxEntries <= 0 {
+		return
+	}
+
+	ttl = min(ttl, c.ttl)
+	if ttl <= 0 || (c.maxBytes > 0 && size > c.maxBytes) {
+		c.Delete(key)
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, size: size, expiresAt: time.Now().Add(ttl)})
+	c.bytes += size
+
+	for c.order.Len() > c.maxEntries || (c.maxBytes > 0 && c.bytes > c.maxBytes) {
+		c.remove(c.order.Back())
+		c.evictions.Add(1)
+	}
+}
+
+func (c *Cache[K, V]) Delete(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+}
+
+func (c *Cache[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.items = make(map[K]*list.Element)
+	c.order.Init()
+	c.bytes = 0
+}
+
+func (c *Cache[K, V]) Stats() Stats {
+	c.mu.Lock()
+	entries, bytes := c.order.Len(), c.bytes
+	c.mu.Unlock()
+
+	return Stats{
+		Hits:      c.hits.Load(),
+		Misses:    c.misses.Load(),
+		Evictions: c.evictions.Load(),
+		Entries:   entries,
+		Bytes:     bytes,
+	}
+}
+
+func (c *Cache[K, V]) remove(elem *list.Element) {
+	e := c.order.Remove(elem).(*entry[K, V])
+	delete(c.items, e.key)
+	c.bytes -= e.size
+}
@@ -0,0 +1,108 @@
+package lru
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetSet(t *testing.T) {
+	c := New[string, int](2, 0, time.Minute)
+
+	_, ok := c.Get("a")
+	require.False(t, ok)
+
+	c.Set("a", 1, 1, time.Minute)
+	c.Set("b", 2, 1, time.Minute)
+
+	value, ok := c.Get("a")
+	require.True(t, ok)
+	require.Equal(t, 1, value)
+
+	c.Set("c", 3, 1, time.Minute)
+
+	_, ok = c.Get("b")
+	require.False(t, ok)
+
+	value, ok = c.Get("c")
+	require.True(t, ok)
+	require.Equal(t, 3, value)
+
+	c.Delete("c")
+	_, ok = c.Get("c")
+	require.False(t, ok)
+
+	stats := c.Stats()
+	require.Equal(t, uint64(2), stats.Hits)
+	require.Equal(t, uint64(3), stats.Misses)
+	require.Equal(t, uint64(1), stats.Evictions)
+	require.Equal(t, 1, stats.Entries)
+	require.Equal(t, int64(1), stats.Bytes)
+
+	c.Purge()
+	require.Equal(t, 0, c.Stats().Entries)
+}
+
+func TestMaxBytes(t *testing.T) {
+	c := New[string, string](10, 10, time.Minute)
+
+	c.Set("a", "aaaaa", 5, time.Minute)
+	c.Set("b", "bbbbb", 5, time.Minute)
+	c.Set("c", "cc", 2, time.Minute)
+
+	_, ok := c.Get("a")
+	require.False(t, ok)
+	require.Equal(t, int64(7), c.Stats().Bytes)
+
+	c.Set("d", "too large", 11, time.Minute)
+	_, ok = c.Get("d")
+	require.False(t, ok)
+}
+
+func TestTTL(t *testing.T) {
+	c := New[string, int](10, 0, 20*time.Millisecond)
+
+	c.Set("a", 1, 1, time.Hour)
+	c.Set("b", 2, 1, time.Millisecond)
+	c.Set("c", 3, 1, 0)
+
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok := c.Get("a")
+	require.True(t, ok)
+	_, ok = c.Get("b")
+	require.False(t, ok)
+	_, ok = c.Get("c")
+	require.False(t, ok)
+
+	time.Sleep(20 * time.Millisecond)
+
+	_, ok = c.Get("a")
+	require.False(t, ok)
+}
+
+func TestDisabled(t *testing.T) {
+	c := New[string, int](0, 0, time.Minute)
+
+	c.Set("a", 1, 1, time.Minute)
+	_, ok := c.Get("a")
+	require.False(t, ok)
+}
+
+func TestContains(t *testing.T) {
+	c := New[string, int](10, 0, time.Minute)
+
+	c.Set("a", 1, 1, time.Minute)
+	c.Set("b", 2, 1, time.Millisecond)
+
+	time.Sleep(5 * time.Millisecond)
+
+	require.True(t, c.Contains("a"))
+	require.False(t, c.Contains("b"))
+	require.False(t, c.Contains("c"))
+
+	stats := c.Stats()
+	require.Zero(t, stats.Hits)
+	require.Zero(t, stats.Misses)
+}
@@ -0,0 +1,29 @@
+package signature
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"event":"banner.created"}`)
+
+	signature := Sign("secret", 1700000000, body)
+	require.Equal(t, signature, Sign("secret", 1700000000, body))
+	require.NotEqual(t, signature, Sign("other", 1700000000, body))
+	require.NotEqual(t, signature, Sign("secret", 1700000001, body))
+	require.NotEqual(t, signature, Sign("secret", 1700000000, []byte(`{}`)))
+	require.Len(t, signature, len(prefix)+64)
+}
+
+func TestVerify(t *testing.T) {
+	body := []byte(`{"event":"banner.deleted"}`)
+	signature := Sign("secret", 1700000000, body)
+
+	require.True(t, Verify("secret", 1700000000, body, signature))
+	require.False(t, Verify("other", 1700000000, body, signature))
+	require.False(t, Verify("secret", 1700000001, body, signature))
+	require.False(t, Verify("secret", 1700000000, body, signature[len(prefix):]))
+	require.False(t, Verify("secret", 1700000000, body, ""))
+}
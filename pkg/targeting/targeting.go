@@ -0,0 +1,412 @@
+package targeting
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrInvalidRule = errors.New("invalid targeting rule")
+
+// Rule is a parsed targeting expression such as
+//
+//	platform in ("ios", "android") && app_version >= 2.3.0 && country != "RU"
+//
+// The comparisons are joined with &&, || and !, and grouped with parentheses.
+// The values are compared case-insensitively, the ordering operators compare dotted versions part by part,
+// other values are compared as strings. A comparison with an attribute the user does not have is false.
+type Rule struct {
+	root       node
+	attributes []string
+}
+
+// Parse returns a rule which can be matched concurrently.
+func Parse(expr string) (*Rule, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, attributes: make(map[string]struct{})}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.peek().kind != tokenEOF {
+		return nil, p.unexpected()
+	}
+
+	attributes := make([]string, 0, len(p.attributes))
+	for attribute := range p.attributes {
+		attributes = append(attributes, attribute)
+	}
+	slices.Sort(attributes)
+
+	return &Rule{root: root, attributes: attributes}, nil
+}
+
+func (r *Rule) Match(attributes map[string]string) bool {
+	return r.root.match(attributes)
+}
+
+// Attributes returns the sorted names of the attributes the rule depends on.
+func (r *Rule) Attributes() []string {
+	return r.attributes
+}
+
+type node interface {
+	match(attributes map[string]string) bool
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n andNode) match(attributes map[string]string) bool {
+	return n.left.match(attributes) && n.right.match(attributes)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n orNode) match(attributes map[string]string) bool {
+	return n.left.match(attributes) || n.right.match(attributes)
+}
+
+type notNode struct {
+	operand node
+}
+
+func (n notNode) match(attributes map[string]string) bool {
+	return !n.operand.match(attributes)
+}
+
+type comparison struct {
+	attribute string
+	operator  string
+	values    []string
+}
+
+func (n comparison) match(attributes map[string]string) bool {
+	actual, ok := attributes[n.attribute]
+	if !ok {
+		return false
+	}
+
+	switch n.operator {
+	case "==":
+		return strings.EqualFold(actual, n.values[0])
+	case "!=":
+		return !strings.EqualFold(actual, n.values[0])
+	case "in":
+		return slices.ContainsFunc(n.values, func(value string) bool { return strings.EqualFold(actual, value) })
+	case "not in":
+		return !slices.ContainsFunc(n.values, func(value string) bool { return strings.EqualFold(actual, value) })
+	case "<":
+		return compare(actual, n.values[0]) < 0
+	case "<=":
+		return compare(actual, n.values[0]) <= 0
+	case ">":
+		return compare(actual, n.values[0]) > 0
+	case ">=":
+		return compare(actual, n.values[0]) >= 0
+	}
+
+	return false
+}
+
+// compare orders the values as versions if both of them are versions, so that 2.10 is greater than 2.9.
+func compare(a string, b string) int {
+	aParts, aOK := parseVersion(a)
+	bParts, bOK := parseVersion(b)
+	if !aOK || !bOK {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	}
+
+	for i := 0; i < max(len(aParts), len(bParts)); i++ {
+		var aPart, bPart int
+		if i < len(aParts) {
+			aPart = aParts[i]
+		}
+
+		if i < len(bParts) {
+			bPart = bParts[i]
+		}
+
+		if aPart != bPart {
+			if aPart < bPart {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
+
+func parseVersion(s string) ([]int, bool) {
+	parts := strings.Split(s, ".")
+	res := make([]int, 0, len(parts))
+	for _, part := range parts {
+		if part == "" || strings.TrimLeft(part, "0123456789") != "" {
+			return nil, false
+		}
+
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, false
+		}
+
+		res = append(res, n)
+	}
+
+	return res, true
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenValue
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value string
+	pos   int
+}
+
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case isIdentStart(c):
+			start := i
+			for i < len(expr) && isIdentPart(expr[i]) {
+				i++
+			}
+
+			tokens = append(tokens, token{kind: tokenIdent, text: strings.ToLower(expr[start:i]), pos: start})
+		case c >= '0' && c <= '9':
+			start := i
+			for i < len(expr) && (isIdentPart(expr[i]) || expr[i] == '.' || expr[i] == '-') {
+				i++
+			}
+
+			tokens = append(tokens, token{kind: tokenValue, text: expr[start:i], value: expr[start:i], pos: start})
+		case c == '"':
+			start := i
+			var value strings.Builder
+			i++
+			for ; i < len(expr) && expr[i] != '"'; i++ {
+				if expr[i] == '\\' && i+1 < len(expr) {
+					i++
+				}
+
+				value.WriteByte(expr[i])
+			}
+
+			if i == len(expr) {
+				return nil, fmt.Errorf("%w: unterminated string at %d", ErrInvalidRule, start)
+			}
+
+			i++
+			tokens = append(tokens, token{kind: tokenValue, text: expr[start:i], value: value.String(), pos: start})
+		default:
+			operator := ""
+			for _, candidate := range []string{"==", "!=", "<=", ">=", "&&", "||", "<", ">", "!", "(", ")", ","} {
+				if strings.HasPrefix(expr[i:], candidate) {
+					operator = candidate
+					break
+				}
+			}
+
+			if operator == "" {
+				return nil, fmt.Errorf("%w: unexpected character %q at %d", ErrInvalidRule, c, i)
+			}
+
+			tokens = append(tokens, token{kind: tokenOperator, text: operator, pos: i})
+			i += len(operator)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(expr)}), nil
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || (c >= '0' && c <= '9')
+}
+
+type parser struct {
+	tokens     []token
+	pos        int
+	attributes map[string]struct{}
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) accept(kind tokenKind, text string) bool {
+	if t := p.peek(); t.kind == kind && t.text == text {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) unexpected() error {
+	t := p.peek()
+	if t.kind == tokenEOF {
+		return fmt.Errorf("%w: unexpected end of rule", ErrInvalidRule)
+	}
+
+	return fmt.Errorf("%w: unexpected %q at %d", ErrInvalidRule, t.text, t.pos)
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokenOperator, "||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.accept(tokenOperator, "&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.accept(tokenOperator, "!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return notNode{operand: operand}, nil
+	}
+
+	if p.accept(tokenOperator, "(") {
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if !p.accept(tokenOperator, ")") {
+			return nil, p.unexpected()
+		}
+
+		return inner, nil
+	}
+
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	if p.peek().kind != tokenIdent || p.peek().text == "in" || p.peek().text == "not" {
+		return nil, p.unexpected()
+	}
+
+	attribute := p.next().text
+	p.attributes[attribute] = struct{}{}
+
+	if p.accept(tokenIdent, "in") {
+		return p.parseList(attribute, "in")
+	}
+
+	if p.accept(tokenIdent, "not") {
+		if !p.accept(tokenIdent, "in") {
+			return nil, p.unexpected()
+		}
+
+		return p.parseList(attribute, "not in")
+	}
+
+	operator := p.peek()
+	if operator.kind != tokenOperator || !slices.Contains([]string{"==", "!=", "<", "<=", ">", ">="}, operator.text) {
+		return nil, p.unexpected()
+	}
+	p.next()
+
+	value := p.peek()
+	if value.kind != tokenValue {
+		return nil, p.unexpected()
+	}
+	p.next()
+
+	return comparison{attribute: attribute, operator: operator.text, values: []string{value.value}}, nil
+}
+
+func (p *parser) parseList(attribute string, operator string) (node, error) {
+	if !p.accept(tokenOperator, "(") {
+		return nil, p.unexpected()
+	}
+
+	var values []string
+	for {
+		value := p.peek()
+		if value.kind != tokenValue {
+			return nil, p.unexpected()
+		}
+		p.next()
+
+		values = append(values, value.value)
+
+		if p.accept(tokenOperator, ")") {
+			return comparison{attribute: attribute, operator: operator, values: values}, nil
+		}
+
+		if !p.accept(tokenOperator, ",") {
+			return nil, p.unexpected()
+		}
+	}
+}
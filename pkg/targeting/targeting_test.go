@@ -0,0 +1,72 @@
+package targeting
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	rule, err := Parse(`platform in ("ios", "android") && (app_version >= 2.3.0 || Country == "DE")`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"app_version", "country", "platform"}, rule.Attributes())
+
+	for _, expr := range []string{
+		"",
+		"platform",
+		`platform = "ios"`,
+		`platform == `,
+		`platform == "ios`,
+		`platform in "ios"`,
+		`platform in ("ios",)`,
+		`platform not ("ios")`,
+		`(platform == "ios"`,
+		`platform == "ios")`,
+		`platform == "ios" &&`,
+		`"ios" == platform`,
+		`platform == "ios" country == "DE"`,
+	} {
+		_, err := Parse(expr)
+		require.ErrorIs(t, err, ErrInvalidRule, expr)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	attributes := map[string]string{"platform": "iOS", "app_version": "2.10.1", "country": "DE"}
+
+	testTable := []struct {
+		expr     string
+		expected bool
+	}{
+		{`platform == "ios"`, true},
+		{`platform != "ios"`, false},
+		{`platform in ("android", "ios")`, true},
+		{`platform not in ("android", "ios")`, false},
+		{`app_version >= 2.9`, true},
+		{`app_version < 2.10.1`, false},
+		{`app_version <= 2.10.1`, true},
+		{`app_version > "2.10"`, true},
+		{`country == "DE" && platform == "android"`, false},
+		{`country == "DE" || platform == "android"`, true},
+		{`!(country == "RU")`, true},
+		{`language == "en"`, false},
+		{`language != "en"`, false},
+		{`!(language == "en")`, true},
+		{`platform == "android" || country == "DE" && app_version >= 3`, false},
+		{`name == "a \"quoted\" name"`, false},
+	}
+
+	for _, testCase := range testTable {
+		rule, err := Parse(testCase.expr)
+		require.NoError(t, err, testCase.expr)
+		require.Equal(t, testCase.expected, rule.Match(attributes), testCase.expr)
+	}
+}
+
+func TestCompare(t *testing.T) {
+	require.Equal(t, 0, compare("2.0", "2.0.0"))
+	require.Equal(t, 1, compare("2.10", "2.9"))
+	require.Equal(t, -1, compare("1", "1.0.1"))
+	require.Equal(t, -1, compare("2.0-beta", "2.0-rc"))
+	require.Equal(t, 0, compare("abc", "ABC"))
+}
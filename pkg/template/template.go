@@ -0,0 +1,220 @@
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+var (
+	ErrInvalidTemplate = errors.New("invalid template")
+	ErrMissingVariable = errors.New("missing template variable")
+)
+
+// Missing is the policy for the placeholders whose variables were not provided.
+type Missing string
+
+const (
+	// MissingEmpty replaces the placeholder with an empty string.
+	MissingEmpty Missing = "empty"
+	// MissingKeep leaves the placeholder as it is.
+	MissingKeep Missing = "keep"
+	// MissingError fails the rendering.
+	MissingError Missing = "error"
+)
+
+func (m Missing) Valid() bool {
+	return m == MissingEmpty || m == MissingKeep || m == MissingError
+}
+
+// Variables validates the JSON content whose string values have placeholders such as
+//
+//	{"title": "Hi {{user_name}}, your discount is {{ discount }}"}
+//
+// and returns the sorted names of its variables. The names consist of letters, digits and underscores
+// and are case-insensitive, every {{ in a string value has to start a placeholder. The keys are never rendered.
+func Variables(content []byte) ([]string, error) {
+	seen := make(map[string]struct{})
+	_, err := walk(content, func(s string) (string, bool, error) {
+		return expand(s, func(name string, placeholder string) (string, error) {
+			seen[name] = struct{}{}
+			return placeholder, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	variables := make([]string, 0, len(seen))
+	for name := range seen {
+		variables = append(variables, name)
+	}
+	slices.Sort(variables)
+
+	return variables, nil
+}
+
+// Render fills in the placeholders of the content from the variables whose names are in lower case.
+// The values are escaped, so they can't break the JSON. The content without placeholders is returned as is.
+func Render(content []byte, variables map[string]string, missing Missing) ([]byte, error) {
+	return walk(content, func(s string) (string, bool, error) {
+		return expand(s, func(name string, placeholder string) (string, error) {
+			if value, ok := variables[name]; ok {
+				return value, nil
+			}
+
+			switch missing {
+			case MissingKeep:
+				return placeholder, nil
+			case MissingError:
+				return "", fmt.Errorf("%w: %s", ErrMissingVariable, name)
+			default:
+				return "", nil
+			}
+		})
+	})
+}
+
+// walk replaces the string values of the JSON content with the ones returned by render, keeping the rest of the bytes.
+func walk(content []byte, render func(s string) (string, bool, error)) ([]byte, error) {
+	if !json.Valid(content) {
+		return nil, fmt.Errorf("%w: content is not a valid JSON", ErrInvalidTemplate)
+	}
+
+	var res []byte
+	last := 0
+	for i := 0; i < len(content); i++ {
+		if content[i] != '"' {
+			continue
+		}
+
+		end := stringEnd(content, i)
+		if isKey(content, end) {
+			i = end - 1
+			continue
+		}
+
+		var s string
+		if err := json.Unmarshal(content[i:end], &s); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidTemplate, err)
+		}
+
+		rendered, changed, err := render(s)
+		if err != nil {
+			return nil, err
+		}
+
+		if changed {
+			quoted, err := quote(rendered)
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, content[last:i]...)
+			res = append(res, quoted...)
+			last = end
+		}
+
+		i = end - 1
+	}
+
+	if res == nil {
+		return content, nil
+	}
+
+	return append(res, content[last:]...), nil
+}
+
+// stringEnd returns the index after the closing quote of the string starting at start, the content is a valid JSON.
+func stringEnd(content []byte, start int) int {
+	for i := start + 1; i < len(content); i++ {
+		switch content[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+
+	return len(content)
+}
+
+func isKey(content []byte, end int) bool {
+	for i := end; i < len(content); i++ {
+		switch content[i] {
+		case ' ', '\t', '\n', '\r':
+			continue
+		case ':':
+			return true
+		default:
+			return false
+		}
+	}
+
+	return false
+}
+
+// quote does not escape <, > and &, which are left as they are in the content too.
+func quote(s string) ([]byte, error) {
+	var buf bytes.Buffer
+	e := json.NewEncoder(&buf)
+	e.SetEscapeHTML(false)
+	if err := e.Encode(s); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// expand replaces every placeholder of s with the value returned by lookup, it reports whether s had any placeholders.
+func expand(s string, lookup func(name string, placeholder string) (string, error)) (string, bool, error) {
+	if !strings.Contains(s, "{{") {
+		return s, false, nil
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "{{")
+		if start < 0 {
+			b.WriteString(s)
+			return b.String(), true, nil
+		}
+
+		length := strings.Index(s[start:], "}}")
+		if length < 0 {
+			return "", false, fmt.Errorf("%w: unterminated placeholder %q", ErrInvalidTemplate, s[start:])
+		}
+
+		placeholder := s[start : start+length+2]
+		name := strings.TrimSpace(placeholder[2 : len(placeholder)-2])
+		if !isName(name) {
+			return "", false, fmt.Errorf("%w: invalid placeholder %q", ErrInvalidTemplate, placeholder)
+		}
+
+		value, err := lookup(strings.ToLower(name), placeholder)
+		if err != nil {
+			return "", false, err
+		}
+
+		b.WriteString(s[:start])
+		b.WriteString(value)
+		s = s[start+len(placeholder):]
+	}
+}
+
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && (c < '0' || c > '9') && c != '_' {
+			return false
+		}
+	}
+
+	return true
+}
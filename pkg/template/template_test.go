@@ -0,0 +1,56 @@
+package template
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVariables(t *testing.T) {
+	variables, err := Variables([]byte(`{"title": "Hi {{User_Name}}", "{{key}}": ["{{ discount }}%", 1, {"text": "{{user_name}}"}]}`))
+	require.NoError(t, err)
+	require.Equal(t, []string{"discount", "user_name"}, variables)
+
+	for _, content := range []string{
+		`{"title": "Hi {{user_name"}`,
+		`{"title": "Hi {{}}"}`,
+		`{"title": "Hi {{user name}}"}`,
+		`{"title": "Hi {{user-name}}"}`,
+		`{"title": "Hi {{{{user_name}}"}`,
+		`{"title": }`,
+	} {
+		_, err := Variables([]byte(content))
+		require.ErrorIs(t, err, ErrInvalidTemplate, content)
+	}
+}
+
+func TestRender(t *testing.T) {
+	content := []byte(`{"title": "Hi {{user_name}}, your discount is {{ discount }}%", "{{key}}": "{{key}}", "url": "https://example.com/?a=1&b=2"}`)
+	variables := map[string]string{"user_name": "\"Bob\" <b>", "key": "value"}
+
+	testTable := []struct {
+		missing  Missing
+		expected string
+	}{
+		{MissingEmpty, `{"title": "Hi \"Bob\" <b>, your discount is %", "{{key}}": "value", "url": "https://example.com/?a=1&b=2"}`},
+		{MissingKeep, `{"title": "Hi \"Bob\" <b>, your discount is {{ discount }}%", "{{key}}": "value", "url": "https://example.com/?a=1&b=2"}`},
+	}
+
+	for _, testCase := range testTable {
+		rendered, err := Render(content, variables, testCase.missing)
+		require.NoError(t, err)
+		require.Equal(t, testCase.expected, string(rendered))
+	}
+
+	_, err := Render(content, variables, MissingError)
+	require.ErrorIs(t, err, ErrMissingVariable)
+
+	rendered, err := Render(content, map[string]string{"user_name": "Bob", "discount": "10", "key": ""}, MissingError)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"title": "Hi Bob, your discount is 10%", "{{key}}": "", "url": "https://example.com/?a=1&b=2"}`, string(rendered))
+
+	plain := []byte(`{"title": "hello"}`)
+	rendered, err = Render(plain, nil, MissingError)
+	require.NoError(t, err)
+	require.Equal(t, plain, rendered)
+}
@@ -2,21 +2,40 @@
 package e2e
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/caarlos0/env/v6"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/PoorMercymain/bannerify/pkg/api"
+	"github.com/PoorMercymain/bannerify/pkg/signature"
 )
 
 type e2eConfig struct {
 	ServicePort int    `env:"SERVICE_PORT" envDefault:"8080"`
 	ServiceHost string `env:"SERVICE_HOST" envDefault:"bannerify-e2e"`
+	GRPCPort    int    `env:"GRPC_PORT"    envDefault:"9090"`
+	StubHost    string `env:"WEBHOOK_STUB_HOST" envDefault:"e2e"`
+	StubPort    int    `env:"WEBHOOK_STUB_PORT" envDefault:"8091"`
+	OutboxPort  int    `env:"OUTBOX_STUB_PORT"  envDefault:"8092"`
 }
 
 type auth struct {
@@ -55,10 +74,106 @@ type versionListElement struct {
 	IsChosen  bool            `json:"is_chosen"`
 }
 
+type bannerBatchElement struct {
+	Content   json.RawMessage `json:"content"`
+	Err       string          `json:"error"`
+	BannerID  int             `json:"banner_id"`
+	VersionID int             `json:"version_id"`
+	TagID     int             `json:"tag_id"`
+}
+
+type warmUpResult struct {
+	Warmed int `json:"warmed"`
+}
+
 type bannerID struct {
 	ID int `json:"banner_id"`
 }
 
+type experimentID struct {
+	ID int `json:"experiment_id"`
+}
+
+type titledContent struct {
+	Title string `json:"title"`
+}
+
+type errorDetail struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+type errorWithDetails struct {
+	Err     string        `json:"error"`
+	Details []errorDetail `json:"details"`
+}
+
+type schemaCheckElement struct {
+	BannerID  int           `json:"banner_id"`
+	VersionID int           `json:"version_id"`
+	Details   []errorDetail `json:"details"`
+}
+
+type versionStats struct {
+	VersionID   int     `json:"version_id"`
+	Impressions int64   `json:"impressions"`
+	Clicks      int64   `json:"clicks"`
+	CTR         float64 `json:"ctr"`
+}
+
+type bannerChangeEvent struct {
+	ID        string          `json:"-"`
+	FeatureID int             `json:"feature_id"`
+	Content   json.RawMessage `json:"content"`
+	Err       string          `json:"error"`
+}
+
+type webhookCreated struct {
+	ID     int    `json:"webhook_id"`
+	Secret string `json:"secret"`
+}
+
+type webhookEvent struct {
+	Event     string `json:"event"`
+	BannerID  int    `json:"banner_id"`
+	VersionID int    `json:"version_id"`
+}
+
+type webhookDelivery struct {
+	Event          string `json:"event"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	LastStatusCode int    `json:"last_status_code"`
+}
+
+type outboxPayload struct {
+	BannerID int `json:"banner_id"`
+}
+
+type outboxEvent struct {
+	ID        int64         `json:"id"`
+	Type      string        `json:"type"`
+	Payload   outboxPayload `json:"payload"`
+	CreatedAt string        `json:"created_at"`
+}
+
+type auditEntry struct {
+	Actor     string                     `json:"actor"`
+	Action    string                     `json:"action"`
+	BannerID  int                        `json:"banner_id"`
+	VersionID int                        `json:"version_id"`
+	Diff      map[string]json.RawMessage `json:"diff"`
+	RequestID string                     `json:"request_id"`
+}
+
+type bannerStats struct {
+	BannerID    int            `json:"banner_id"`
+	Impressions int64          `json:"impressions"`
+	Clicks      int64          `json:"clicks"`
+	CTR         float64        `json:"ctr"`
+	Versions    []versionStats `json:"versions"`
+}
+
 func buildRequest(httpMethod string, route string, body string, headers [][2]string, cfg e2eConfig) (*http.Request, error) {
 	req, err := http.NewRequest(httpMethod, fmt.Sprintf("http://%s:%d%s", cfg.ServiceHost, cfg.ServicePort, route), strings.NewReader(body))
 	if err != nil {
@@ -554,7 +669,7 @@ func TestGetBanner(t *testing.T) {
 			parsedBody: nil,
 		},
 		{
-			caseName: "get cached banner",
+			caseName: "get banner after update",
 			httpMethod: http.MethodGet,
 			route: "/user_banner?tag_id=1&feature_id=1",
 			body: "",
@@ -597,9 +712,174 @@ func TestGetBanner(t *testing.T) {
 		if testCase.caseName == "get updated banner" {
 			t.Log("cached:", cachedBanner)
 			t.Log("updated:", updatedBanner)
-			require.NotEqual(t, len(cachedBanner), len(updatedBanner))
+			require.Equal(t, updatedBanner, cachedBanner)
+		}
+	}
+}
+
+func TestGetBannerInvalidation(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var chosenID, deletedID, byTagID, byFeatureID bannerID
+	var versions []versionListElement
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin31\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user31\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create banner to choose version of",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2900], \"feature_id\": 2900, \"content\": {\"title\": \"first\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &chosenID,
+		},
+		{
+			caseName: "create banner to delete",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2901], \"feature_id\": 2900, \"content\": {\"title\": \"deleted\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &deletedID,
+		},
+		{
+			caseName: "create banner to delete by tag",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2902], \"feature_id\": 2900, \"content\": {\"title\": \"deleted by tag\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &byTagID,
+		},
+		{
+			caseName: "create banner to delete by feature",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2903], \"feature_id\": 2901, \"content\": {\"title\": \"deleted by feature\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &byFeatureID,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", adminAuthData.Token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	send := func(httpMethod string, route string, body string, expectedStatus int, parsedBody interface{}) {
+		req, err := buildRequest(httpMethod, route, body, [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, expectedStatus, parsedBody, parsedBody != nil)
+	}
+
+	// the banners are read without use_last_revision, so every read after the first one is served from the cache
+	getUserBanner := func(tagID int, featureID int) (int, titledContent) {
+		req, err := buildRequest(http.MethodGet, fmt.Sprintf("/user_banner?tag_id=%d&feature_id=%d", tagID, featureID), "", [][2]string{{"token", userAuthData.Token}}, cfg)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var content titledContent
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&content))
 		}
+
+		return resp.StatusCode, content
+	}
+
+	requireTitle := func(tagID int, featureID int, title string) {
+		status, content := getUserBanner(tagID, featureID)
+		require.Equal(t, http.StatusOK, status)
+		require.Equal(t, title, content.Title)
 	}
+
+	t.Log("choose version")
+
+	send(http.MethodPatch, "/banner/"+strconv.Itoa(chosenID.ID), "{\"content\": {\"title\": \"second\"}}", http.StatusOK, nil)
+	requireTitle(2900, 2900, "second")
+	requireTitle(2900, 2900, "second")
+
+	send(http.MethodGet, "/banner_versions/"+strconv.Itoa(chosenID.ID), "", http.StatusOK, &versions)
+	require.Len(t, versions, 2)
+
+	firstVersion := versions[len(versions)-1]
+	require.False(t, firstVersion.IsChosen)
+
+	send(http.MethodPatch, fmt.Sprintf("/banner_versions/choose/%d?version_id=%d", chosenID.ID, firstVersion.VersionID), "", http.StatusOK, nil)
+	requireTitle(2900, 2900, "first")
+
+	t.Log("delete banner")
+
+	requireTitle(2901, 2900, "deleted")
+	requireTitle(2901, 2900, "deleted")
+
+	send(http.MethodDelete, "/banner/"+strconv.Itoa(deletedID.ID), "", http.StatusNoContent, nil)
+
+	status, _ := getUserBanner(2901, 2900)
+	require.Equal(t, http.StatusNotFound, status)
+
+	t.Log("delete banners by tag")
+
+	requireTitle(2902, 2900, "deleted by tag")
+	requireTitle(2902, 2900, "deleted by tag")
+
+	send(http.MethodDelete, "/banner?tag_id=2902", "", http.StatusAccepted, nil)
+
+	require.Eventually(t, func() bool {
+		status, _ := getUserBanner(2902, 2900)
+		return status == http.StatusNotFound
+	}, time.Second*15, time.Millisecond*500)
+
+	t.Log("delete banners by feature")
+
+	requireTitle(2903, 2901, "deleted by feature")
+	requireTitle(2903, 2901, "deleted by feature")
+
+	send(http.MethodDelete, "/banner?feature_id=2901", "", http.StatusAccepted, nil)
+
+	require.Eventually(t, func() bool {
+		status, _ := getUserBanner(2903, 2901)
+		return status == http.StatusNotFound
+	}, time.Second*15, time.Millisecond*500)
+
+	requireTitle(2900, 2900, "first")
 }
 
 func TestListBanners(t *testing.T) {
@@ -1862,4 +2142,2731 @@ func TestDeleteBannerByTagOrFeature(t *testing.T) {
 			require.NotEqual(t, len(banner), len(secondBanner))
 		}
 	}
+}
+func TestGetBanners(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var banners, userBanners map[string]bannerBatchElement
+
+	var testTable = []testTableElem {
+		{
+			caseName: "no auth",
+			httpMethod: http.MethodGet,
+			route: "/user_banners?tag_id=1000&feature_id=1000,1001",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusUnauthorized,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin12\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user12\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "no feature_id",
+			httpMethod: http.MethodGet,
+			route: "/user_banners?tag_id=1000",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "non-numeric feature_id",
+			httpMethod: http.MethodGet,
+			route: "/user_banners?tag_id=1000&feature_id=1000,a",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1000], \"feature_id\": 1000, \"content\": {\"title\": \"first\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create inactive banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1000], \"feature_id\": 1001, \"content\": {\"title\": \"second\"}, \"is_active\": false}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "get banners admin",
+			httpMethod: http.MethodGet,
+			route: "/user_banners?tag_id=1000&feature_id=1000,1001&feature_id=1002",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &banners,
+		},
+		{
+			caseName: "get banners user",
+			httpMethod: http.MethodGet,
+			route: "/user_banners?tag_id=1000&feature_id=1000,1001,1002",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &userBanners,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if testCase.caseName == "get banners user" {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	require.Len(t, banners, 3)
+	require.NotEmpty(t, banners["1000"].Content)
+	require.NotEmpty(t, banners["1001"].Content)
+	require.NotEmpty(t, banners["1002"].Err)
+
+	require.Len(t, userBanners, 3)
+	require.NotEmpty(t, userBanners["1000"].Content)
+	require.NotEmpty(t, userBanners["1001"].Err)
+	require.NotEmpty(t, userBanners["1002"].Err)
+}
+
+func TestGetBannerSeveralTags(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData auth
+	var firstBanner, secondBanner, prioritizedBanner map[string]string
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin13\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "create banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1100], \"feature_id\": 1100, \"content\": {\"title\": \"first\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1101], \"feature_id\": 1100, \"content\": {\"title\": \"second\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "non-numeric tag in list",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1100,a&feature_id=1100",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "lowest tag wins",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1101,1100&feature_id=1100&use_last_revision=true",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &firstBanner,
+		},
+		{
+			caseName: "only second tag",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1101&tag_id=1102&feature_id=1100&use_last_revision=true",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &secondBanner,
+		},
+		{
+			caseName: "set priority without body",
+			httpMethod: http.MethodPut,
+			route: "/tag_priority/1101",
+			body: "{}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "set priority",
+			httpMethod: http.MethodPut,
+			route: "/tag_priority/1101",
+			body: "{\"priority\": 10}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusNoContent,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "prioritized tag wins",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1100,1101&feature_id=1100&use_last_revision=true",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &prioritizedBanner,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", adminAuthData.Token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	require.Equal(t, "first", firstBanner["title"])
+	require.Equal(t, "second", secondBanner["title"])
+	require.Equal(t, "second", prioritizedBanner["title"])
+}
+
+func TestGetScheduledBanner(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var futureID bannerID
+
+	past := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	future := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin14\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user14\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "invalid window",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: fmt.Sprintf("{\"tag_ids\": [1200], \"feature_id\": 1200, \"content\": {}, \"is_active\": true, \"active_from\": %q, \"active_until\": %q}", future, past),
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create current banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: fmt.Sprintf("{\"tag_ids\": [1200], \"feature_id\": 1200, \"content\": {}, \"is_active\": true, \"active_from\": %q, \"active_until\": %q}", past, future),
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create future banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: fmt.Sprintf("{\"tag_ids\": [1201], \"feature_id\": 1200, \"content\": {}, \"is_active\": true, \"active_from\": %q}", future),
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &futureID,
+		},
+		{
+			caseName: "user gets current banner",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1200&feature_id=1200",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "user does not get future banner",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1201&feature_id=1200",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusNotFound,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "admin gets future banner",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1201&feature_id=1200",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	send := func(token string, httpMethod string, route string, body string, expectedStatus int) {
+		req, err := buildRequest(httpMethod, route, body, [][2]string{{"Content-Type", "application/json"}, {"token", token}}, cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, expectedStatus, nil, false)
+	}
+
+	send(adminAuthData.Token, http.MethodPatch, "/banner/"+strconv.Itoa(futureID.ID), fmt.Sprintf("{\"active_from\": %q, \"active_until\": null}", future), http.StatusOK)
+	send(userAuthData.Token, http.MethodGet, "/user_banner?tag_id=1201&feature_id=1200", "", http.StatusNotFound)
+
+	send(adminAuthData.Token, http.MethodPatch, "/banner/"+strconv.Itoa(futureID.ID), "{\"active_from\": null}", http.StatusOK)
+	send(userAuthData.Token, http.MethodGet, "/user_banner?tag_id=1201&feature_id=1200", "", http.StatusOK)
+	send(userAuthData.Token, http.MethodGet, "/user_banner?tag_id=1201&feature_id=1200&use_last_revision=true", "", http.StatusOK)
+}
+
+func TestGetBannerNotModified(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin15\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user15\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1300], \"feature_id\": 1300, \"content\": {\"title\": \"etag\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	req, err := buildRequest(http.MethodGet, "/user_banner?tag_id=1300&feature_id=1300", "", [][2]string{{"token", userAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+	require.True(t, strings.HasPrefix(resp.Header.Get("Cache-Control"), "public, max-age="))
+
+	req, err = buildRequest(http.MethodGet, "/user_banner?tag_id=1300&feature_id=1300", "", [][2]string{{"token", userAuthData.Token}, {"If-None-Match", etag}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusNotModified, nil, false)
+
+	req, err = buildRequest(http.MethodGet, "/user_banner?tag_id=1300&feature_id=1300", "", [][2]string{{"token", userAuthData.Token}, {"If-None-Match", "\"other\""}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, nil, false)
+}
+
+func TestCacheWarmUp(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var warmUpResult warmUpResult
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin16\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user16\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "user warm up",
+			httpMethod: http.MethodPost,
+			route: "/cache_warmup",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusForbidden,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1400], \"feature_id\": 1400, \"content\": {}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "warm up",
+			httpMethod: http.MethodPost,
+			route: "/cache_warmup",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &warmUpResult,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	require.Positive(t, warmUpResult.Warmed)
+}
+
+func TestGetMissingBanner(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin17\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user17\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "user get missing banner",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1500&feature_id=1500",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusNotFound,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "user get cached missing banner",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1500&feature_id=1500",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusNotFound,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1500], \"feature_id\": 1500, \"content\": {\"title\": \"missing\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "user get created banner",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1500&feature_id=1500",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+}
+
+func TestExperiment(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var id bannerID
+	var versions []versionListElement
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin18\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user18\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1600], \"feature_id\": 1600, \"content\": {\"title\": \"a\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &id,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	req, err := buildRequest(http.MethodPatch, fmt.Sprintf("/banner/%d", id.ID), "{\"content\": {\"title\": \"b\"}}", [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, nil, false)
+
+	req, err = buildRequest(http.MethodGet, fmt.Sprintf("/banner_versions/%d", id.ID), "", [][2]string{{"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, &versions, true)
+	require.Len(t, versions, 2)
+
+	var experiment experimentID
+	body := fmt.Sprintf("{\"feature_id\": 1600, \"tag_id\": 1600, \"variants\": [{\"key\": \"a\", \"version_id\": %d, \"weight\": 50}, {\"key\": \"b\", \"version_id\": %d, \"weight\": 50}]}", versions[0].VersionID, versions[1].VersionID)
+	req, err = buildRequest(http.MethodPost, "/experiment", body, [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusCreated, &experiment, true)
+
+	req, err = buildRequest(http.MethodPost, "/experiment", body, [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusConflict, nil, false)
+
+	req, err = buildRequest(http.MethodPost, "/experiment", body, [][2]string{{"Content-Type", "application/json"}, {"token", userAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusForbidden, nil, false)
+
+	// the pair has no banner, so the variants would never be served in place of it
+	withoutBanner := fmt.Sprintf("{\"feature_id\": 1600, \"tag_id\": 1601, \"variants\": [{\"key\": \"a\", \"version_id\": %d, \"weight\": 50}, {\"key\": \"b\", \"version_id\": %d, \"weight\": 50}]", versions[0].VersionID, versions[1].VersionID)
+	req, err = buildRequest(http.MethodPost, "/experiment", withoutBanner+"}", [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusConflict, nil, false)
+
+	var inactiveExperiment experimentID
+	req, err = buildRequest(http.MethodPost, "/experiment", withoutBanner+", \"is_active\": false}", [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusCreated, &inactiveExperiment, true)
+
+	req, err = buildRequest(http.MethodPatch, fmt.Sprintf("/experiment/%d", inactiveExperiment.ID), "{\"is_active\": true}", [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusConflict, nil, false)
+
+	var variant string
+	for i := 0; i < 2; i++ {
+		req, err = buildRequest(http.MethodGet, "/user_banner?tag_id=1600&feature_id=1600&user_id=user18", "", [][2]string{{"token", userAuthData.Token}}, cfg)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Contains(t, []string{"a", "b"}, resp.Header.Get("X-Experiment-Variant"))
+		if i > 0 {
+			require.Equal(t, variant, resp.Header.Get("X-Experiment-Variant"))
+		}
+		variant = resp.Header.Get("X-Experiment-Variant")
+	}
+
+	req, err = buildRequest(http.MethodDelete, fmt.Sprintf("/experiment/%d", experiment.ID), "", [][2]string{{"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusNoContent, nil, false)
+
+	req, err = buildRequest(http.MethodDelete, fmt.Sprintf("/experiment/%d", experiment.ID), "", [][2]string{{"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusNotFound, nil, false)
+
+	req, err = buildRequest(http.MethodGet, "/user_banner?tag_id=1600&feature_id=1600&user_id=user18", "", [][2]string{{"token", userAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Empty(t, resp.Header.Get("X-Experiment-Variant"))
+}
+
+func TestTargeting(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var iosBanner, headerBanner, fallbackBanner titledContent
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin19\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user19\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create banner with invalid targeting",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1700], \"feature_id\": 1700, \"content\": {\"title\": \"ios\"}, \"is_active\": true, \"targeting\": \"platform = \\\"ios\\\"\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create targeted banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1700], \"feature_id\": 1700, \"content\": {\"title\": \"ios\"}, \"is_active\": true, \"targeting\": \"platform == \\\"ios\\\" && app_version >= 2.3\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "user get targeted banner without attributes",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1700&feature_id=1700",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusNotFound,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create fallback banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1701], \"feature_id\": 1700, \"content\": {\"title\": \"fallback\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "user get targeted banner by query",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1700&tag_id=1701&feature_id=1700&platform=ios&app_version=2.10",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &iosBanner,
+		},
+		{
+			caseName: "user get targeted banner by headers",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1700&tag_id=1701&feature_id=1700",
+			body: "",
+			headers: [][2]string{{"X-User-Platform", "iOS"}, {"X-User-App-Version", "2.3.0"}},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &headerBanner,
+		},
+		{
+			caseName: "user get fallback banner",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1700&tag_id=1701&feature_id=1700&platform=ios&app_version=2.2",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &fallbackBanner,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	require.Equal(t, "ios", iosBanner.Title)
+	require.Equal(t, "ios", headerBanner.Title)
+	require.Equal(t, "fallback", fallbackBanner.Title)
+}
+
+func TestLocalizedBanner(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var defaultBanner, queryBanner, headerBanner titledContent
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin20\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user20\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create banner with invalid locale",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1800], \"feature_id\": 1800, \"content\": {\"title\": \"hello\"}, \"is_active\": true, \"default_locale\": \"en\", \"locales\": {\"d e\": {\"title\": \"hallo\"}}}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create localized banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1800], \"feature_id\": 1800, \"content\": {\"title\": \"hello\"}, \"is_active\": true, \"default_locale\": \"en\", \"locales\": {\"de\": {\"title\": \"hallo\"}, \"fr\": {\"title\": \"bonjour\"}}}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "user get banner in default locale",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1800&feature_id=1800",
+			body: "",
+			headers: [][2]string{{"Accept-Language", "it"}},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &defaultBanner,
+		},
+		{
+			caseName: "user get banner by locale parameter",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1800&feature_id=1800&locale=fr-CA",
+			body: "",
+			headers: [][2]string{{"Accept-Language", "de"}},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &queryBanner,
+		},
+		{
+			caseName: "user get banner by accept language",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1800&feature_id=1800",
+			body: "",
+			headers: [][2]string{{"Accept-Language", "it, de-AT;q=0.8, en;q=0.5"}},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &headerBanner,
+		},
+		{
+			caseName: "user get banner with invalid locale",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1800&feature_id=1800&locale=1",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	require.Equal(t, "hello", defaultBanner.Title)
+	require.Equal(t, "bonjour", queryBanner.Title)
+	require.Equal(t, "hallo", headerBanner.Title)
+
+	req, err := buildRequest(http.MethodGet, "/user_banner?tag_id=1800&feature_id=1800", "", [][2]string{{"token", userAuthData.Token}, {"Accept-Language", "de"}}, cfg)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "de", resp.Header.Get("Content-Language"))
+}
+
+func TestTemplateBanner(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var id bannerID
+	var queryBanner, headerBanner, keptBanner titledContent
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin21\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user21\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create banner with invalid template",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1900], \"feature_id\": 1900, \"content\": {\"title\": \"Hi {{user name}}\"}, \"is_active\": true, \"is_template\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create banner with invalid missing variables policy",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1900], \"feature_id\": 1900, \"content\": {\"title\": \"Hi {{user_name}}\"}, \"is_active\": true, \"is_template\": true, \"missing_variables\": \"ignore\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create template banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [1900], \"feature_id\": 1900, \"content\": {\"title\": \"Hi {{user_name}}, your discount is {{ discount }}%\"}, \"is_active\": true, \"is_template\": true, \"missing_variables\": \"error\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &id,
+		},
+		{
+			caseName: "user get banner rendered from query",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1900&feature_id=1900&user_name=Bob&discount=10",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &queryBanner,
+		},
+		{
+			caseName: "user get banner rendered from headers",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1900&feature_id=1900&discount=15",
+			body: "",
+			headers: [][2]string{{"X-User-User-Name", "\"Alice\""}},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &headerBanner,
+		},
+		{
+			caseName: "user get banner without variable",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=1900&feature_id=1900&user_name=Bob",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	require.Equal(t, "Hi Bob, your discount is 10%", queryBanner.Title)
+	require.Equal(t, "Hi \"Alice\", your discount is 15%", headerBanner.Title)
+
+	req, err := buildRequest(http.MethodPatch, fmt.Sprintf("/banner/%d", id.ID), "{\"content\": {\"title\": \"Hi {{user_name\"}}", [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusBadRequest, nil, false)
+
+	req, err = buildRequest(http.MethodPatch, fmt.Sprintf("/banner/%d", id.ID), "{\"missing_variables\": \"keep\"}", [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, nil, false)
+
+	req, err = buildRequest(http.MethodGet, "/user_banner?tag_id=1900&feature_id=1900&user_name=Bob&use_last_revision=true", "", [][2]string{{"token", userAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, &keptBanner, true)
+	require.Equal(t, "Hi Bob, your discount is {{ discount }}%", keptBanner.Title)
+}
+
+func TestFeatureSchema(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var id bannerID
+	var checked []schemaCheckElement
+	var violation errorWithDetails
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin22\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user22\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create banner before schema",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2000], \"feature_id\": 2000, \"content\": {\"title\": 5}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &id,
+		},
+		{
+			caseName: "user set schema",
+			httpMethod: http.MethodPut,
+			route: "/feature/2000/schema",
+			body: "{\"type\": \"object\", \"required\": [\"title\"], \"properties\": {\"title\": {\"type\": \"string\", \"minLength\": 1}}}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusForbidden,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "check schema",
+			httpMethod: http.MethodPost,
+			route: "/feature/2000/schema/check",
+			body: "{\"type\": \"object\", \"required\": [\"title\"], \"properties\": {\"title\": {\"type\": \"string\", \"minLength\": 1}}}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusOK,
+			requireParsing: true,
+			parsedBody: &checked,
+		},
+		{
+			caseName: "set invalid schema",
+			httpMethod: http.MethodPut,
+			route: "/feature/2000/schema",
+			body: "{\"type\": \"strange\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "get schema before it is set",
+			httpMethod: http.MethodGet,
+			route: "/feature/2000/schema",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusNotFound,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "set schema",
+			httpMethod: http.MethodPut,
+			route: "/feature/2000/schema",
+			body: "{\"type\": \"object\", \"required\": [\"title\"], \"properties\": {\"title\": {\"type\": \"string\", \"minLength\": 1}}}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusOK,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "get schema",
+			httpMethod: http.MethodGet,
+			route: "/feature/2000/schema",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusOK,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create banner not conforming to schema",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2001], \"feature_id\": 2000, \"content\": {\"title\": \"\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: true,
+			parsedBody: &violation,
+		},
+		{
+			caseName: "create banner conforming to schema",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2001], \"feature_id\": 2000, \"content\": {\"title\": \"hello\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "delete schema",
+			httpMethod: http.MethodDelete,
+			route: "/feature/2000/schema",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusNoContent,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "delete deleted schema",
+			httpMethod: http.MethodDelete,
+			route: "/feature/2000/schema",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusNotFound,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	require.Len(t, checked, 1)
+	require.Equal(t, id.ID, checked[0].BannerID)
+	require.Equal(t, "/content/title", checked[0].Details[0].Path)
+
+	require.NotEmpty(t, violation.Details)
+	require.Equal(t, "/content/title", violation.Details[0].Path)
+}
+
+func TestFallbackBanner(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var fallbackID, otherFeatureID bannerID
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin23\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user23\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create tagged banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2100], \"feature_id\": 2100, \"content\": {\"title\": \"tagged\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create fallback banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2101], \"feature_id\": 2100, \"content\": {\"title\": \"fallback\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &fallbackID,
+		},
+		{
+			caseName: "create banner of other feature",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2100], \"feature_id\": 2101, \"content\": {\"title\": \"other\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &otherFeatureID,
+		},
+		{
+			caseName: "user get banner without fallback",
+			httpMethod: http.MethodGet,
+			route: "/user_banner?tag_id=2102&feature_id=2100",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusNotFound,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "get fallback before it is set",
+			httpMethod: http.MethodGet,
+			route: "/feature/2100/fallback",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusNotFound,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "set fallback with invalid banner id",
+			httpMethod: http.MethodPut,
+			route: "/feature/2100/fallback",
+			body: "{\"banner_id\": 0}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	req, err := buildRequest(http.MethodPut, "/feature/2100/fallback", fmt.Sprintf("{\"banner_id\": %d}", fallbackID.ID), [][2]string{{"Content-Type", "application/json"}, {"token", userAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusForbidden, nil, false)
+
+	req, err = buildRequest(http.MethodPut, "/feature/2100/fallback", fmt.Sprintf("{\"banner_id\": %d}", otherFeatureID.ID), [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusBadRequest, nil, false)
+
+	req, err = buildRequest(http.MethodPut, "/feature/2100/fallback", fmt.Sprintf("{\"banner_id\": %d}", fallbackID.ID), [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, nil, false)
+
+	var fallback bannerID
+	req, err = buildRequest(http.MethodGet, "/feature/2100/fallback", "", [][2]string{{"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, &fallback, true)
+	require.Equal(t, fallbackID.ID, fallback.ID)
+
+	getUserBanner := func(tagID int) (*http.Response, titledContent) {
+		req, err := buildRequest(http.MethodGet, fmt.Sprintf("/user_banner?tag_id=%d&feature_id=2100", tagID), "", [][2]string{{"token", userAuthData.Token}}, cfg)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var content titledContent
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&content))
+		}
+
+		return resp, content
+	}
+
+	resp, content := getUserBanner(2102)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "true", resp.Header.Get("X-Banner-Fallback"))
+	require.Equal(t, "fallback", content.Title)
+
+	resp, content = getUserBanner(2100)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Empty(t, resp.Header.Get("X-Banner-Fallback"))
+	require.Equal(t, "tagged", content.Title)
+
+	var batch map[int]bannerBatchElement
+	req, err = buildRequest(http.MethodGet, "/user_banners?tag_id=2102&feature_id=2100&feature_id=2101", "", [][2]string{{"token", userAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, &batch, true)
+	require.JSONEq(t, "{\"title\": \"fallback\"}", string(batch[2100].Content))
+	require.NotEmpty(t, batch[2101].Err)
+
+	req, err = buildRequest(http.MethodPatch, fmt.Sprintf("/banner/%d", fallbackID.ID), "{\"content\": {\"title\": \"new fallback\"}}", [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, nil, false)
+
+	resp, content = getUserBanner(2102)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "new fallback", content.Title)
+
+	req, err = buildRequest(http.MethodDelete, "/feature/2100/fallback", "", [][2]string{{"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusNoContent, nil, false)
+
+	resp, _ = getUserBanner(2102)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	req, err = buildRequest(http.MethodDelete, "/feature/2100/fallback", "", [][2]string{{"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusNotFound, nil, false)
+}
+
+func TestBannerEvents(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var createdID bannerID
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin24\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user24\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2200], \"feature_id\": 2200, \"content\": {\"title\": \"tracked\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &createdID,
+		},
+		{
+			caseName: "user send no events",
+			httpMethod: http.MethodPost,
+			route: "/banner_events",
+			body: "[]",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "user send event of unknown type",
+			httpMethod: http.MethodPost,
+			route: "/banner_events",
+			body: "[{\"type\": \"hover\", \"banner_id\": 1, \"version_id\": 1, \"feature_id\": 1, \"tag_id\": 1}]",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "user send event without banner id",
+			httpMethod: http.MethodPost,
+			route: "/banner_events",
+			body: "[{\"type\": \"click\", \"version_id\": 1, \"feature_id\": 1, \"tag_id\": 1}]",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "user get stats",
+			httpMethod: http.MethodGet,
+			route: "/banner_stats",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusForbidden,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "get stats with invalid time",
+			httpMethod: http.MethodGet,
+			route: "/banner_stats?from=yesterday",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "get stats with reversed range",
+			httpMethod: http.MethodGet,
+			route: "/banner_stats?from=2024-02-01T00:00:00Z&to=2024-01-01T00:00:00Z",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	req, err := buildRequest(http.MethodGet, "/user_banner?tag_id=2200&feature_id=2200", "", [][2]string{{"token", userAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, strconv.Itoa(createdID.ID), resp.Header.Get("X-Banner-ID"))
+	require.Equal(t, "2200", resp.Header.Get("X-Banner-Tag-ID"))
+
+	versionID, err := strconv.Atoi(resp.Header.Get("X-Banner-Version-ID"))
+	require.NoError(t, err)
+
+	var batch map[int]bannerBatchElement
+	req, err = buildRequest(http.MethodGet, "/user_banners?tag_id=2200&feature_id=2200", "", [][2]string{{"token", userAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, &batch, true)
+	require.Equal(t, createdID.ID, batch[2200].BannerID)
+	require.Equal(t, versionID, batch[2200].VersionID)
+	require.Equal(t, 2200, batch[2200].TagID)
+
+	event := func(eventType string) string {
+		return fmt.Sprintf("{\"type\": \"%s\", \"banner_id\": %d, \"version_id\": %d, \"feature_id\": 2200, \"tag_id\": 2200}", eventType, createdID.ID, versionID)
+	}
+
+	mismatched := fmt.Sprintf("{\"type\": \"click\", \"banner_id\": %d, \"version_id\": %d, \"feature_id\": 2201, \"tag_id\": 2200}", createdID.ID, versionID)
+	body := "[" + strings.Join([]string{event("impression"), event("impression"), event("impression"), event("impression"), event("click"), mismatched}, ",") + "]"
+
+	req, err = buildRequest(http.MethodPost, "/banner_events", body, [][2]string{{"Content-Type", "application/json"}, {"token", userAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusAccepted, nil, false)
+
+	var stats []bannerStats
+	require.Eventually(t, func() bool {
+		req, err := buildRequest(http.MethodGet, fmt.Sprintf("/banner_stats?banner_id=%d", createdID.ID), "", [][2]string{{"token", adminAuthData.Token}}, cfg)
+		require.NoError(t, err)
+
+		stats = nil
+		sendReq(t, &client, req, http.StatusOK, &stats, true)
+
+		return len(stats) == 1 && stats[0].Impressions == 4
+	}, time.Second*15, time.Millisecond*500)
+
+	require.Equal(t, createdID.ID, stats[0].BannerID)
+	require.Equal(t, int64(1), stats[0].Clicks)
+	require.InDelta(t, 0.25, stats[0].CTR, 1e-9)
+	require.Len(t, stats[0].Versions, 1)
+	require.Equal(t, versionID, stats[0].Versions[0].VersionID)
+
+	req, err = buildRequest(http.MethodGet, fmt.Sprintf("/banner_stats?banner_id=%d&tag_id=2201", createdID.ID), "", [][2]string{{"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	stats = nil
+	sendReq(t, &client, req, http.StatusOK, &stats, true)
+	require.Empty(t, stats)
+
+	req, err = buildRequest(http.MethodGet, fmt.Sprintf("/banner_stats?banner_id=%d&from=2020-01-01T00:00:00Z&to=2020-01-02T00:00:00Z", createdID.ID), "", [][2]string{{"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	stats = nil
+	sendReq(t, &client, req, http.StatusOK, &stats, true)
+	require.Empty(t, stats)
+}
+
+func TestFrequencyCap(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var fallbackID bannerID
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin25\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user25\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create banner with negative frequency cap",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2300], \"feature_id\": 2300, \"content\": {\"title\": \"capped\"}, \"is_active\": true, \"frequency_cap\": -1}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create capped banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2300], \"feature_id\": 2300, \"content\": {\"title\": \"capped\"}, \"is_active\": true, \"frequency_cap\": 2}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create fallback banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2301], \"feature_id\": 2300, \"content\": {\"title\": \"fallback\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &fallbackID,
+		},
+		{
+			caseName: "create capped banner without fallback",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2300], \"feature_id\": 2301, \"content\": {\"title\": \"capped once\"}, \"is_active\": true, \"frequency_cap\": 1}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	req, err := buildRequest(http.MethodPut, "/feature/2300/fallback", fmt.Sprintf("{\"banner_id\": %d}", fallbackID.ID), [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, nil, false)
+
+	getUserBanner := func(route string, token string) (*http.Response, titledContent) {
+		req, err := buildRequest(http.MethodGet, route, "", [][2]string{{"token", token}}, cfg)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		var content titledContent
+		if resp.StatusCode == http.StatusOK {
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&content))
+		}
+
+		return resp, content
+	}
+
+	for range 2 {
+		resp, content := getUserBanner("/user_banner?tag_id=2300&feature_id=2300&user_id=capped-user", userAuthData.Token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "capped", content.Title)
+		require.Equal(t, "no-cache", resp.Header.Get("Cache-Control"))
+	}
+
+	resp, content := getUserBanner("/user_banner?tag_id=2300&feature_id=2300&user_id=capped-user", userAuthData.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "fallback", content.Title)
+	require.Equal(t, "true", resp.Header.Get("X-Banner-Fallback"))
+
+	resp, content = getUserBanner("/user_banner?tag_id=2300&feature_id=2300&user_id=other-user", userAuthData.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "capped", content.Title)
+
+	resp, content = getUserBanner("/user_banner?tag_id=2300&feature_id=2300&user_id=capped-user", adminAuthData.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "capped", content.Title)
+
+	resp, content = getUserBanner("/user_banner?tag_id=2300&feature_id=2300", userAuthData.Token)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	require.Equal(t, "capped", content.Title)
+
+	var batch map[int]bannerBatchElement
+	req, err = buildRequest(http.MethodGet, "/user_banners?tag_id=2300&feature_id=2300&feature_id=2301&user_id=capped-user", "", [][2]string{{"token", userAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, &batch, true)
+	require.JSONEq(t, "{\"title\": \"fallback\"}", string(batch[2300].Content))
+	require.JSONEq(t, "{\"title\": \"capped once\"}", string(batch[2301].Content))
+
+	resp, _ = getUserBanner("/user_banner?tag_id=2300&feature_id=2301&user_id=capped-user", userAuthData.Token)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestBannerStream(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var streamedID bannerID
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin26\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user26\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create streamed banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2400], \"feature_id\": 2400, \"content\": {\"title\": \"streamed\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &streamedID,
+		},
+		{
+			caseName: "user stream without feature",
+			httpMethod: http.MethodGet,
+			route: "/banner_stream?tag_id=2400",
+			body: "",
+			headers: nil,
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "user stream with invalid last event id",
+			httpMethod: http.MethodGet,
+			route: "/banner_stream?tag_id=2400&feature_id=2400",
+			body: "",
+			headers: [][2]string{{"Last-Event-ID", "-1"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	subscribe := func(lastEventID string) (*http.Response, <-chan bannerChangeEvent) {
+		headers := [][2]string{{"token", userAuthData.Token}, {"Accept", "text/event-stream"}}
+		if lastEventID != "" {
+			headers = append(headers, [2]string{"Last-Event-ID", lastEventID})
+		}
+
+		req, err := buildRequest(http.MethodGet, "/banner_stream?tag_id=2400&feature_id=2400,2401", "", headers, cfg)
+		require.NoError(t, err)
+
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+		events := make(chan bannerChangeEvent)
+		go func() {
+			defer close(events)
+
+			var event bannerChangeEvent
+			scanner := bufio.NewScanner(resp.Body)
+			for scanner.Scan() {
+				line := scanner.Text()
+				switch {
+				case strings.HasPrefix(line, "id: "):
+					event.ID = strings.TrimPrefix(line, "id: ")
+				case strings.HasPrefix(line, "data: "):
+					if json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &event) != nil {
+						return
+					}
+				case line == "" && event.ID != "":
+					events <- event
+					event = bannerChangeEvent{}
+				}
+			}
+		}()
+
+		return resp, events
+	}
+
+	next := func(events <-chan bannerChangeEvent) bannerChangeEvent {
+		select {
+		case event, ok := <-events:
+			require.True(t, ok)
+			return event
+		case <-time.After(time.Second * 10):
+			t.Fatal("no banner event received")
+			return bannerChangeEvent{}
+		}
+	}
+
+	resp, events := subscribe("")
+
+	first := next(events)
+	require.Equal(t, 2400, first.FeatureID)
+	require.JSONEq(t, "{\"title\": \"streamed\"}", string(first.Content))
+
+	missing := next(events)
+	require.Equal(t, 2401, missing.FeatureID)
+	require.Equal(t, "requested banner not found", missing.Err)
+
+	req, err := buildRequest(http.MethodPatch, "/banner/"+strconv.Itoa(streamedID.ID), "{\"content\": {\"title\": \"updated\"}}", [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusOK, nil, false)
+
+	updated := next(events)
+	require.Equal(t, 2400, updated.FeatureID)
+	require.JSONEq(t, "{\"title\": \"updated\"}", string(updated.Content))
+
+	resp.Body.Close()
+
+	req, err = buildRequest(http.MethodPost, "/banner", "{\"tag_ids\": [2400], \"feature_id\": 2401, \"content\": {\"title\": \"created\"}, \"is_active\": true}", [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusCreated, nil, false)
+
+	resp, events = subscribe(updated.ID)
+	defer resp.Body.Close()
+
+	created := next(events)
+
+	// the banners changed by the transactions running when the last event was made may be pushed again
+	if created.FeatureID == 2400 {
+		require.JSONEq(t, "{\"title\": \"updated\"}", string(created.Content))
+		created = next(events)
+	}
+
+	require.Equal(t, 2401, created.FeatureID)
+	require.JSONEq(t, "{\"title\": \"created\"}", string(created.Content))
+
+	req, err = buildRequest(http.MethodDelete, "/banner/"+strconv.Itoa(streamedID.ID), "", [][2]string{{"token", adminAuthData.Token}}, cfg)
+	require.NoError(t, err)
+
+	sendReq(t, &client, req, http.StatusNoContent, nil, false)
+
+	deleted := next(events)
+	require.Equal(t, 2400, deleted.FeatureID)
+	require.Equal(t, "requested banner not found", deleted.Err)
+}
+
+
+func TestGRPC(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin27\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user27\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, testCase.headers, cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	conn, err := grpc.NewClient(fmt.Sprintf("%s:%d", cfg.ServiceHost, cfg.GRPCPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	bannerClient := api.NewBannerServiceClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	adminCtx := metadata.AppendToOutgoingContext(ctx, "token", adminAuthData.Token)
+	userCtx := metadata.AppendToOutgoingContext(ctx, "token", userAuthData.Token)
+
+	_, err = bannerClient.GetBanner(ctx, &api.GetBannerRequest{TagIds: []int32{2500}, FeatureId: 2500})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	content, err := structpb.NewValue(map[string]any{"title": "grpc"})
+	require.NoError(t, err)
+
+	isActive := true
+	featureID := int32(2500)
+	banner := &api.BannerFields{TagIds: &api.TagIDs{TagIds: []int32{2500}}, FeatureId: &featureID, Content: content, IsActive: &isActive}
+
+	_, err = bannerClient.CreateBanner(userCtx, &api.CreateBannerRequest{Banner: banner})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = bannerClient.CreateBanner(adminCtx, &api.CreateBannerRequest{Banner: &api.BannerFields{Content: content}})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	created, err := bannerClient.CreateBanner(adminCtx, &api.CreateBannerRequest{Banner: banner})
+	require.NoError(t, err)
+
+	userBanner, err := bannerClient.GetBanner(userCtx, &api.GetBannerRequest{TagIds: []int32{2500}, FeatureId: 2500, UseLastRevision: true})
+	require.NoError(t, err)
+	require.Equal(t, created.BannerId, userBanner.BannerId)
+	require.Equal(t, "grpc", userBanner.Content.GetStructValue().Fields["title"].GetStringValue())
+
+	updatedContent, err := structpb.NewValue(map[string]any{"title": "updated"})
+	require.NoError(t, err)
+
+	_, err = bannerClient.UpdateBanner(adminCtx, &api.UpdateBannerRequest{BannerId: created.BannerId, Banner: &api.BannerFields{Content: updatedContent}})
+	require.NoError(t, err)
+
+	listed, err := bannerClient.ListBanners(adminCtx, &api.ListBannersRequest{FeatureId: &featureID})
+	require.NoError(t, err)
+	require.Len(t, listed.Banners, 1)
+	require.Equal(t, "updated", listed.Banners[0].Content.GetStructValue().Fields["title"].GetStringValue())
+
+	versions, err := bannerClient.ListVersions(adminCtx, &api.ListVersionsRequest{BannerId: created.BannerId})
+	require.NoError(t, err)
+	require.Len(t, versions.Versions, 2)
+
+	firstVersion := versions.Versions[len(versions.Versions)-1]
+	require.False(t, firstVersion.IsChosen)
+
+	_, err = bannerClient.ChooseVersion(adminCtx, &api.ChooseVersionRequest{BannerId: created.BannerId, VersionId: firstVersion.VersionId})
+	require.NoError(t, err)
+
+	userBanner, err = bannerClient.GetBanner(userCtx, &api.GetBannerRequest{TagIds: []int32{2500}, FeatureId: 2500, UseLastRevision: true})
+	require.NoError(t, err)
+	require.Equal(t, "grpc", userBanner.Content.GetStructValue().Fields["title"].GetStringValue())
+
+	_, err = bannerClient.DeleteBanner(adminCtx, &api.DeleteBannerRequest{BannerId: created.BannerId})
+	require.NoError(t, err)
+
+	_, err = bannerClient.GetBanner(userCtx, &api.GetBannerRequest{TagIds: []int32{2500}, FeatureId: 2500, UseLastRevision: true})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = bannerClient.DeleteBanners(adminCtx, &api.DeleteBannersRequest{FeatureId: &featureID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	arrayContent, err := structpb.NewValue([]any{"first", "second"})
+	require.NoError(t, err)
+
+	scalarContent := structpb.NewStringValue("grpc")
+
+	_, err = bannerClient.CreateBanner(adminCtx, &api.CreateBannerRequest{Banner: &api.BannerFields{TagIds: &api.TagIDs{TagIds: []int32{2501}}, FeatureId: &featureID, Content: arrayContent, IsActive: &isActive}})
+	require.NoError(t, err)
+
+	scalarBanner, err := bannerClient.CreateBanner(adminCtx, &api.CreateBannerRequest{Banner: &api.BannerFields{TagIds: &api.TagIDs{TagIds: []int32{2502}}, FeatureId: &featureID, Content: scalarContent, IsActive: &isActive}})
+	require.NoError(t, err)
+
+	userBanner, err = bannerClient.GetBanner(userCtx, &api.GetBannerRequest{TagIds: []int32{2501}, FeatureId: 2500, UseLastRevision: true})
+	require.NoError(t, err)
+	require.Equal(t, []any{"first", "second"}, userBanner.Content.GetListValue().AsSlice())
+
+	userBanner, err = bannerClient.GetBanner(userCtx, &api.GetBannerRequest{TagIds: []int32{2502}, FeatureId: 2500, UseLastRevision: true})
+	require.NoError(t, err)
+	require.Equal(t, "grpc", userBanner.Content.GetStringValue())
+
+	listed, err = bannerClient.ListBanners(adminCtx, &api.ListBannersRequest{FeatureId: &featureID})
+	require.NoError(t, err)
+	require.Len(t, listed.Banners, 2)
+
+	versions, err = bannerClient.ListVersions(adminCtx, &api.ListVersionsRequest{BannerId: scalarBanner.BannerId})
+	require.NoError(t, err)
+	require.Len(t, versions.Versions, 1)
+	require.Equal(t, "grpc", versions.Versions[0].Content.GetStringValue())
+}
+
+func TestWebhooks(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	type received struct {
+		event webhookEvent
+		valid bool
+	}
+
+	var secret atomic.Value
+	secret.Store("")
+
+	var stubStatus atomic.Int32
+	stubStatus.Store(http.StatusNoContent)
+
+	events := make(chan received, 16)
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.StubPort))
+	require.NoError(t, err)
+
+	stub := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		timestamp, err := strconv.ParseInt(r.Header.Get("X-Bannerify-Timestamp"), 10, 64)
+		valid := err == nil && signature.Verify(secret.Load().(string), timestamp, body, r.Header.Get("X-Bannerify-Signature"))
+
+		var event webhookEvent
+		if json.Unmarshal(body, &event) == nil {
+			events <- received{event: event, valid: valid && event.Event == r.Header.Get("X-Bannerify-Event")}
+		}
+
+		w.WriteHeader(int(stubStatus.Load()))
+	})}
+
+	go stub.Serve(listener)
+	defer stub.Close()
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var created webhookCreated
+	var firstID, secondID bannerID
+	var deliveries []webhookDelivery
+
+	stubURL := fmt.Sprintf("http://%s:%d/hook", cfg.StubHost, cfg.StubPort)
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin28\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user28\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "user create webhook",
+			httpMethod: http.MethodPost,
+			route: "/webhook",
+			body: "{\"url\": \"" + stubURL + "\", \"events\": [\"banner.created\"]}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusForbidden,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create webhook with relative url",
+			httpMethod: http.MethodPost,
+			route: "/webhook",
+			body: "{\"url\": \"/hook\", \"events\": [\"banner.created\"]}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create webhook with unknown event",
+			httpMethod: http.MethodPost,
+			route: "/webhook",
+			body: "{\"url\": \"" + stubURL + "\", \"events\": [\"banner.viewed\"]}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create webhook with short secret",
+			httpMethod: http.MethodPost,
+			route: "/webhook",
+			body: "{\"url\": \"" + stubURL + "\", \"events\": [\"banner.created\"], \"secret\": \"short\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "create webhook",
+			httpMethod: http.MethodPost,
+			route: "/webhook",
+			body: "{\"url\": \"" + stubURL + "\", \"events\": [\"banner.created\", \"banner.updated\", \"banner.version_chosen\", \"banner.deleted\"]}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &created,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	require.NotEmpty(t, created.Secret)
+	secret.Store(created.Secret)
+
+	// the other tests may change banners meanwhile, so only the events of the banners of this test are checked
+	next := func(bannerID int) received {
+		for {
+			select {
+			case event := <-events:
+				if event.event.BannerID == bannerID {
+					return event
+				}
+			case <-time.After(time.Second * 15):
+				t.Fatal("no webhook delivery received")
+				return received{}
+			}
+		}
+	}
+
+	send := func(httpMethod string, route string, body string, expectedStatus int, parsedBody interface{}) {
+		req, err := buildRequest(httpMethod, route, body, [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, expectedStatus, parsedBody, parsedBody != nil)
+	}
+
+	send(http.MethodPost, "/banner", "{\"tag_ids\": [2600], \"feature_id\": 2600, \"content\": {\"title\": \"hooked\"}, \"is_active\": true}", http.StatusCreated, &firstID)
+
+	event := next(firstID.ID)
+	require.True(t, event.valid)
+	require.Equal(t, "banner.created", event.event.Event)
+	require.NotZero(t, event.event.VersionID)
+
+	send(http.MethodPatch, "/banner/"+strconv.Itoa(firstID.ID), "{\"content\": {\"title\": \"updated\"}}", http.StatusOK, nil)
+
+	event = next(firstID.ID)
+	require.True(t, event.valid)
+	require.Equal(t, "banner.updated", event.event.Event)
+
+	send(http.MethodDelete, "/banner?feature_id=2600", "", http.StatusAccepted, nil)
+
+	event = next(firstID.ID)
+	require.True(t, event.valid)
+	require.Equal(t, "banner.deleted", event.event.Event)
+	require.Zero(t, event.event.VersionID)
+
+	stubStatus.Store(http.StatusInternalServerError)
+
+	send(http.MethodPost, "/banner", "{\"tag_ids\": [2601], \"feature_id\": 2601, \"content\": {\"title\": \"failed\"}, \"is_active\": true}", http.StatusCreated, &secondID)
+
+	event = next(secondID.ID)
+	require.Equal(t, "banner.created", event.event.Event)
+
+	// the attempt is recorded after the response is received
+	require.Eventually(t, func() bool {
+		deliveries = nil
+		send(http.MethodGet, "/webhook/"+strconv.Itoa(created.ID)+"/deliveries?status=pending", "", http.StatusOK, &deliveries)
+		return len(deliveries) > 0 && deliveries[0].Attempts == 1
+	}, time.Second*5, time.Millisecond*200)
+
+	require.Len(t, deliveries, 1)
+	require.Equal(t, "banner.created", deliveries[0].Event)
+	require.Equal(t, http.StatusInternalServerError, deliveries[0].LastStatusCode)
+
+	send(http.MethodGet, "/webhook/"+strconv.Itoa(created.ID)+"/deliveries?status=sent", "", http.StatusBadRequest, nil)
+
+	deliveries = nil
+	send(http.MethodGet, "/webhook/"+strconv.Itoa(created.ID)+"/deliveries?status=delivered", "", http.StatusOK, &deliveries)
+	require.GreaterOrEqual(t, len(deliveries), 3)
+	require.Equal(t, "banner.deleted", deliveries[0].Event)
+	require.Equal(t, http.StatusNoContent, deliveries[0].LastStatusCode)
+
+	send(http.MethodDelete, "/webhook/"+strconv.Itoa(created.ID), "", http.StatusNoContent, nil)
+	send(http.MethodGet, "/webhook/"+strconv.Itoa(created.ID)+"/deliveries", "", http.StatusNotFound, nil)
+	send(http.MethodDelete, "/banner/"+strconv.Itoa(secondID.ID), "", http.StatusNoContent, nil)
+}
+
+func TestAudit(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData, userAuthData auth
+	var created bannerID
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin29\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "register user",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"user29\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &userAuthData,
+		},
+		{
+			caseName: "create banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2700], \"feature_id\": 2700, \"content\": {\"title\": \"audited\"}, \"is_active\": false}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"X-Request-ID", "e2e-audit-create"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &created,
+		},
+		{
+			caseName: "user search audit",
+			httpMethod: http.MethodGet,
+			route: "/audit",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusForbidden,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "search audit with invalid time",
+			httpMethod: http.MethodGet,
+			route: "/audit?from=yesterday",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "search audit with empty time range",
+			httpMethod: http.MethodGet,
+			route: "/audit?from=2024-04-14T00:00:00Z&to=2024-04-14T00:00:00Z",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "search audit with invalid banner id",
+			httpMethod: http.MethodGet,
+			route: "/audit?banner_id=abc",
+			body: "",
+			headers: [][2]string{},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	var token string
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		if strings.HasPrefix(testCase.caseName, "user") {
+			token = userAuthData.Token
+		} else {
+			token = adminAuthData.Token
+		}
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	send := func(httpMethod string, route string, body string, expectedStatus int, parsedBody interface{}) {
+		req, err := buildRequest(httpMethod, route, body, [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, expectedStatus, parsedBody, parsedBody != nil)
+	}
+
+	send(http.MethodPatch, "/banner/"+strconv.Itoa(created.ID), "{\"is_active\": true}", http.StatusOK, nil)
+
+	var entries []auditEntry
+	send(http.MethodGet, "/audit?actor=admin29&banner_id="+strconv.Itoa(created.ID), "", http.StatusOK, &entries)
+	require.Len(t, entries, 2)
+
+	require.Equal(t, "banner.update", entries[0].Action)
+	require.Equal(t, "admin29", entries[0].Actor)
+	require.Equal(t, created.ID, entries[0].BannerID)
+	require.NotZero(t, entries[0].VersionID)
+	require.JSONEq(t, `{"before": false, "after": true}`, string(entries[0].Diff["is_active"]))
+	require.Len(t, entries[0].RequestID, 32)
+
+	require.Equal(t, "banner.create", entries[1].Action)
+	require.Equal(t, "e2e-audit-create", entries[1].RequestID)
+	require.JSONEq(t, `{"before": null, "after": [2700]}`, string(entries[1].Diff["tag_ids"]))
+
+	entries = nil
+	send(http.MethodGet, "/audit?actor=user29", "", http.StatusOK, &entries)
+	require.Empty(t, entries)
+
+	entries = nil
+	send(http.MethodGet, "/audit?banner_id="+strconv.Itoa(created.ID)+"&from="+time.Now().Add(time.Hour).UTC().Format(time.RFC3339), "", http.StatusOK, &entries)
+	require.Empty(t, entries)
+
+	send(http.MethodDelete, "/banner/"+strconv.Itoa(created.ID), "", http.StatusNoContent, nil)
+
+	entries = nil
+	send(http.MethodGet, "/audit?banner_id="+strconv.Itoa(created.ID)+"&limit=1", "", http.StatusOK, &entries)
+	require.Len(t, entries, 1)
+	require.Equal(t, "banner.delete", entries[0].Action)
+	require.JSONEq(t, `{"before": true, "after": null}`, string(entries[0].Diff["is_active"]))
+}
+
+func TestFeatureSchemaReferences(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	client := http.Client{}
+	var adminAuthData auth
+	var invalidID, validID bannerID
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin30\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "create banner before schema",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2800], \"feature_id\": 2800, \"content\": {\"title\": 5}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &invalidID,
+		},
+		{
+			caseName: "create banner conforming to schema",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [2801], \"feature_id\": 2800, \"content\": {\"title\": \"hello\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &validID,
+		},
+		{
+			caseName: "set schema",
+			httpMethod: http.MethodPut,
+			route: "/feature/2800/schema",
+			body: "{\"type\": \"object\", \"required\": [\"title\"], \"properties\": {\"title\": {\"type\": \"string\", \"minLength\": 1}}}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusOK,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+		{
+			caseName: "set schema with unsupported keyword",
+			httpMethod: http.MethodPut,
+			route: "/feature/2800/schema",
+			body: "{\"type\": \"object\", \"properties\": {\"url\": {\"type\": \"string\", \"format\": \"uri\"}}}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusBadRequest,
+			requireParsing: false,
+			parsedBody: nil,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", adminAuthData.Token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	send := func(httpMethod string, route string, body string, expectedStatus int, parsedBody interface{}) {
+		req, err := buildRequest(httpMethod, route, body, [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, expectedStatus, parsedBody, parsedBody != nil)
+	}
+
+	send(http.MethodPatch, "/banner/"+strconv.Itoa(validID.ID), "{\"content\": {\"title\": \"world\"}}", http.StatusOK, nil)
+
+	var invalidVersions, validVersions []versionListElement
+	send(http.MethodGet, "/banner_versions/"+strconv.Itoa(invalidID.ID), "", http.StatusOK, &invalidVersions)
+	require.Len(t, invalidVersions, 1)
+
+	send(http.MethodGet, "/banner_versions/"+strconv.Itoa(validID.ID), "", http.StatusOK, &validVersions)
+	require.Len(t, validVersions, 2)
+
+	var violation errorWithDetails
+	body := fmt.Sprintf("{\"feature_id\": 2800, \"tag_id\": 2801, \"variants\": [{\"key\": \"a\", \"version_id\": %d, \"weight\": 50}, {\"key\": \"b\", \"version_id\": %d, \"weight\": 50}]}", validVersions[0].VersionID, invalidVersions[0].VersionID)
+	send(http.MethodPost, "/experiment", body, http.StatusBadRequest, &violation)
+	require.NotEmpty(t, violation.Details)
+	require.Equal(t, "/variants/1/content/title", violation.Details[0].Path)
+
+	var experiment experimentID
+	body = fmt.Sprintf("{\"feature_id\": 2800, \"tag_id\": 2801, \"variants\": [{\"key\": \"a\", \"version_id\": %d, \"weight\": 50}, {\"key\": \"b\", \"version_id\": %d, \"weight\": 50}]}", validVersions[0].VersionID, validVersions[1].VersionID)
+	send(http.MethodPost, "/experiment", body, http.StatusCreated, &experiment)
+
+	violation = errorWithDetails{}
+	body = fmt.Sprintf("{\"variants\": [{\"key\": \"a\", \"version_id\": %d, \"weight\": 50}, {\"key\": \"b\", \"version_id\": %d, \"weight\": 50}]}", invalidVersions[0].VersionID, validVersions[1].VersionID)
+	send(http.MethodPatch, "/experiment/"+strconv.Itoa(experiment.ID), body, http.StatusBadRequest, &violation)
+	require.NotEmpty(t, violation.Details)
+	require.Equal(t, "/variants/0/content/title", violation.Details[0].Path)
+
+	violation = errorWithDetails{}
+	send(http.MethodPut, "/feature/2800/fallback", fmt.Sprintf("{\"banner_id\": %d}", invalidID.ID), http.StatusBadRequest, &violation)
+	require.NotEmpty(t, violation.Details)
+	require.Equal(t, "/content/title", violation.Details[0].Path)
+
+	send(http.MethodGet, "/feature/2800/fallback", "", http.StatusNotFound, nil)
+
+	send(http.MethodPut, "/feature/2800/fallback", fmt.Sprintf("{\"banner_id\": %d}", validID.ID), http.StatusOK, nil)
+}
+
+func TestOutbox(t *testing.T) {
+	cfg := e2eConfig{}
+	if err := env.Parse(&cfg); err != nil {
+		t.Fatalf("Failed to parse env: %v", err)
+	}
+
+	// the service publishes the outbox to this stub, the events of the previous tests are published to it too
+	var mu sync.Mutex
+	var published []outboxEvent
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", cfg.OutboxPort))
+	require.NoError(t, err)
+
+	stub := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var events []outboxEvent
+		if err := json.NewDecoder(r.Body).Decode(&events); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		published = append(published, events...)
+		mu.Unlock()
+
+		w.WriteHeader(http.StatusNoContent)
+	})}
+
+	go stub.Serve(listener)
+	defer stub.Close()
+
+	client := http.Client{}
+	var adminAuthData auth
+	var id bannerID
+
+	var testTable = []testTableElem {
+		{
+			caseName: "register admin",
+			httpMethod: http.MethodPost,
+			route: "/register",
+			body: "{\"login\": \"admin32\",\"password\": \"password\"}",
+			headers: [][2]string{{"Content-Type", "application/json"}, {"admin", "true"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &adminAuthData,
+		},
+		{
+			caseName: "create banner",
+			httpMethod: http.MethodPost,
+			route: "/banner",
+			body: "{\"tag_ids\": [3000], \"feature_id\": 3000, \"content\": {\"title\": \"outbox\"}, \"is_active\": true}",
+			headers: [][2]string{{"Content-Type", "application/json"}},
+			expectedStatus: http.StatusCreated,
+			requireParsing: true,
+			parsedBody: &id,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Log(testCase.caseName)
+
+		req, err := buildRequest(testCase.httpMethod, testCase.route, testCase.body, append(testCase.headers, [2]string{"token", adminAuthData.Token}), cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, testCase.expectedStatus, testCase.parsedBody, testCase.requireParsing)
+	}
+
+	send := func(httpMethod string, route string, body string, expectedStatus int) {
+		req, err := buildRequest(httpMethod, route, body, [][2]string{{"Content-Type", "application/json"}, {"token", adminAuthData.Token}}, cfg)
+		require.NoError(t, err)
+
+		sendReq(t, &client, req, expectedStatus, nil, false)
+	}
+
+	send(http.MethodPatch, "/banner/"+strconv.Itoa(id.ID), "{\"content\": {\"title\": \"updated\"}}", http.StatusOK)
+	send(http.MethodDelete, "/banner/"+strconv.Itoa(id.ID), "", http.StatusNoContent)
+
+	// the delivery is at least once, so the repeated events are dropped by their ids the way a consumer does
+	bannerEvents := func() []string {
+		mu.Lock()
+		defer mu.Unlock()
+
+		seen := make(map[int64]struct{})
+		var types []string
+		for _, event := range published {
+			if _, ok := seen[event.ID]; ok || event.Payload.BannerID != id.ID {
+				continue
+			}
+
+			seen[event.ID] = struct{}{}
+			types = append(types, event.Type)
+		}
+
+		return types
+	}
+
+	require.Eventually(t, func() bool {
+		return len(bannerEvents()) == 3
+	}, time.Second*15, time.Millisecond*200)
+
+	require.ElementsMatch(t, []string{"banner.created", "banner.updated", "banner.deleted"}, bannerEvents())
 }
\ No newline at end of file